@@ -63,6 +63,14 @@ func (acc *Account) Decode(bs []byte) error {
 	return cbor.Unmarshal(bs, &acc.data)
 }
 
+func (acc *Account) MarshalCBOR() ([]byte, error) {
+	return cbor.Marshal(acc.data)
+}
+
+func (acc *Account) UnmarshalCBOR(bs []byte) error {
+	return cbor.Unmarshal(bs, &acc.data)
+}
+
 func (acc Account) MarshalJSON() ([]byte, error) {
 	return json.Marshal(acc.data)
 }
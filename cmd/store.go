@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/zarbchain/zarb-go/config"
+	"github.com/zarbchain/zarb-go/genesis"
+	"github.com/zarbchain/zarb-go/store"
+)
+
+// LoadConfig changes the working directory and loads the genesis and config files of the node.
+func LoadConfig(workingDir string) (*genesis.Genesis, *config.Config, error) {
+	workspace, err := filepath.Abs(workingDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := os.Chdir(workspace); err != nil {
+		return nil, nil, err
+	}
+
+	gen, err := genesis.LoadFromFile("./genesis.json")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	conf, err := config.LoadFromFile("./config.toml")
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := conf.Store.SanityCheck(); err != nil {
+		return nil, nil, err
+	}
+
+	return gen, conf, nil
+}
+
+// OpenStore changes the working directory and opens the store of the node.
+// The node should be stopped, otherwise the store is locked.
+func OpenStore(workingDir string) (*genesis.Genesis, store.Store, error) {
+	gen, conf, err := LoadConfig(workingDir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	st, err := store.NewStore(conf.Store)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return gen, st, nil
+}
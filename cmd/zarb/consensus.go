@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	cli "github.com/jawher/mow.cli"
+	"github.com/zarbchain/zarb-go/cmd"
+	grpcclient "github.com/zarbchain/zarb-go/www/grpc/client"
+)
+
+// Consensus prints the current height, round and votes of a running node
+func Consensus() func(c *cli.Cmd) {
+	return func(c *cli.Cmd) {
+		grpcOpt := c.String(cli.StringOpt{
+			Name:  "e endpoint",
+			Desc:  "gRPC server address of the node",
+			Value: "localhost:9090",
+		})
+
+		c.Spec = "[-e]"
+		c.LongDesc = "Printing the consensus state of a running node, including the validators that haven't voted yet in each round."
+		c.Before = func() { fmt.Println(cmd.ZARB) }
+		c.Action = func() {
+			info, err := grpcclient.GetConsensusInfo(*grpcOpt)
+			if err != nil {
+				cmd.PrintErrorMsg("Couldn't retrieve the consensus state: %v", err)
+				return
+			}
+
+			cmd.PrintInfoMsg("Height:   %v", info.Height)
+			cmd.PrintInfoMsg("Round:    %v", info.Round)
+			cmd.PrintInfoMsg("Step:     %v", info.Step)
+			cmd.PrintInfoMsg("Proposer: %v", info.Proposer)
+			if info.ProposalHash == "" {
+				cmd.PrintWarnMsg("Proposal: none")
+			} else {
+				cmd.PrintInfoMsg("Proposal: %v", info.ProposalHash)
+			}
+
+			for _, r := range info.Rounds {
+				cmd.PrintLine()
+				cmd.PrintInfoMsg("Round %v", r.Round)
+				for _, t := range r.Tallies {
+					cmd.PrintInfoMsg("  %-16s %v/%v", t.Type+":", t.VotedPower, t.TotalPower)
+
+					hashes := make([]string, 0, len(t.BlockPowers))
+					for h := range t.BlockPowers {
+						hashes = append(hashes, h)
+					}
+					sort.Strings(hashes)
+					for _, h := range hashes {
+						cmd.PrintInfoMsg("    %v: %v", h, t.BlockPowers[h])
+					}
+					if len(t.Missing) > 0 {
+						cmd.PrintWarnMsg("    missing: %v", strings.Join(t.Missing, ", "))
+					}
+				}
+			}
+		}
+	}
+}
@@ -5,6 +5,8 @@ import (
 
 	cli "github.com/jawher/mow.cli"
 	"github.com/zarbchain/zarb-go/cmd/zarb/key"
+	"github.com/zarbchain/zarb-go/cmd/zarb/snapshot"
+	"github.com/zarbchain/zarb-go/cmd/zarb/store"
 	"github.com/zarbchain/zarb-go/cmd/zarb/tx"
 )
 
@@ -25,6 +27,17 @@ func zarb() *cli.Cli {
 		k.Command("bond", "Create, sign and publish a bond transaction", tx.BondTx())
 		k.Command("send", "Create, sign and publish a send transactio", tx.SendTx())
 	})
+	app.Command("snapshot", "Export and import a snapshot of the state", func(k *cli.Cmd) {
+		k.Command("export", "Export a snapshot of the state into a file", snapshot.Export())
+		k.Command("import", "Import a snapshot of the state from a file", snapshot.Import())
+	})
+	app.Command("store", "Maintain the store of the node", func(k *cli.Cmd) {
+		k.Command("verify", "Verify the integrity of the store", store.Verify())
+		k.Command("migrate", "Upgrade the database schema of the store", store.Migrate())
+	})
+	app.Command("signer", "Run a remote signer for the validator key", Signer())
+	app.Command("consensus", "Print the consensus state of a running node", Consensus())
+	app.Command("rollback", "Roll back the state to a given height", Rollback())
 	app.Command("version", "Print the zarb version", Version())
 	return app
 }
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+
+	cli "github.com/jawher/mow.cli"
+	"github.com/zarbchain/zarb-go/cmd"
+	"github.com/zarbchain/zarb-go/state"
+)
+
+// Rollback reverts the state of the node to the given height
+func Rollback() func(c *cli.Cmd) {
+	return func(c *cli.Cmd) {
+		workingDirOpt := c.String(cli.StringOpt{
+			Name:  "w working-dir",
+			Desc:  "Working directory of the configuration and genesis files",
+			Value: ".",
+		})
+		heightOpt := c.Int(cli.IntOpt{
+			Name: "height",
+			Desc: "The height to roll back the state to",
+		})
+
+		c.Spec = "[-w] --height"
+		c.LongDesc = "Removing the blocks and transactions above the height and restoring the accounts and validators to their state at that height. The node should be stopped."
+		c.Before = func() { fmt.Println(cmd.ZARB) }
+		c.Action = func() {
+			gen, st, err := cmd.OpenStore(*workingDirOpt)
+			if err != nil {
+				cmd.PrintErrorMsg("Aborted! Unable to open the store: %v", err)
+				return
+			}
+			defer st.Close()
+
+			if err := state.Rollback(gen, st, *heightOpt); err != nil {
+				cmd.PrintErrorMsg("Failed to roll back the state: %v", err)
+				return
+			}
+
+			cmd.PrintLine()
+			cmd.PrintSuccessMsg("State rolled back to height %v. You can start the node now.", *heightOpt)
+		}
+	}
+}
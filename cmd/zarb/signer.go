@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	cli "github.com/jawher/mow.cli"
+	"github.com/zarbchain/zarb-go/cmd"
+	"github.com/zarbchain/zarb-go/crypto/remote"
+	"github.com/zarbchain/zarb-go/logger"
+	"github.com/zarbchain/zarb-go/util"
+)
+
+// Signer runs a signer daemon that keeps the validator key and signs the messages for the node
+func Signer() func(c *cli.Cmd) {
+	return func(c *cli.Cmd) {
+		workingDirOpt := c.String(cli.StringOpt{
+			Name:  "w working-dir",
+			Desc:  "Working directory of the signer. The certificates and the sign state are kept here",
+			Value: "./signer",
+		})
+		listenOpt := c.String(cli.StringOpt{
+			Name:  "l listen",
+			Desc:  "Address to listen for the node connection",
+			Value: "127.0.0.1:26600",
+		})
+		privateKeyOpt := c.String(cli.StringOpt{
+			Name: "p private-key",
+			Desc: "Validator's private key",
+		})
+		keyFileOpt := c.String(cli.StringOpt{
+			Name: "k key-file",
+			Desc: "Path to the encrypted key file contains validator's private key",
+		})
+		authOpt := c.String(cli.StringOpt{
+			Name: "a auth",
+			Desc: "Passphrase of the key file",
+		})
+
+		c.LongDesc = "Running a signer that keeps the validator key out of the node. " +
+			"The node and the signer authenticate each other by their certificates. " +
+			"Copy 'signer_cert.pem' to the node and 'node_cert.pem' from the node to the signer's working directory. " +
+			"Votes and proposals are checked against double signing before signing them."
+		c.Before = func() { fmt.Println(cmd.ZARB) }
+		c.Action = func() {
+			workspace, err := filepath.Abs(*workingDirOpt)
+			if err != nil {
+				cmd.PrintErrorMsg("Aborted! %v", err)
+				return
+			}
+
+			conf := &remote.ServerConfig{
+				Listen:        *listenOpt,
+				CertFile:      filepath.Join(workspace, "signer_cert.pem"),
+				KeyFile:       filepath.Join(workspace, "signer_key.pem"),
+				NodeCertFile:  filepath.Join(workspace, "node_cert.pem"),
+				SignStatePath: filepath.Join(workspace, "sign_state.json"),
+			}
+
+			if _, err := remote.LoadOrGenerateCertificate(conf.CertFile, conf.KeyFile); err != nil {
+				cmd.PrintErrorMsg("Aborted! Unable to load the certificate: %v", err)
+				return
+			}
+			if !util.PathExists(conf.NodeCertFile) {
+				cmd.PrintWarnMsg("Certificate of the node not found. Copy it to: %v", conf.NodeCertFile)
+				cmd.PrintInfoMsg("Certificate of the signer: %v", conf.CertFile)
+				return
+			}
+
+			keyObj, err := retrievePrivateKey(workspace, keyFileOpt, authOpt, privateKeyOpt)
+			if err != nil {
+				cmd.PrintErrorMsg("Aborted! %v", err)
+				return
+			}
+
+			logger.InitLogger(logger.DefaultConfig())
+			server, err := remote.NewServer(conf, keyObj.ToSigner())
+			if err != nil {
+				cmd.PrintErrorMsg("Aborted! Unable to start the signer: %v", err)
+				return
+			}
+			server.Start()
+
+			cmd.PrintInfoMsg("Validator address: %v", keyObj.Address())
+			cmd.PrintInfoMsg("Listening on     : %v", server.Address())
+			cmd.PrintLine()
+
+			cmd.TrapSignal(func() {
+				server.Stop()
+				cmd.PrintInfoMsg("Exiting ...")
+			})
+
+			// run forever
+			select {}
+		}
+	}
+}
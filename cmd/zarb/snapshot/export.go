@@ -0,0 +1,70 @@
+package snapshot
+
+import (
+	"fmt"
+	"path/filepath"
+
+	cli "github.com/jawher/mow.cli"
+	"github.com/zarbchain/zarb-go/cmd"
+	"github.com/zarbchain/zarb-go/state/snapshot"
+)
+
+// Export writes a snapshot of the current state into a file
+func Export() func(c *cli.Cmd) {
+	return func(c *cli.Cmd) {
+		workingDirOpt := c.String(cli.StringOpt{
+			Name:  "w working-dir",
+			Desc:  "Working directory of the configuration and genesis files",
+			Value: ".",
+		})
+		blocksOpt := c.Int(cli.IntOpt{
+			Name: "blocks",
+			Desc: "Number of recent blocks to keep in the snapshot. Default is the transaction to live interval",
+		})
+		fileArg := c.String(cli.StringArg{
+			Name: "FILE",
+			Desc: "Path to the snapshot file",
+		})
+
+		c.LongDesc = "Exporting a snapshot of accounts, validators and recent blocks. The node should be stopped."
+		c.Before = func() { fmt.Println(cmd.ZARB) }
+		c.Action = func() {
+			path, err := filepath.Abs(*fileArg)
+			if err != nil {
+				cmd.PrintErrorMsg("Aborted! %v", err)
+				return
+			}
+
+			gen, st, err := cmd.OpenStore(*workingDirOpt)
+			if err != nil {
+				cmd.PrintErrorMsg("Aborted! Unable to open the store: %v", err)
+				return
+			}
+			defer st.Close()
+
+			recentBlocks := *blocksOpt
+			if recentBlocks == 0 {
+				recentBlocks = gen.Params().TransactionToLiveInterval
+			}
+
+			s, err := snapshot.Export(st, gen, recentBlocks)
+			if err != nil {
+				cmd.PrintErrorMsg("Failed to export the snapshot: %v", err)
+				return
+			}
+
+			if err := s.SaveToFile(path); err != nil {
+				cmd.PrintErrorMsg("Failed to write the snapshot: %v", err)
+				return
+			}
+
+			m := s.Manifest()
+			cmd.PrintLine()
+			cmd.PrintSuccessMsg("Snapshot exported to: %v", path)
+			cmd.PrintInfoMsg("Block height: %v", m.BlockHeight())
+			cmd.PrintInfoMsg("Block hash  : %v", m.BlockHash())
+			cmd.PrintInfoMsg("State hash  : %v", m.StateHash())
+			cmd.PrintInfoMsg("Chunks      : %v", m.TotalChunks())
+		}
+	}
+}
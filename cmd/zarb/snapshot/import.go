@@ -0,0 +1,77 @@
+package snapshot
+
+import (
+	"fmt"
+	"path/filepath"
+
+	cli "github.com/jawher/mow.cli"
+	"github.com/zarbchain/zarb-go/cmd"
+	"github.com/zarbchain/zarb-go/crypto"
+	"github.com/zarbchain/zarb-go/state/snapshot"
+)
+
+// Import restores the state from a snapshot file
+func Import() func(c *cli.Cmd) {
+	return func(c *cli.Cmd) {
+		workingDirOpt := c.String(cli.StringOpt{
+			Name:  "w working-dir",
+			Desc:  "Working directory of the configuration and genesis files",
+			Value: ".",
+		})
+		blockHashOpt := c.String(cli.StringOpt{
+			Name: "block-hash",
+			Desc: "Trusted hash of the last block in the snapshot",
+		})
+		stateHashOpt := c.String(cli.StringOpt{
+			Name: "state-hash",
+			Desc: "Trusted state hash of the snapshot",
+		})
+		fileArg := c.String(cli.StringArg{
+			Name: "FILE",
+			Desc: "Path to the snapshot file",
+		})
+
+		c.Spec = "[-w] --block-hash --state-hash FILE"
+		c.LongDesc = "Importing a snapshot into an empty store. The snapshot is checked against the trusted block hash and state hash."
+		c.Before = func() { fmt.Println(cmd.ZARB) }
+		c.Action = func() {
+			blockHash, err := crypto.HashFromString(*blockHashOpt)
+			if err != nil {
+				cmd.PrintErrorMsg("Invalid block hash: %v", err)
+				return
+			}
+			stateHash, err := crypto.HashFromString(*stateHashOpt)
+			if err != nil {
+				cmd.PrintErrorMsg("Invalid state hash: %v", err)
+				return
+			}
+
+			path, err := filepath.Abs(*fileArg)
+			if err != nil {
+				cmd.PrintErrorMsg("Aborted! %v", err)
+				return
+			}
+
+			s, err := snapshot.LoadFromFile(path)
+			if err != nil {
+				cmd.PrintErrorMsg("Failed to read the snapshot: %v", err)
+				return
+			}
+
+			gen, st, err := cmd.OpenStore(*workingDirOpt)
+			if err != nil {
+				cmd.PrintErrorMsg("Aborted! Unable to open the store: %v", err)
+				return
+			}
+			defer st.Close()
+
+			if err := s.Restore(st, gen.Hash(), blockHash, stateHash); err != nil {
+				cmd.PrintErrorMsg("Failed to import the snapshot: %v", err)
+				return
+			}
+
+			cmd.PrintLine()
+			cmd.PrintSuccessMsg("Snapshot imported at height %v. You can start the node now.", s.Manifest().BlockHeight())
+		}
+	}
+}
@@ -12,8 +12,10 @@ import (
 	"github.com/zarbchain/zarb-go/cmd"
 	"github.com/zarbchain/zarb-go/config"
 	"github.com/zarbchain/zarb-go/crypto"
+	"github.com/zarbchain/zarb-go/crypto/remote"
 	"github.com/zarbchain/zarb-go/genesis"
 	"github.com/zarbchain/zarb-go/keystore/key"
+	"github.com/zarbchain/zarb-go/logger"
 	"github.com/zarbchain/zarb-go/node"
 	"github.com/zarbchain/zarb-go/util"
 	"github.com/zarbchain/zarb-go/version"
@@ -78,10 +80,13 @@ func Start() func(c *cli.Cmd) {
 				return
 			}
 
-			keyObj, err = retrievePrivateKey(workspace, keyFileOpt, authOpt, privateKeyOpt)
-			if err != nil {
-				cmd.PrintErrorMsg("Aborted! %v", err)
-				return
+			// the key file is read after changing the working directory
+			if *keyFileOpt != "" {
+				*keyFileOpt, err = filepath.Abs(*keyFileOpt)
+				if err != nil {
+					cmd.PrintErrorMsg("Aborted! %v", err)
+					return
+				}
 			}
 
 			// change working directory
@@ -120,7 +125,25 @@ func Start() func(c *cli.Cmd) {
 				return
 			}
 
-			validatorAddr := keyObj.Address()
+			var signer crypto.Signer
+			if conf.RemoteSigner.Address != "" {
+				// The validator key is kept by the remote signer
+				logger.InitLogger(conf.Logger)
+				signer, err = remote.NewSigner(conf.RemoteSigner)
+				if err != nil {
+					cmd.PrintErrorMsg("Aborted! Could not connect to the remote signer. %v", err)
+					return
+				}
+			} else {
+				keyObj, err = retrievePrivateKey(workspace, keyFileOpt, authOpt, privateKeyOpt)
+				if err != nil {
+					cmd.PrintErrorMsg("Aborted! %v", err)
+					return
+				}
+				signer = keyObj.ToSigner()
+			}
+
+			validatorAddr := signer.Address()
 			mintbaseAddr := conf.State.MintbaseAddress
 			if mintbaseAddr == "" {
 				mintbaseAddr = validatorAddr.String()
@@ -130,7 +153,6 @@ func Start() func(c *cli.Cmd) {
 			cmd.PrintInfoMsg("Mintbase address : %v", mintbaseAddr)
 			cmd.PrintLine()
 
-			signer := keyObj.ToSigner()
 			node, err := node.NewNode(gen, conf, signer)
 			if err != nil {
 				cmd.PrintErrorMsg("Could not initialize node. %v", err)
@@ -0,0 +1,42 @@
+package store
+
+import (
+	"fmt"
+
+	cli "github.com/jawher/mow.cli"
+	"github.com/zarbchain/zarb-go/cmd"
+	"github.com/zarbchain/zarb-go/store"
+)
+
+// Migrate upgrades the database schema of the store to the current version
+func Migrate() func(c *cli.Cmd) {
+	return func(c *cli.Cmd) {
+		workingDirOpt := c.String(cli.StringOpt{
+			Name:  "w working-dir",
+			Desc:  "Working directory of the configuration and genesis files",
+			Value: ".",
+		})
+
+		c.Spec = "[-w]"
+		c.LongDesc = "Upgrading the database schema of the store to the current version, step by step. The node should be stopped."
+		c.Before = func() { fmt.Println(cmd.ZARB) }
+		c.Action = func() {
+			_, conf, err := cmd.LoadConfig(*workingDirOpt)
+			if err != nil {
+				cmd.PrintErrorMsg("Aborted! %v", err)
+				return
+			}
+
+			err = store.Migrate(conf.Store, func(from, to int, description string) {
+				cmd.PrintInfoMsg("Migrating from version %v to %v: %v", from, to, description)
+			})
+			if err != nil {
+				cmd.PrintErrorMsg("Failed to migrate the store: %v", err)
+				return
+			}
+
+			cmd.PrintLine()
+			cmd.PrintSuccessMsg("The store is at schema version %v.", store.SchemaVersion)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package store
+
+import (
+	"fmt"
+	"os"
+
+	cli "github.com/jawher/mow.cli"
+	"github.com/zarbchain/zarb-go/cmd"
+	"github.com/zarbchain/zarb-go/logger"
+	"github.com/zarbchain/zarb-go/state"
+	"github.com/zarbchain/zarb-go/store"
+	"github.com/zarbchain/zarb-go/util"
+)
+
+// Verify replays all the blocks of the store and checks the integrity of the store
+func Verify() func(c *cli.Cmd) {
+	return func(c *cli.Cmd) {
+		workingDirOpt := c.String(cli.StringOpt{
+			Name:  "w working-dir",
+			Desc:  "Working directory of the configuration and genesis files",
+			Value: ".",
+		})
+		intervalOpt := c.Int(cli.IntOpt{
+			Name:  "state-hash-interval",
+			Desc:  "Number of blocks between two state hash checks. Zero disables it",
+			Value: 1000,
+		})
+
+		c.Spec = "[-w] [--state-hash-interval]"
+		c.LongDesc = "Verifying the integrity of the store by replaying all the blocks from the genesis. The node should be stopped."
+		c.Before = func() { fmt.Println(cmd.ZARB) }
+		c.Action = func() {
+			if *intervalOpt < 0 {
+				cmd.PrintErrorMsg("Invalid state hash interval: %v", *intervalOpt)
+				return
+			}
+
+			gen, st, err := cmd.OpenStore(*workingDirOpt)
+			if err != nil {
+				cmd.PrintErrorMsg("Aborted! Unable to open the store: %v", err)
+				return
+			}
+			defer st.Close()
+
+			// Blocks are replayed into a temporary store
+			replayConf := store.DefaultConfig()
+			replayConf.Path = util.TempDirPath()
+			defer os.RemoveAll(replayConf.Path)
+			replayStore, err := store.NewStore(replayConf)
+			if err != nil {
+				cmd.PrintErrorMsg("Aborted! Unable to create the replay store: %v", err)
+				return
+			}
+			defer replayStore.Close()
+
+			logConf := logger.DefaultConfig()
+			logConf.Levels["default"] = "warning"
+			logger.InitLogger(logConf)
+
+			cmd.PrintInfoMsg("Verifying the store. It might take a while...")
+			err = state.Verify(gen, st, replayStore, *intervalOpt)
+			if err != nil {
+				if cErr, ok := err.(*state.CorruptionError); ok {
+					cmd.PrintDangerMsg("The store is corrupted at height %v: %v", cErr.Height, cErr.Err)
+					if cErr.Height > 1 {
+						cmd.PrintWarnMsg("You can roll back the state to height %v by `zarb rollback`.", cErr.Height-1)
+					}
+					return
+				}
+				cmd.PrintErrorMsg("Failed to verify the store: %v", err)
+				return
+			}
+
+			cmd.PrintLine()
+			cmd.PrintSuccessMsg("The store is verified.")
+		}
+	}
+}
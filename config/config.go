@@ -8,6 +8,7 @@ import (
 
 	toml "github.com/pelletier/go-toml"
 	"github.com/zarbchain/zarb-go/consensus"
+	"github.com/zarbchain/zarb-go/crypto/remote"
 	"github.com/zarbchain/zarb-go/errors"
 	"github.com/zarbchain/zarb-go/logger"
 	"github.com/zarbchain/zarb-go/network"
@@ -22,30 +23,32 @@ import (
 )
 
 type Config struct {
-	State     *state.Config     `toml:"" comment:"State contains the state of the blockchain."`
-	Store     *store.Config     `toml:"" comment:"Store which write and store the blockchin data using golevel db. "`
-	TxPool    *txpool.Config    `toml:"" comment:"TxPool is pool of unconfirmed transaction."`
-	Consensus *consensus.Config `toml:"" comment:"Consensus configuration."`
-	Network   *network.Config   `toml:"" comment:"Network contains all details of network configuration. Zarb uses lip2p protocol."`
-	Logger    *logger.Config    `toml:"" comment:"Logger contains Output level for logging."`
-	Sync      *sync.Config      `toml:"" comment:"Sync is used for peer to peer connection and synchronizing blockchain and it also contains monkier and its details."`
-	Capnp     *capnp.Config     `toml:"" comment:"Cap’n Proto is an insanely fast data interchange format and capability-based RPC system."`
-	HTTP      *http.Config      `toml:"" comment:"Http configuration."`
-	GRPC      *grpc.Config      `toml:"" comment:"GRPC configuration."`
+	State        *state.Config     `toml:"" comment:"State contains the state of the blockchain."`
+	Store        *store.Config     `toml:"" comment:"Store which write and store the blockchin data using a key-value database."`
+	TxPool       *txpool.Config    `toml:"" comment:"TxPool is pool of unconfirmed transaction."`
+	Consensus    *consensus.Config `toml:"" comment:"Consensus configuration."`
+	Network      *network.Config   `toml:"" comment:"Network contains all details of network configuration. Zarb uses lip2p protocol."`
+	Logger       *logger.Config    `toml:"" comment:"Logger contains Output level for logging."`
+	Sync         *sync.Config      `toml:"" comment:"Sync is used for peer to peer connection and synchronizing blockchain and it also contains monkier and its details."`
+	Capnp        *capnp.Config     `toml:"" comment:"Cap’n Proto is an insanely fast data interchange format and capability-based RPC system."`
+	HTTP         *http.Config      `toml:"" comment:"Http configuration."`
+	GRPC         *grpc.Config      `toml:"" comment:"GRPC configuration."`
+	RemoteSigner *remote.Config    `toml:"" comment:"RemoteSigner keeps the validator key in a separate signer process. Run it by 'zarb signer'."`
 }
 
 func DefaultConfig() *Config {
 	conf := &Config{
-		State:     state.DefaultConfig(),
-		Store:     store.DefaultConfig(),
-		TxPool:    txpool.DefaultConfig(),
-		Consensus: consensus.DefaultConfig(),
-		Network:   network.DefaultConfig(),
-		Sync:      sync.DefaultConfig(),
-		Logger:    logger.DefaultConfig(),
-		Capnp:     capnp.DefaultConfig(),
-		HTTP:      http.DefaultConfig(),
-		GRPC:      grpc.DefaultConfig(),
+		State:        state.DefaultConfig(),
+		Store:        store.DefaultConfig(),
+		TxPool:       txpool.DefaultConfig(),
+		Consensus:    consensus.DefaultConfig(),
+		Network:      network.DefaultConfig(),
+		Sync:         sync.DefaultConfig(),
+		Logger:       logger.DefaultConfig(),
+		Capnp:        capnp.DefaultConfig(),
+		HTTP:         http.DefaultConfig(),
+		GRPC:         grpc.DefaultConfig(),
+		RemoteSigner: remote.DefaultConfig(),
 	}
 
 	return conf
@@ -53,16 +56,17 @@ func DefaultConfig() *Config {
 
 func TestConfig() *Config {
 	conf := &Config{
-		State:     state.TestConfig(),
-		Store:     store.TestConfig(),
-		TxPool:    txpool.TestConfig(),
-		Consensus: consensus.TestConfig(),
-		Network:   network.TestConfig(),
-		Sync:      sync.TestConfig(),
-		Logger:    logger.TestConfig(),
-		Capnp:     capnp.TestConfig(),
-		HTTP:      http.TestConfig(),
-		GRPC:      grpc.TestConfig(),
+		State:        state.TestConfig(),
+		Store:        store.TestConfig(),
+		TxPool:       txpool.TestConfig(),
+		Consensus:    consensus.TestConfig(),
+		Network:      network.TestConfig(),
+		Sync:         sync.TestConfig(),
+		Logger:       logger.TestConfig(),
+		Capnp:        capnp.TestConfig(),
+		HTTP:         http.TestConfig(),
+		GRPC:         grpc.TestConfig(),
+		RemoteSigner: remote.TestConfig(),
 	}
 
 	return conf
@@ -159,5 +163,8 @@ func (conf *Config) SanityCheck() error {
 	if err := conf.HTTP.SanityCheck(); err != nil {
 		return err
 	}
+	if err := conf.RemoteSigner.SanityCheck(); err != nil {
+		return err
+	}
 	return nil
 }
@@ -1,9 +1,11 @@
 package consensus
 
 import (
+	"github.com/zarbchain/zarb-go/consensus/event"
 	"github.com/zarbchain/zarb-go/consensus/proposal"
 	"github.com/zarbchain/zarb-go/consensus/vote"
 	"github.com/zarbchain/zarb-go/crypto"
+	"github.com/zarbchain/zarb-go/util"
 )
 
 type changeProposerState struct {
@@ -12,6 +14,11 @@ type changeProposerState struct {
 
 func (s *changeProposerState) enter() {
 	s.logger.Info("Requesting for chaning proposer", "proposer", s.proposer(s.round).Address())
+	s.publishEvent(event.Event{
+		Type:    event.TypeChangeProposer,
+		Round:   s.round,
+		Address: s.proposer(s.round).Address(),
+	})
 	s.signAddVote(vote.VoteTypeChangeProposer, crypto.UndefHash)
 
 	s.decide()
@@ -21,8 +28,12 @@ func (s *changeProposerState) decide() {
 	voteset := s.log.ChangeProposerVoteSet(s.round)
 	if voteset.QuorumHash() != nil {
 		s.logger.Debug("change proposer has quorum", "proposer", s.proposer(s.round).Address())
+		s.publishQuorumReached(vote.VoteTypeChangeProposer, crypto.UndefHash)
 		s.round++
 
+		s.roundStartTime = util.Now()
+		s.publishEvent(event.Event{Type: event.TypeNewRound, Round: s.round})
+
 		s.enterNewState(s.proposeState)
 	}
 }
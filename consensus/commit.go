@@ -1,6 +1,7 @@
 package consensus
 
 import (
+	"github.com/zarbchain/zarb-go/consensus/event"
 	"github.com/zarbchain/zarb-go/consensus/proposal"
 	"github.com/zarbchain/zarb-go/consensus/vote"
 )
@@ -46,6 +47,12 @@ func (s *commitState) decide() {
 	}
 
 	s.logger.Info("Block committed, Schedule new height", "precommitQH", precommitQH)
+	s.publishEvent(event.Event{
+		Type:      event.TypeBlockCommitted,
+		Round:     s.round,
+		BlockHash: certBlock.Hash(),
+		Address:   certBlock.Header().ProposerAddress(),
+	})
 	// Now we can broadcast the committed block
 	s.announceNewBlock(s.height, certBlock, cert)
 
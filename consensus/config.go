@@ -10,6 +10,8 @@ type Config struct {
 	QueryProposalTimeout  time.Duration `toml:"" comment:"QueryProposalTimeout which query the network if propsal does not exist.Default is 1 second."`
 	ChangeProposerTimeout time.Duration `toml:"" comment:"ChangeProposerTimeout if current proposer failed to create the block .Default is 6 second."`
 	ChangeProposerDelta   time.Duration `toml:"" comment:"ChangeProposerDelta which increase proposer timeout by round.Default is 2 second."`
+	WALPath               string        `toml:"" comment:"WALPath is the path of the write-ahead log file that keeps our votes and the received proposals. It is replayed after restarting the node. Empty path disables it. Default is ./data/cs.wal"`
+	SignStatePath         string        `toml:"" comment:"SignStatePath is the path of the file that keeps our last signed votes and proposals to prevent double signing. Empty path keeps it in memory. Default is ./data/sign_state.json"`
 }
 
 func DefaultConfig() *Config {
@@ -17,6 +19,8 @@ func DefaultConfig() *Config {
 		QueryProposalTimeout:  1 * time.Second,
 		ChangeProposerTimeout: 6 * time.Second,
 		ChangeProposerDelta:   2 * time.Second,
+		WALPath:               "data/cs.wal",
+		SignStatePath:         "data/sign_state.json",
 	}
 }
 
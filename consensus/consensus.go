@@ -6,9 +6,12 @@ import (
 	"time"
 
 	"github.com/zarbchain/zarb-go/block"
+	"github.com/zarbchain/zarb-go/consensus/event"
+	"github.com/zarbchain/zarb-go/consensus/guard"
 	"github.com/zarbchain/zarb-go/consensus/log"
 	"github.com/zarbchain/zarb-go/consensus/proposal"
 	"github.com/zarbchain/zarb-go/consensus/vote"
+	"github.com/zarbchain/zarb-go/consensus/wal"
 	"github.com/zarbchain/zarb-go/crypto"
 	"github.com/zarbchain/zarb-go/logger"
 	"github.com/zarbchain/zarb-go/state"
@@ -22,10 +25,14 @@ type consensus struct {
 
 	config              *Config
 	log                 *log.Log
-	signer              crypto.Signer
+	wal                 *wal.WAL
+	signer              *guard.Signer
 	state               state.Facade
 	height              int
 	round               int
+	heightStartTime     time.Time
+	roundStartTime      time.Time
+	eventBus            *event.Bus
 	newHeightState      consState
 	proposeState        consState
 	prepareState        consState
@@ -42,17 +49,35 @@ func NewConsensus(
 	state state.Facade,
 	signer crypto.Signer,
 	broadcastCh chan payload.Payload) (Consensus, error) {
+	signStatePath := ""
+	if conf.SignStatePath != "" {
+		signStatePath = util.MakeAbs(conf.SignStatePath)
+	}
+	guardedSigner, err := guard.NewSigner(signer, signStatePath)
+	if err != nil {
+		return nil, err
+	}
+
 	cs := &consensus{
 		config:      conf,
 		state:       state,
 		broadcastCh: broadcastCh,
-		signer:      signer,
+		signer:      guardedSigner,
+		eventBus:    event.NewBus(),
 	}
 
 	// Update height later, See enterNewHeight.
 	cs.log = log.NewLog()
 	cs.logger = logger.NewLogger("_consensus", cs)
 
+	if conf.WALPath != "" {
+		w, err := wal.Open(util.MakeAbs(conf.WALPath))
+		if err != nil {
+			return nil, err
+		}
+		cs.wal = w
+	}
+
 	cs.newHeightState = &newHeightState{cs}
 	cs.proposeState = &proposeState{cs}
 	cs.prepareState = &prepareState{cs, false}
@@ -72,6 +97,17 @@ func (cs *consensus) Start() error {
 }
 
 func (cs *consensus) Stop() {
+	cs.lk.Lock()
+	defer cs.lk.Unlock()
+
+	if cs.wal != nil {
+		if err := cs.wal.Close(); err != nil {
+			cs.logger.Error("Unable to close the write-ahead log", "err", err)
+		}
+	}
+	if err := cs.signer.Close(); err != nil {
+		cs.logger.Error("Unable to release the sign state", "err", err)
+	}
 }
 
 func (cs *consensus) Fingerprint() string {
@@ -134,6 +170,58 @@ func (cs *consensus) HasVote(hash crypto.Hash) bool {
 	return cs.log.HasVote(hash)
 }
 
+func (cs *consensus) Info() *Info {
+	cs.lk.RLock()
+	defer cs.lk.RUnlock()
+
+	info := &Info{
+		Height: cs.height,
+		Round:  cs.round,
+		Rounds: make([]RoundInfo, 0),
+	}
+	if cs.currentState != nil {
+		info.Step = cs.currentState.name()
+	}
+	if cs.round < 0 {
+		// Not started yet
+		return info
+	}
+	if proposer := cs.state.Proposer(cs.round); proposer != nil {
+		info.Proposer = proposer.Address()
+	}
+	if p := cs.log.RoundProposal(cs.round); p != nil {
+		info.ProposalHash = p.Block().Hash()
+	}
+	for r := 0; r <= cs.round; r++ {
+		m := cs.log.RoundMessages(r)
+		if m == nil {
+			continue
+		}
+		ri := RoundInfo{Round: r}
+		for _, t := range []vote.Type{vote.VoteTypePrepare, vote.VoteTypePrecommit, vote.VoteTypeChangeProposer} {
+			ri.Tallies = append(ri.Tallies, makeVoteTally(m.VoteSet(t)))
+		}
+		info.Rounds = append(info.Rounds, ri)
+	}
+	return info
+}
+
+// SubscribeEvents subscribes to the events of the consensus.
+// The subscriber should read the events fast enough, otherwise they are dropped.
+func (cs *consensus) SubscribeEvents(capacity int) *event.Subscription {
+	return cs.eventBus.Subscribe(capacity)
+}
+
+// publishEvent sets the height and the timing of the event and publishes it
+func (cs *consensus) publishEvent(e event.Event) {
+	now := util.Now()
+	e.Height = cs.height
+	e.Time = now
+	e.HeightDuration = now.Sub(cs.heightStartTime)
+	e.RoundDuration = now.Sub(cs.roundStartTime)
+	cs.eventBus.Publish(e)
+}
+
 func (cs *consensus) enterNewState(s consState) {
 	cs.currentState = s
 	cs.currentState.enter()
@@ -152,13 +240,11 @@ func (cs *consensus) MoveToNewHeight() {
 
 func (cs *consensus) scheduleTimeout(duration time.Duration, height int, round int, target tickerTarget) {
 	ti := &ticker{duration, height, round, target}
-	timer := time.NewTimer(duration)
 	cs.logger.Debug("New timer scheduled ⏱️", "duration", duration, "height", height, "round", round, "target", target)
 
-	go func() {
-		<-timer.C
+	util.AfterFunc(duration, func() {
 		cs.handleTimeout(ti)
-	}()
+	})
 }
 
 func (cs *consensus) SetProposal(p *proposal.Proposal) {
@@ -191,8 +277,22 @@ func (cs *consensus) SetProposal(p *proposal.Proposal) {
 }
 
 func (cs *consensus) doSetProposal(p *proposal.Proposal) {
+	if cs.wal != nil {
+		if err := cs.wal.WriteProposal(p); err != nil {
+			cs.logger.Error("Unable to write the proposal into the write-ahead log", "proposal", p, "err", err)
+			return
+		}
+	}
+
 	cs.logger.Info("Proposal set", "proposal", p)
 	cs.log.SetRoundProposal(p.Round(), p)
+
+	cs.publishEvent(event.Event{
+		Type:      event.TypeProposalReceived,
+		Round:     p.Round(),
+		BlockHash: p.Block().Hash(),
+		Address:   p.Block().Header().ProposerAddress(),
+	})
 }
 
 func (cs *consensus) handleTimeout(t *ticker) {
@@ -231,9 +331,31 @@ func (cs *consensus) doAddVote(v *vote.Vote) {
 	err := cs.log.AddVote(v)
 	if err != nil {
 		cs.logger.Error("Error on adding a vote", "vote", v, "err", err)
+		return
 	}
 
 	cs.logger.Debug("New vote added", "vote", v)
+	cs.publishVoteAdded(v)
+}
+
+func (cs *consensus) publishVoteAdded(v *vote.Vote) {
+	cs.publishEvent(event.Event{
+		Type:      event.TypeVoteAdded,
+		Round:     v.Round(),
+		BlockHash: v.BlockHash(),
+		VoteType:  v.Type(),
+		Address:   v.Signer(),
+	})
+}
+
+// publishQuorumReached publishes the event when the votes of this round reach the quorum
+func (cs *consensus) publishQuorumReached(voteType vote.Type, hash crypto.Hash) {
+	cs.publishEvent(event.Event{
+		Type:      event.TypeQuorumReached,
+		Round:     cs.round,
+		BlockHash: hash,
+		VoteType:  voteType,
+	})
 }
 
 func (cs *consensus) proposer(round int) *validator.Validator {
@@ -247,17 +369,97 @@ func (cs *consensus) signAddVote(msgType vote.Type, hash crypto.Hash) {
 		return
 	}
 
-	// Sign the vote
+	// Sign the vote with its block, so the signer can bind the vote to its height
+	var b *block.Block
+	if p := cs.log.RoundProposal(cs.round); p != nil && p.IsForBlock(hash) {
+		b = p.Block()
+	}
 	v := vote.NewVote(msgType, cs.height, cs.round, hash, address)
-	cs.signer.SignMsg(v)
-	cs.logger.Info("Our vote signed and broadcasted", "vote", v)
+	if err := cs.signer.SignVote(v, b); err != nil {
+		cs.logger.Error("Refused to sign our vote", "vote", v, "err", err)
+		return
+	}
+
+	// We might have voted before restarting the node
+	if cs.log.HasVote(v.Hash()) {
+		cs.logger.Info("Our vote exists, broadcasting it again", "vote", v)
+		cs.broadcastVote(v)
+		return
+	}
 
+	// Adding the vote to the log makes sure it doesn't conflict with our previous votes
 	err := cs.log.AddVote(v)
 	if err != nil {
 		cs.logger.Error("Error on adding our vote!", "err", err, "vote", v)
-	} else {
-		cs.broadcastVote(v)
+		return
+	}
+
+	// The vote should be saved before broadcasting it
+	if cs.wal != nil {
+		if err := cs.wal.WriteVote(v); err != nil {
+			cs.logger.Error("Unable to write our vote into the write-ahead log", "vote", v, "err", err)
+			return
+		}
+	}
+
+	cs.logger.Info("Our vote signed and broadcasted", "vote", v)
+	cs.publishVoteAdded(v)
+	cs.broadcastVote(v)
+}
+
+// ourVote returns our vote in the given round, if we have voted
+func (cs *consensus) ourVote(msgType vote.Type, round int) *vote.Vote {
+	m := cs.log.RoundMessages(round)
+	if m == nil {
+		return nil
+	}
+	for _, v := range m.AllVotes() {
+		if v.Type() == msgType && v.Signer().EqualsTo(cs.signer.Address()) {
+			return v
+		}
+	}
+	return nil
+}
+
+// replayWAL restores our votes and the proposals of the current height from the write-ahead log.
+// The round is moved to the last round that we have voted in.
+// The messages of the previous heights are removed from the log.
+func (cs *consensus) replayWAL() {
+	if cs.wal == nil {
+		return
+	}
+
+	msgs, err := cs.wal.Messages()
+	if err != nil {
+		cs.logger.Error("Unable to read the write-ahead log", "err", err)
+		return
+	}
+
+	replayed := 0
+	for _, m := range msgs {
+		if v := m.Vote(); v != nil && v.Height() == cs.height {
+			if err := cs.log.AddVote(v); err != nil {
+				cs.logger.Error("Unable to replay our vote", "vote", v, "err", err)
+				continue
+			}
+			if v.Round() > cs.round {
+				cs.round = v.Round()
+			}
+			replayed++
+		}
+		if p := m.Proposal(); p != nil && p.Height() == cs.height {
+			cs.log.SetRoundProposal(p.Round(), p)
+			replayed++
+		}
+	}
+
+	if replayed == 0 {
+		if err := cs.wal.Reset(); err != nil {
+			cs.logger.Error("Unable to reset the write-ahead log", "err", err)
+		}
+		return
 	}
+	cs.logger.Info("Write-ahead log replayed", "messages", replayed, "round", cs.round)
 }
 
 func (cs *consensus) queryProposal() {
@@ -10,6 +10,7 @@ import (
 	"github.com/zarbchain/zarb-go/account"
 	"github.com/zarbchain/zarb-go/block"
 	"github.com/zarbchain/zarb-go/committee"
+	"github.com/zarbchain/zarb-go/consensus/event"
 	"github.com/zarbchain/zarb-go/consensus/proposal"
 	"github.com/zarbchain/zarb-go/consensus/vote"
 	"github.com/zarbchain/zarb-go/crypto"
@@ -271,22 +272,22 @@ func makeProposal(t *testing.T, height, round int) *proposal.Proposal {
 		pb, err := tConsX.state.ProposeBlock(round)
 		require.NoError(t, err)
 		p = proposal.NewProposal(height, round, pb)
-		tConsX.signer.SignMsg(p)
+		tSigners[tIndexX].SignMsg(p)
 	case 2:
 		pb, err := tConsY.state.ProposeBlock(round)
 		require.NoError(t, err)
 		p = proposal.NewProposal(height, round, pb)
-		tConsY.signer.SignMsg(p)
+		tSigners[tIndexY].SignMsg(p)
 	case 3:
 		pb, err := tConsB.state.ProposeBlock(round)
 		require.NoError(t, err)
 		p = proposal.NewProposal(height, round, pb)
-		tConsB.signer.SignMsg(p)
+		tSigners[tIndexB].SignMsg(p)
 	case 0, 4:
 		pb, err := tConsP.state.ProposeBlock(round)
 		require.NoError(t, err)
 		p = proposal.NewProposal(height, round, pb)
-		tConsP.signer.SignMsg(p)
+		tSigners[tIndexP].SignMsg(p)
 	}
 
 	return p
@@ -500,3 +501,186 @@ func TestDuplicateProposal(t *testing.T) {
 
 	assert.Equal(t, tConsX.RoundProposal(0).Hash(), p1.Hash())
 }
+
+func newConsensusWithWAL(t *testing.T, cons *consensus, index int, walPath string) *consensus {
+	conf := TestConfig()
+	conf.WALPath = walPath
+	c, err := NewConsensus(conf, cons.state, tSigners[index], make(chan payload.Payload, 100))
+	require.NoError(t, err)
+	return c.(*consensus)
+}
+
+func TestReplayWAL(t *testing.T) {
+	setup(t)
+
+	t.Run("Proposer restarts after proposing", func(t *testing.T) {
+		path := util.TempFilePath()
+		cons := newConsensusWithWAL(t, tConsX, tIndexX, path)
+		testEnterNewHeight(cons)
+		shouldPublishProposal(t, cons, 1, 0)
+		p := cons.RoundProposal(0)
+		require.NotNil(t, p)
+		shouldPublishVote(t, cons, vote.VoteTypePrepare, p.Block().Hash())
+		cons.Stop()
+
+		// Restarting
+		cons = newConsensusWithWAL(t, tConsX, tIndexX, path)
+		testEnterNewHeight(cons)
+		checkHeightRound(t, cons, 1, 0)
+		assert.Equal(t, cons.RoundProposal(0).Hash(), p.Hash())
+		shouldPublishProposal(t, cons, 1, 0)
+		shouldPublishVote(t, cons, vote.VoteTypePrepare, p.Block().Hash())
+		assert.Equal(t, len(cons.RoundVotes(0)), 1)
+		cons.Stop()
+	})
+
+	t.Run("Validator restarts after requesting for changing proposer", func(t *testing.T) {
+		path := util.TempFilePath()
+		cons := newConsensusWithWAL(t, tConsP, tIndexP, path)
+		cons.config.ChangeProposerTimeout = 100 * time.Millisecond
+		testEnterNewHeight(cons)
+		shouldPublishVote(t, cons, vote.VoteTypeChangeProposer, crypto.UndefHash)
+		cons.Stop()
+
+		// Restarting
+		cons = newConsensusWithWAL(t, tConsP, tIndexP, path)
+		testEnterNewHeight(cons)
+		checkHeightRound(t, cons, 1, 0)
+		assert.Equal(t, cons.currentState.name(), cons.changeProposerState.name())
+		shouldPublishVote(t, cons, vote.VoteTypeChangeProposer, crypto.UndefHash)
+
+		// We shouldn't vote for the proposal anymore
+		p := makeProposal(t, 1, 0)
+		cons.SetProposal(p)
+		assert.Nil(t, cons.ourVote(vote.VoteTypePrepare, 0))
+		cons.Stop()
+	})
+
+	t.Run("Messages of previous heights are removed", func(t *testing.T) {
+		path := util.TempFilePath()
+		cons := newConsensusWithWAL(t, tConsX, tIndexX, path)
+		testEnterNewHeight(cons)
+		shouldPublishProposal(t, cons, 1, 0)
+		cons.Stop()
+
+		commitBlockForAllStates(t)
+
+		cons = newConsensusWithWAL(t, tConsX, tIndexX, path)
+		testEnterNewHeight(cons)
+		checkHeightRound(t, cons, 2, 0)
+		msgs, err := cons.wal.Messages()
+		assert.NoError(t, err)
+		for _, m := range msgs {
+			if m.Vote() != nil {
+				assert.Equal(t, m.Vote().Height(), 2)
+			} else {
+				assert.Equal(t, m.Proposal().Height(), 2)
+			}
+		}
+		cons.Stop()
+	})
+}
+
+func TestEvents(t *testing.T) {
+	setup(t)
+
+	eventTypes := func(sub *event.Subscription) []event.Type {
+		types := []event.Type{}
+		for {
+			select {
+			case e := <-sub.Events():
+				assert.Equal(t, e.Height, 2)
+				assert.False(t, e.Time.IsZero())
+				types = append(types, e.Type)
+			default:
+				return types
+			}
+		}
+	}
+
+	commitBlockForAllStates(t) // height 1
+
+	t.Run("Committing a block", func(t *testing.T) {
+		sub := tConsX.SubscribeEvents(100)
+		defer sub.Unsubscribe()
+
+		testEnterNewHeight(tConsX)
+		p := makeProposal(t, 2, 0)
+		tConsX.SetProposal(p)
+		testAddVote(tConsX, vote.VoteTypePrepare, 2, 0, p.Block().Hash(), tIndexY)
+		testAddVote(tConsX, vote.VoteTypePrepare, 2, 0, p.Block().Hash(), tIndexP)
+		testAddVote(tConsX, vote.VoteTypePrecommit, 2, 0, p.Block().Hash(), tIndexY)
+		testAddVote(tConsX, vote.VoteTypePrecommit, 2, 0, p.Block().Hash(), tIndexP)
+
+		assert.Equal(t, eventTypes(sub), []event.Type{
+			event.TypeNewHeight,
+			event.TypeProposalReceived,
+			event.TypeVoteAdded, // our prepare vote
+			event.TypeVoteAdded,
+			event.TypeVoteAdded,
+			event.TypeQuorumReached,
+			event.TypeVoteAdded, // our precommit vote
+			event.TypeVoteAdded,
+			event.TypeVoteAdded,
+			event.TypeQuorumReached,
+			event.TypeBlockCommitted,
+		})
+	})
+
+	t.Run("Changing the proposer", func(t *testing.T) {
+		sub := tConsP.SubscribeEvents(100)
+		defer sub.Unsubscribe()
+
+		tConsP.config.ChangeProposerTimeout = 100 * time.Millisecond
+		testEnterNewHeight(tConsP)
+		shouldPublishVote(t, tConsP, vote.VoteTypeChangeProposer, crypto.UndefHash)
+		testAddVote(tConsP, vote.VoteTypeChangeProposer, 2, 0, crypto.UndefHash, tIndexX)
+		testAddVote(tConsP, vote.VoteTypeChangeProposer, 2, 0, crypto.UndefHash, tIndexY)
+		checkHeightRound(t, tConsP, 2, 1)
+
+		assert.Equal(t, eventTypes(sub), []event.Type{
+			event.TypeNewHeight,
+			event.TypeChangeProposer,
+			event.TypeVoteAdded, // our change-proposer vote
+			event.TypeVoteAdded,
+			event.TypeVoteAdded,
+			event.TypeQuorumReached,
+			event.TypeNewRound,
+		})
+	})
+}
+
+func TestInfo(t *testing.T) {
+	setup(t)
+
+	info := tConsX.Info()
+	assert.Equal(t, info.Step, "new-height")
+	assert.Equal(t, info.Round, -1)
+	assert.Empty(t, info.Rounds)
+
+	commitBlockForAllStates(t) // height 1
+
+	testEnterNewHeight(tConsX)
+	p := makeProposal(t, 2, 0)
+	tConsX.SetProposal(p)
+	testAddVote(tConsX, vote.VoteTypePrepare, 2, 0, p.Block().Hash(), tIndexY)
+
+	info = tConsX.Info()
+	assert.Equal(t, info.Height, 2)
+	assert.Equal(t, info.Round, 0)
+	assert.Equal(t, info.Step, "prepare")
+	assert.Equal(t, info.Proposer, tConsX.state.Proposer(0).Address())
+	assert.Equal(t, info.ProposalHash, p.Block().Hash())
+	require.Len(t, info.Rounds, 1)
+
+	prepare := info.Rounds[0].Tallies[0]
+	assert.Equal(t, prepare.Type, vote.VoteTypePrepare)
+	assert.Equal(t, prepare.VotedPower, prepare.TotalPower/2)
+	assert.Equal(t, prepare.BlockPowers[p.Block().Hash()], prepare.TotalPower/2)
+	assert.ElementsMatch(t, prepare.Missing, []crypto.Address{tSigners[tIndexB].Address(), tSigners[tIndexP].Address()})
+
+	precommit := info.Rounds[0].Tallies[1]
+	assert.Equal(t, precommit.Type, vote.VoteTypePrecommit)
+	assert.Zero(t, precommit.VotedPower)
+	assert.Len(t, precommit.Missing, 4)
+}
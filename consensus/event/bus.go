@@ -0,0 +1,100 @@
+package event
+
+import (
+	"sync"
+)
+
+// Bus delivers the events to the subscribers.
+// Publishing never blocks the consensus. If a subscriber is too slow and its
+// buffer is full, the event is dropped for that subscriber.
+type Bus struct {
+	lk sync.RWMutex
+
+	subs map[*Subscription]bool
+}
+
+type Subscription struct {
+	lk sync.Mutex
+
+	bus     *Bus
+	ch      chan Event
+	dropped int
+	closed  bool
+}
+
+func NewBus() *Bus {
+	return &Bus{
+		subs: make(map[*Subscription]bool),
+	}
+}
+
+// Subscribe returns a subscription with a buffer for `capacity` events
+func (b *Bus) Subscribe(capacity int) *Subscription {
+	b.lk.Lock()
+	defer b.lk.Unlock()
+
+	s := &Subscription{
+		bus: b,
+		ch:  make(chan Event, capacity),
+	}
+	b.subs[s] = true
+	return s
+}
+
+func (b *Bus) Publish(e Event) {
+	b.lk.RLock()
+	defer b.lk.RUnlock()
+
+	for s := range b.subs {
+		s.send(e)
+	}
+}
+
+// NumSubscribers returns the number of the active subscriptions
+func (b *Bus) NumSubscribers() int {
+	b.lk.RLock()
+	defer b.lk.RUnlock()
+
+	return len(b.subs)
+}
+
+func (s *Subscription) send(e Event) {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	if s.closed {
+		return
+	}
+	select {
+	case s.ch <- e:
+	default:
+		s.dropped++
+	}
+}
+
+// Events returns the channel of the events. It is closed after unsubscribing.
+func (s *Subscription) Events() <-chan Event {
+	return s.ch
+}
+
+// Dropped returns the number of the events that are dropped because the buffer was full
+func (s *Subscription) Dropped() int {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	return s.dropped
+}
+
+func (s *Subscription) Unsubscribe() {
+	s.bus.lk.Lock()
+	delete(s.bus.subs, s)
+	s.bus.lk.Unlock()
+
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	if !s.closed {
+		s.closed = true
+		close(s.ch)
+	}
+}
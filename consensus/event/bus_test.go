@@ -0,0 +1,41 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublish(t *testing.T) {
+	bus := NewBus()
+	s1 := bus.Subscribe(10)
+	s2 := bus.Subscribe(1)
+	assert.Equal(t, bus.NumSubscribers(), 2)
+
+	bus.Publish(Event{Type: TypeNewHeight, Height: 1})
+	bus.Publish(Event{Type: TypeNewRound, Height: 1, Round: 1})
+
+	e := <-s1.Events()
+	assert.Equal(t, e.Type, TypeNewHeight)
+	e = <-s1.Events()
+	assert.Equal(t, e.Type, TypeNewRound)
+	assert.Zero(t, s1.Dropped())
+
+	// The buffer of the second subscriber was full
+	e = <-s2.Events()
+	assert.Equal(t, e.Type, TypeNewHeight)
+	assert.Equal(t, s2.Dropped(), 1)
+}
+
+func TestUnsubscribe(t *testing.T) {
+	bus := NewBus()
+	s := bus.Subscribe(10)
+
+	s.Unsubscribe()
+	s.Unsubscribe()
+	assert.Zero(t, bus.NumSubscribers())
+
+	bus.Publish(Event{Type: TypeNewHeight, Height: 1})
+	_, ok := <-s.Events()
+	assert.False(t, ok)
+}
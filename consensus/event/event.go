@@ -0,0 +1,64 @@
+package event
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/zarbchain/zarb-go/consensus/vote"
+	"github.com/zarbchain/zarb-go/crypto"
+)
+
+type Type int
+
+const (
+	TypeNewHeight        = Type(1)
+	TypeNewRound         = Type(2)
+	TypeProposalReceived = Type(3)
+	TypeVoteAdded        = Type(4)
+	TypeQuorumReached    = Type(5)
+	TypeChangeProposer   = Type(6)
+	TypeBlockCommitted   = Type(7)
+)
+
+func (t Type) String() string {
+	switch t {
+	case TypeNewHeight:
+		return "new-height"
+	case TypeNewRound:
+		return "new-round"
+	case TypeProposalReceived:
+		return "proposal-received"
+	case TypeVoteAdded:
+		return "vote-added"
+	case TypeQuorumReached:
+		return "quorum-reached"
+	case TypeChangeProposer:
+		return "change-proposer"
+	case TypeBlockCommitted:
+		return "block-committed"
+	default:
+		return "unknown"
+	}
+}
+
+// Event is emitted by the consensus on each step.
+// The durations show how long it took since the height and the round started.
+type Event struct {
+	Type           Type
+	Height         int
+	Round          int
+	Time           time.Time
+	HeightDuration time.Duration
+	RoundDuration  time.Duration
+
+	// BlockHash is set for proposals, votes, quorums and committed blocks
+	BlockHash crypto.Hash
+	// VoteType is set for votes and quorums
+	VoteType vote.Type
+	// Address is the signer of the vote, the proposer of the proposal or the proposer that is changing
+	Address crypto.Address
+}
+
+func (e Event) String() string {
+	return fmt.Sprintf("{%v %d/%d %v}", e.Type, e.Height, e.Round, e.RoundDuration)
+}
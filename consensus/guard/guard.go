@@ -0,0 +1,258 @@
+package guard
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/zarbchain/zarb-go/block"
+	"github.com/zarbchain/zarb-go/consensus/proposal"
+	"github.com/zarbchain/zarb-go/consensus/vote"
+	"github.com/zarbchain/zarb-go/crypto"
+	"github.com/zarbchain/zarb-go/errors"
+	"github.com/zarbchain/zarb-go/util"
+)
+
+// Signer wraps the signer of the validator and protects it against double signing.
+// It keeps the last signed height, round and block hash for each type of message
+// and refuses to sign a conflicting message.
+//
+// The sign bytes of the votes don't have the height, so a vote for a block of our last signed height
+// could be signed under another height and then be presented as a conflicting vote.
+// To prevent it, the votes are signed with their blocks, and the blocks are bound to their heights
+// by their parents: all the blocks of a height have the same parent, which is the block of the previous height.
+//
+// The sign state is saved in a file before returning the signature,
+// and a lock file prevents two processes from using the same sign state.
+// An empty path keeps the sign state in memory.
+type Signer struct {
+	lk sync.Mutex
+
+	signer crypto.Signer
+	path   string
+	lock   *fileLock
+	state  signState
+}
+
+type signState struct {
+	Proposal       *signedMessage `json:"proposal,omitempty"`
+	Prepare        *signedMessage `json:"prepare,omitempty"`
+	Precommit      *signedMessage `json:"precommit,omitempty"`
+	ChangeProposer *signedMessage `json:"change_proposer,omitempty"`
+}
+
+type signedMessage struct {
+	Height        int         `json:"height"`
+	Round         int         `json:"round"`
+	BlockHash     crypto.Hash `json:"block_hash"`
+	LastBlockHash crypto.Hash `json:"last_block_hash"`
+}
+
+// blockVoteSigner is implemented by the signers that guard the votes by themselves, like the remote signer.
+// They need the block of the vote to bind the vote to its height.
+type blockVoteSigner interface {
+	SignBlockVote(v *vote.Vote, b *block.Block)
+}
+
+// NewSigner loads the sign state from the file and locks it
+func NewSigner(signer crypto.Signer, path string) (*Signer, error) {
+	s := &Signer{
+		signer: signer,
+		path:   path,
+	}
+
+	if path == "" {
+		return s, nil
+	}
+
+	if err := util.Mkdir(filepath.Dir(path)); err != nil {
+		return nil, err
+	}
+	lock, err := lockFile(path + ".lock")
+	if err != nil {
+		return nil, errors.Errorf(errors.ErrGeneric,
+			"unable to lock the sign state. Is another node running with the same key? %v", err)
+	}
+	s.lock = lock
+
+	if util.PathExists(path) {
+		bs, err := util.ReadFile(path)
+		if err != nil {
+			s.lock.unlock()
+			return nil, err
+		}
+		if err := json.Unmarshal(bs, &s.state); err != nil {
+			s.lock.unlock()
+			return nil, errors.Errorf(errors.ErrGeneric, "invalid sign state: %v", err)
+		}
+	}
+
+	return s, nil
+}
+
+func (s *Signer) Address() crypto.Address {
+	return s.signer.Address()
+}
+
+func (s *Signer) PublicKey() crypto.PublicKey {
+	return s.signer.PublicKey()
+}
+
+// SignVote signs the vote, if it doesn't conflict with our previous votes.
+// Prepare and precommit votes should be signed with their blocks.
+// Change-proposer votes have no block and b is ignored.
+func (s *Signer) SignVote(v *vote.Vote, b *block.Block) error {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	var last **signedMessage
+	switch v.Type() {
+	case vote.VoteTypePrepare:
+		last = &s.state.Prepare
+	case vote.VoteTypePrecommit:
+		last = &s.state.Precommit
+	case vote.VoteTypeChangeProposer:
+		last = &s.state.ChangeProposer
+		b = nil
+	default:
+		return errors.Errorf(errors.ErrInvalidVote, "invalid vote type: %v", v.Type())
+	}
+
+	lastBlockHash := crypto.UndefHash
+	if v.Type() != vote.VoteTypeChangeProposer {
+		if b == nil || !b.HashesTo(v.BlockHash()) {
+			return errors.Errorf(errors.ErrInvalidVote, "vote is not signed with its block")
+		}
+		lastBlockHash = b.Header().LastBlockHash()
+	}
+
+	if err := s.checkAndSave(last, v.Height(), v.Round(), v.BlockHash(), lastBlockHash); err != nil {
+		return err
+	}
+	if bs, ok := s.signer.(blockVoteSigner); ok && b != nil {
+		bs.SignBlockVote(v, b)
+	} else {
+		s.signer.SignMsg(v)
+	}
+	if v.Signature() == nil {
+		return errors.Errorf(errors.ErrGeneric, "unable to sign the vote")
+	}
+	return nil
+}
+
+// SignProposal signs the proposal, if it doesn't conflict with our previous proposals
+func (s *Signer) SignProposal(p *proposal.Proposal) error {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	if err := s.checkAndSave(&s.state.Proposal, p.Height(), p.Round(),
+		p.Block().Hash(), p.Block().Header().LastBlockHash()); err != nil {
+		return err
+	}
+	s.signer.SignMsg(p)
+	if p.Signature() == nil {
+		return errors.Errorf(errors.ErrGeneric, "unable to sign the proposal")
+	}
+	return nil
+}
+
+// checkAndSave checks the message against the last signed message of the same type.
+// Signing the same message again is allowed. The new state is saved before signing.
+func (s *Signer) checkAndSave(last **signedMessage, height, round int, blockHash, lastBlockHash crypto.Hash) error {
+	if err := s.checkHeight(height, lastBlockHash); err != nil {
+		return err
+	}
+	if *last != nil {
+		l := *last
+		if height < l.Height || (height == l.Height && round < l.Round) {
+			return errors.Errorf(errors.ErrGeneric,
+				"height or round regression. Last signed %v/%v, got %v/%v", l.Height, l.Round, height, round)
+		}
+		if height == l.Height && round == l.Round {
+			if !blockHash.EqualsTo(l.BlockHash) {
+				return errors.Errorf(errors.ErrGeneric,
+					"conflicting signature at %v/%v. Last signed %v, got %v", height, round, l.BlockHash, blockHash)
+			}
+			return nil
+		}
+	}
+
+	prev := *last
+	*last = &signedMessage{
+		Height:        height,
+		Round:         round,
+		BlockHash:     blockHash,
+		LastBlockHash: lastBlockHash,
+	}
+	if err := s.save(); err != nil {
+		*last = prev
+		return err
+	}
+	return nil
+}
+
+// checkHeight checks that the block with this parent belongs to the height,
+// by comparing it with the parents of the last signed messages of all types.
+func (s *Signer) checkHeight(height int, lastBlockHash crypto.Hash) error {
+	if lastBlockHash.IsUndef() {
+		return nil
+	}
+	for _, l := range []*signedMessage{s.state.Proposal, s.state.Prepare, s.state.Precommit} {
+		if l == nil || l.LastBlockHash.IsUndef() {
+			continue
+		}
+		if l.Height == height && !l.LastBlockHash.EqualsTo(lastBlockHash) {
+			return errors.Errorf(errors.ErrGeneric,
+				"block is not linked to the previous block of height %v", height-1)
+		}
+		if l.Height != height && l.LastBlockHash.EqualsTo(lastBlockHash) {
+			return errors.Errorf(errors.ErrGeneric,
+				"block belongs to height %v, not %v", l.Height, height)
+		}
+	}
+	return nil
+}
+
+// save writes the sign state into a temporary file and then renames it,
+// so the sign state file is never left half-written.
+func (s *Signer) save() error {
+	if s.path == "" {
+		return nil
+	}
+
+	bs, err := json.MarshalIndent(s.state, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(bs); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// Close releases the lock of the sign state
+func (s *Signer) Close() error {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	if s.lock == nil {
+		return nil
+	}
+	err := s.lock.unlock()
+	s.lock = nil
+	return err
+}
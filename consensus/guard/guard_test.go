@@ -0,0 +1,135 @@
+package guard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zarbchain/zarb-go/block"
+	"github.com/zarbchain/zarb-go/consensus/proposal"
+	"github.com/zarbchain/zarb-go/consensus/vote"
+	"github.com/zarbchain/zarb-go/crypto"
+	"github.com/zarbchain/zarb-go/util"
+)
+
+func signVote(s *Signer, voteType vote.Type, height, round int, b *block.Block) error {
+	hash := crypto.UndefHash
+	if b != nil {
+		hash = b.Hash()
+	}
+	v := vote.NewVote(voteType, height, round, hash, s.Address())
+	return s.SignVote(v, b)
+}
+
+// generateTestBlocks generates the blocks of a height, they have the same parent
+func generateTestBlocks(lastBlockHash crypto.Hash) (*block.Block, *block.Block) {
+	b1, _ := block.GenerateTestBlock(nil, &lastBlockHash)
+	b2, _ := block.GenerateTestBlock(nil, &lastBlockHash)
+	return b1, b2
+}
+
+func TestSignVote(t *testing.T) {
+	signer := crypto.GenerateTestSigner()
+	s, err := NewSigner(signer, "")
+	require.NoError(t, err)
+
+	b1, b2 := generateTestBlocks(crypto.GenerateTestHash())
+
+	v := vote.NewVote(vote.VoteTypePrepare, 5, 1, b1.Hash(), s.Address())
+	assert.NoError(t, s.SignVote(v, b1))
+	assert.NoError(t, v.Verify(signer.PublicKey()))
+
+	t.Run("Signing the same vote again", func(t *testing.T) {
+		assert.NoError(t, signVote(s, vote.VoteTypePrepare, 5, 1, b1))
+	})
+
+	t.Run("Conflicting vote", func(t *testing.T) {
+		v := vote.NewVote(vote.VoteTypePrepare, 5, 1, b2.Hash(), s.Address())
+		assert.Error(t, s.SignVote(v, b2))
+		assert.Nil(t, v.Signature())
+	})
+
+	t.Run("Vote without its block", func(t *testing.T) {
+		v := vote.NewVote(vote.VoteTypePrepare, 5, 2, b2.Hash(), s.Address())
+		assert.Error(t, s.SignVote(v, nil))
+		assert.Error(t, s.SignVote(v, b1))
+		assert.Nil(t, v.Signature())
+	})
+
+	t.Run("Height or round regression", func(t *testing.T) {
+		assert.Error(t, signVote(s, vote.VoteTypePrepare, 5, 0, b1))
+		assert.Error(t, signVote(s, vote.VoteTypePrepare, 4, 2, b1))
+	})
+
+	t.Run("Other vote types", func(t *testing.T) {
+		assert.NoError(t, signVote(s, vote.VoteTypePrecommit, 5, 1, b1))
+		assert.NoError(t, signVote(s, vote.VoteTypeChangeProposer, 5, 1, nil))
+		assert.Error(t, signVote(s, vote.VoteTypePrecommit, 5, 1, b2))
+	})
+
+	t.Run("Block of our last signed height under another height", func(t *testing.T) {
+		// The signature would be a conflicting vote at height 5
+		assert.Error(t, signVote(s, vote.VoteTypePrepare, 6, 1, b2))
+		assert.Error(t, signVote(s, vote.VoteTypePrecommit, 7, 1, b2))
+	})
+
+	t.Run("Block that is not linked to the previous height", func(t *testing.T) {
+		b3, _ := generateTestBlocks(crypto.GenerateTestHash())
+		assert.Error(t, signVote(s, vote.VoteTypePrepare, 5, 3, b3))
+	})
+
+	t.Run("Next round and height", func(t *testing.T) {
+		b6, _ := generateTestBlocks(b1.Hash())
+		assert.NoError(t, signVote(s, vote.VoteTypePrepare, 5, 2, b2))
+		assert.NoError(t, signVote(s, vote.VoteTypePrepare, 6, 0, b6))
+	})
+}
+
+func TestSignProposal(t *testing.T) {
+	s, err := NewSigner(crypto.GenerateTestSigner(), "")
+	require.NoError(t, err)
+
+	b1, b2 := generateTestBlocks(crypto.GenerateTestHash())
+
+	assert.NoError(t, s.SignProposal(proposal.NewProposal(5, 0, b1)))
+	assert.NoError(t, s.SignProposal(proposal.NewProposal(5, 0, b1)))
+	assert.Error(t, s.SignProposal(proposal.NewProposal(5, 0, b2)))
+	assert.NoError(t, s.SignProposal(proposal.NewProposal(5, 1, b2)))
+}
+
+func TestPersistentSignState(t *testing.T) {
+	path := util.TempFilePath()
+	signer := crypto.GenerateTestSigner()
+	s1, err := NewSigner(signer, path)
+	require.NoError(t, err)
+
+	b1, b2 := generateTestBlocks(crypto.GenerateTestHash())
+	assert.NoError(t, signVote(s1, vote.VoteTypePrecommit, 5, 1, b1))
+
+	t.Run("Another process with the same sign state", func(t *testing.T) {
+		_, err := NewSigner(signer, path)
+		assert.Error(t, err)
+	})
+
+	assert.NoError(t, s1.Close())
+
+	// Restarting
+	s2, err := NewSigner(signer, path)
+	require.NoError(t, err)
+	assert.Error(t, signVote(s2, vote.VoteTypePrecommit, 5, 1, b2))
+	assert.Error(t, signVote(s2, vote.VoteTypePrepare, 6, 1, b2))
+	assert.NoError(t, signVote(s2, vote.VoteTypePrecommit, 5, 1, b1))
+	assert.NoError(t, s2.Close())
+
+	t.Run("Invalid sign state", func(t *testing.T) {
+		assert.NoError(t, util.WriteFile(path, []byte("invalid")))
+		_, err := NewSigner(signer, path)
+		assert.Error(t, err)
+
+		// The lock should be released
+		assert.NoError(t, util.WriteFile(path, []byte("{}")))
+		s, err := NewSigner(signer, path)
+		assert.NoError(t, err)
+		assert.NoError(t, s.Close())
+	})
+}
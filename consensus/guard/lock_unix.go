@@ -0,0 +1,35 @@
+//go:build !windows
+// +build !windows
+
+package guard
+
+import (
+	"os"
+	"syscall"
+)
+
+// fileLock is an exclusive lock on a file.
+// The lock is released by the operating system if the process dies.
+type fileLock struct {
+	file *os.File
+}
+
+func lockFile(path string) (*fileLock, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, err
+	}
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &fileLock{file: f}, nil
+}
+
+func (l *fileLock) unlock() error {
+	if err := syscall.Flock(int(l.file.Fd()), syscall.LOCK_UN); err != nil {
+		l.file.Close()
+		return err
+	}
+	return l.file.Close()
+}
@@ -0,0 +1,31 @@
+//go:build windows
+// +build windows
+
+package guard
+
+import (
+	"os"
+)
+
+// fileLock is an exclusive lock on a file.
+// The lock file is created exclusively and removed on unlocking.
+// If the process dies, the lock file should be removed manually.
+type fileLock struct {
+	file *os.File
+	path string
+}
+
+func lockFile(path string) (*fileLock, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &fileLock{file: f, path: path}, nil
+}
+
+func (l *fileLock) unlock() error {
+	if err := l.file.Close(); err != nil {
+		return err
+	}
+	return os.Remove(l.path)
+}
@@ -1,6 +1,7 @@
 package consensus
 
 import (
+	"github.com/zarbchain/zarb-go/consensus/event"
 	"github.com/zarbchain/zarb-go/consensus/proposal"
 	"github.com/zarbchain/zarb-go/consensus/vote"
 	"github.com/zarbchain/zarb-go/util"
@@ -43,8 +44,13 @@ func (s *newHeightState) decide() {
 
 	s.height = sateHeight + 1
 	s.round = 0
+	s.replayWAL()
 	s.logger.Info("Entering new height", "height", s.height)
 
+	s.heightStartTime = util.Now()
+	s.roundStartTime = s.heightStartTime
+	s.publishEvent(event.Event{Type: event.TypeNewHeight, Round: s.round})
+
 	s.enterNewState(s.proposeState)
 }
 
@@ -0,0 +1,45 @@
+package consensus
+
+import (
+	"github.com/zarbchain/zarb-go/consensus/vote"
+	"github.com/zarbchain/zarb-go/consensus/voteset"
+	"github.com/zarbchain/zarb-go/crypto"
+)
+
+// Info is a snapshot of the consensus state, used for inspecting a running node.
+type Info struct {
+	Height       int
+	Round        int
+	Step         string
+	Proposer     crypto.Address
+	ProposalHash crypto.Hash // Undefined if there is no proposal for the current round
+	Rounds       []RoundInfo
+}
+
+type RoundInfo struct {
+	Round   int
+	Tallies []VoteTally
+}
+
+// VoteTally shows the votes of one type in a round
+type VoteTally struct {
+	Type        vote.Type
+	TotalPower  int64
+	VotedPower  int64
+	BlockPowers map[crypto.Hash]int64
+	Missing     []crypto.Address
+}
+
+func makeVoteTally(vs *voteset.VoteSet) VoteTally {
+	missing := make([]crypto.Address, 0)
+	for _, val := range vs.MissingValidators() {
+		missing = append(missing, val.Address())
+	}
+	return VoteTally{
+		Type:        vs.Type(),
+		TotalPower:  vs.TotalPower(),
+		VotedPower:  vs.VotedPower(),
+		BlockPowers: vs.BlockPowers(),
+		Missing:     missing,
+	}
+}
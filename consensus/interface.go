@@ -1,6 +1,7 @@
 package consensus
 
 import (
+	"github.com/zarbchain/zarb-go/consensus/event"
 	"github.com/zarbchain/zarb-go/consensus/proposal"
 	"github.com/zarbchain/zarb-go/consensus/vote"
 )
@@ -11,6 +12,8 @@ type Reader interface {
 	RoundVotes(round int) []*vote.Vote
 	RoundProposal(round int) *proposal.Proposal
 	HeightRound() (int, int)
+	Info() *Info
+	SubscribeEvents(capacity int) *event.Subscription
 	Fingerprint() string
 }
 
@@ -39,6 +39,10 @@ func (m *Messages) AllVotes() []*vote.Vote {
 	return votes
 }
 
+func (m *Messages) VoteSet(voteType vote.Type) *voteset.VoteSet {
+	return m.voteSet(voteType)
+}
+
 func (m *Messages) voteSet(voteType vote.Type) *voteset.VoteSet {
 	switch voteType {
 	case vote.VoteTypePrepare:
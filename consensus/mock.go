@@ -3,8 +3,10 @@ package consensus
 import (
 	"sync"
 
+	"github.com/zarbchain/zarb-go/consensus/event"
 	"github.com/zarbchain/zarb-go/consensus/proposal"
 	"github.com/zarbchain/zarb-go/consensus/vote"
+	"github.com/zarbchain/zarb-go/consensus/voteset"
 	"github.com/zarbchain/zarb-go/state"
 	"github.com/zarbchain/zarb-go/util"
 )
@@ -18,10 +20,11 @@ type MockConsensus struct {
 	Scheduled bool
 	State     *state.MockState
 	Round     int
+	EventBus  *event.Bus
 }
 
 func MockingConsensus(state *state.MockState) *MockConsensus {
-	return &MockConsensus{State: state}
+	return &MockConsensus{State: state, EventBus: event.NewBus()}
 }
 
 func (m *MockConsensus) MoveToNewHeight() {
@@ -29,6 +32,9 @@ func (m *MockConsensus) MoveToNewHeight() {
 	defer m.Lock.Unlock()
 	m.Scheduled = true
 }
+func (m *MockConsensus) SubscribeEvents(capacity int) *event.Subscription {
+	return m.EventBus.Subscribe(capacity)
+}
 func (m *MockConsensus) Start() error {
 	return nil
 }
@@ -78,6 +84,33 @@ func (m *MockConsensus) HeightRound() (int, int) {
 
 	return m.State.LastBlockHeight() + 1, m.Round
 }
+func (m *MockConsensus) Info() *Info {
+	m.Lock.RLock()
+	defer m.Lock.RUnlock()
+
+	info := &Info{
+		Height: m.State.LastBlockHeight() + 1,
+		Round:  m.Round,
+		Step:   "propose",
+		Rounds: make([]RoundInfo, 0),
+	}
+	info.Proposer = m.State.Proposer(m.Round).Address()
+	if m.Proposal != nil && m.Proposal.Round() == m.Round {
+		info.ProposalHash = m.Proposal.Block().Hash()
+	}
+	for r := 0; r <= m.Round; r++ {
+		ri := RoundInfo{Round: r}
+		for _, t := range []vote.Type{vote.VoteTypePrepare, vote.VoteTypePrecommit, vote.VoteTypeChangeProposer} {
+			vs := voteset.NewVoteSet(info.Height, r, t, m.State.CommitteeValidators())
+			for _, v := range m.Votes {
+				_ = vs.AddVote(v)
+			}
+			ri.Tallies = append(ri.Tallies, makeVoteTally(vs))
+		}
+		info.Rounds = append(info.Rounds, ri)
+	}
+	return info
+}
 func (m *MockConsensus) Fingerprint() string {
 	return ""
 }
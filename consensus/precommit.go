@@ -23,6 +23,7 @@ func (s *precommitState) decide() {
 	precommitQH := precommits.QuorumHash()
 	if precommitQH != nil {
 		s.logger.Debug("precommit has quorum", "precommitQH", precommitQH)
+		s.publishQuorumReached(vote.VoteTypePrecommit, *precommitQH)
 		s.enterNewState(s.commitState)
 	} else {
 		// Liveness on PBFT
@@ -24,6 +24,7 @@ func (s *prepareState) decide() {
 	prepareQH := prepares.QuorumHash()
 	if prepareQH != nil {
 		s.logger.Debug("prepare has quorum", "prepareQH", prepareQH)
+		s.publishQuorumReached(vote.VoteTypePrepare, *prepareQH)
 		s.enterNewState(s.precommitState)
 	} else {
 		// Liveness on PBFT
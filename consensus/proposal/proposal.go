@@ -1,6 +1,7 @@
 package proposal
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 
@@ -20,6 +21,12 @@ type proposalData struct {
 	Signature *crypto.Signature `cbor:"4,keyasint"`
 }
 
+type signProposal struct {
+	Height    int         `cbor:"1,keyasint"`
+	Round     int         `cbor:"2,keyasint"`
+	BlockHash crypto.Hash `cbor:"3,keyasint"`
+}
+
 func NewProposal(height int, round int, block *block.Block) *Proposal {
 	return &Proposal{
 		data: proposalData{
@@ -61,11 +68,6 @@ func (p *Proposal) SetSignature(sig crypto.Signature) {
 func (p *Proposal) SetPublicKey(crypto.PublicKey) {}
 
 func (p *Proposal) SignBytes() []byte {
-	type signProposal struct {
-		Height    int         `cbor:"1,keyasint"`
-		Round     int         `cbor:"2,keyasint"`
-		BlockHash crypto.Hash `cbor:"3,keyasint"`
-	}
 	bz, _ := cbor.Marshal(signProposal{
 		Height:    p.data.Height,
 		Round:     p.data.Round,
@@ -74,6 +76,16 @@ func (p *Proposal) SignBytes() []byte {
 	return bz
 }
 
+// IsSignBytes checks if the data are the sign bytes of a proposal.
+func IsSignBytes(data []byte) bool {
+	s := new(signProposal)
+	if err := cbor.Unmarshal(data, s); err != nil {
+		return false
+	}
+	bz, _ := cbor.Marshal(s)
+	return bytes.Equal(bz, data)
+}
+
 func (p *Proposal) MarshalCBOR() ([]byte, error) {
 	return cbor.Marshal(p.data)
 }
@@ -47,3 +47,11 @@ func TestProposalSanityCheck(t *testing.T) {
 	p.data.Signature = nil
 	assert.Error(t, p.SanityCheck())
 }
+
+func TestIsSignBytes(t *testing.T) {
+	p, _ := GenerateTestProposal(2, 0)
+
+	assert.True(t, IsSignBytes(p.SignBytes()))
+	assert.False(t, IsSignBytes(crypto.GenerateTestHash().RawBytes()))
+	assert.False(t, IsSignBytes([]byte("zarb")))
+}
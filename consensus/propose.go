@@ -19,12 +19,25 @@ func (s *proposeState) enter() {
 func (s *proposeState) decide() {
 	proposer := s.proposer(s.round)
 	if proposer.Address().EqualsTo(s.signer.Address()) {
-		s.logger.Info("Our turn to propose", "proposer", proposer.Address())
-		s.createProposal(s.height, s.round)
+		roundProposal := s.log.RoundProposal(s.round)
+		if roundProposal != nil {
+			// We have proposed before restarting the node
+			s.logger.Info("Our proposal exists, broadcasting it again", "proposal", roundProposal)
+			s.broadcastProposal(roundProposal)
+		} else {
+			s.logger.Info("Our turn to propose", "proposer", proposer.Address())
+			s.createProposal(s.height, s.round)
+		}
 	} else {
 		s.logger.Debug("Not our turn to propose", "proposer", proposer.Address())
 	}
 
+	// We have requested for changing the proposer before restarting the node
+	if s.ourVote(vote.VoteTypeChangeProposer, s.round) != nil {
+		s.enterNewState(s.changeProposerState)
+		return
+	}
+
 	s.enterNewState(s.prepareState)
 }
 
@@ -40,7 +53,10 @@ func (s *proposeState) createProposal(height int, round int) {
 	}
 
 	proposal := proposal.NewProposal(height, round, block)
-	s.signer.SignMsg(proposal)
+	if err := s.signer.SignProposal(proposal); err != nil {
+		s.logger.Error("Refused to sign our proposal", "proposal", proposal, "err", err)
+		return
+	}
 	s.doSetProposal(proposal)
 
 	s.logger.Info("Proposal signed and broadcasted", "proposal", proposal)
@@ -0,0 +1,39 @@
+package simulation
+
+import (
+	"github.com/zarbchain/zarb-go/consensus/vote"
+	"github.com/zarbchain/zarb-go/crypto"
+	"github.com/zarbchain/zarb-go/sync/message/payload"
+)
+
+// Behavior decides which messages a node sends to a peer, instead of the message that the consensus broadcasts.
+// It is used to simulate Byzantine validators. Nodes without behavior are honest.
+type Behavior interface {
+	Outgoing(n *Node, to int, pld payload.Payload) []payload.Payload
+}
+
+// Silent doesn't send any message, like a crashed validator.
+type Silent struct{}
+
+func (Silent) Outgoing(n *Node, to int, pld payload.Payload) []payload.Payload {
+	return nil
+}
+
+// Equivocator sends conflicting prepare and precommit votes to the peers with odd index.
+// The conflicting votes are signed directly by the validator key, bypassing the double signing guard.
+type Equivocator struct{}
+
+func (Equivocator) Outgoing(n *Node, to int, pld payload.Payload) []payload.Payload {
+	if to%2 == 0 || pld.Type() != payload.PayloadTypeVote {
+		return []payload.Payload{pld}
+	}
+	v := pld.(*payload.VotePayload).Vote
+	if v.Signer() != n.Signer.Address() ||
+		(v.Type() != vote.VoteTypePrepare && v.Type() != vote.VoteTypePrecommit) {
+		return []payload.Payload{pld}
+	}
+
+	conflicting := vote.NewVote(v.Type(), v.Height(), v.Round(), crypto.HashH(v.BlockHash().RawBytes()), v.Signer())
+	n.Signer.SignMsg(conflicting)
+	return []payload.Payload{payload.NewVotePayload(conflicting)}
+}
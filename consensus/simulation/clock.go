@@ -0,0 +1,124 @@
+package simulation
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+
+	"github.com/zarbchain/zarb-go/util"
+)
+
+var _ util.Clock = &Clock{}
+
+// Clock is a virtual clock. Time passes only when the simulation runs the next scheduled task.
+// The tasks that are scheduled for the same time run in the order they are scheduled.
+type Clock struct {
+	lk sync.Mutex
+
+	now   time.Time
+	seq   int
+	tasks taskQueue
+}
+
+type task struct {
+	at      time.Time
+	seq     int
+	f       func()
+	stopped bool
+	index   int
+}
+
+func (t *task) Stop() bool {
+	stopped := t.stopped
+	t.stopped = true
+	return !stopped
+}
+
+func NewClock(now time.Time) *Clock {
+	return &Clock{now: now}
+}
+
+func (c *Clock) Now() time.Time {
+	c.lk.Lock()
+	defer c.lk.Unlock()
+
+	return c.now
+}
+
+func (c *Clock) AfterFunc(d time.Duration, f func()) util.Timer {
+	c.lk.Lock()
+	defer c.lk.Unlock()
+
+	if d < 0 {
+		d = 0
+	}
+	c.seq++
+	t := &task{at: c.now.Add(d), seq: c.seq, f: f}
+	heap.Push(&c.tasks, t)
+	return t
+}
+
+// step runs the next task that is scheduled before the deadline.
+// It returns false if there is no such task.
+func (c *Clock) step(deadline time.Time) bool {
+	c.lk.Lock()
+	var next *task
+	for c.tasks.Len() > 0 {
+		t := c.tasks[0]
+		if t.at.After(deadline) {
+			break
+		}
+		heap.Pop(&c.tasks)
+		if !t.stopped {
+			next = t
+			break
+		}
+	}
+	if next == nil {
+		c.lk.Unlock()
+		return false
+	}
+	c.now = next.at
+	c.lk.Unlock()
+
+	next.f()
+	return true
+}
+
+// advance moves the time forward without running any task
+func (c *Clock) advance(to time.Time) {
+	c.lk.Lock()
+	defer c.lk.Unlock()
+
+	if to.After(c.now) {
+		c.now = to
+	}
+}
+
+type taskQueue []*task
+
+func (q taskQueue) Len() int { return len(q) }
+func (q taskQueue) Less(i, j int) bool {
+	if q[i].at.Equal(q[j].at) {
+		return q[i].seq < q[j].seq
+	}
+	return q[i].at.Before(q[j].at)
+}
+func (q taskQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+func (q *taskQueue) Push(x interface{}) {
+	t := x.(*task)
+	t.index = len(*q)
+	*q = append(*q, t)
+}
+func (q *taskQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	t := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return t
+}
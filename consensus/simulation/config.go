@@ -0,0 +1,62 @@
+package simulation
+
+import (
+	"time"
+
+	"github.com/zarbchain/zarb-go/errors"
+)
+
+type Config struct {
+	// Seed makes the simulation reproducible. The keys of the validators and the network are derived from it.
+	Seed int64
+	// Validators is the number of the validators. All of them are in the committee.
+	Validators int
+	// BlockTimeInSecond is the block time of the simulated chain
+	BlockTimeInSecond int
+	// MinDelay and MaxDelay are the range of the delay for delivering a message
+	MinDelay time.Duration
+	MaxDelay time.Duration
+	// DropRate is the probability of dropping a message
+	DropRate float64
+	// ReorderRate is the probability of delaying a message more than MaxDelay,
+	// so it is delivered after the messages that are sent later
+	ReorderRate float64
+	// HeartbeatInterval is the interval that each node gossips one of its votes,
+	// same as the heart-beat messages of the sync module. Zero disables it.
+	HeartbeatInterval time.Duration
+}
+
+func DefaultConfig() *Config {
+	return &Config{
+		Seed:              1,
+		Validators:        4,
+		BlockTimeInSecond: 10,
+		MinDelay:          10 * time.Millisecond,
+		MaxDelay:          200 * time.Millisecond,
+		DropRate:          0,
+		ReorderRate:       0,
+		HeartbeatInterval: 5 * time.Second,
+	}
+}
+
+func (conf *Config) SanityCheck() error {
+	if conf.Validators < 1 {
+		return errors.Errorf(errors.ErrInvalidConfig, "at least one validator is needed")
+	}
+	if conf.BlockTimeInSecond <= 0 {
+		return errors.Errorf(errors.ErrInvalidConfig, "BlockTimeInSecond should be positive")
+	}
+	if conf.MinDelay < 0 || conf.MaxDelay < conf.MinDelay {
+		return errors.Errorf(errors.ErrInvalidConfig, "invalid delay range")
+	}
+	if conf.DropRate < 0 || conf.DropRate > 1 {
+		return errors.Errorf(errors.ErrInvalidConfig, "DropRate should be between 0 and 1")
+	}
+	if conf.ReorderRate < 0 || conf.ReorderRate > 1 {
+		return errors.Errorf(errors.ErrInvalidConfig, "ReorderRate should be between 0 and 1")
+	}
+	if conf.HeartbeatInterval < 0 {
+		return errors.Errorf(errors.ErrInvalidConfig, "HeartbeatInterval can't be negative")
+	}
+	return nil
+}
@@ -0,0 +1,373 @@
+package simulation
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/zarbchain/zarb-go/account"
+	"github.com/zarbchain/zarb-go/consensus"
+	"github.com/zarbchain/zarb-go/crypto"
+	"github.com/zarbchain/zarb-go/genesis"
+	"github.com/zarbchain/zarb-go/param"
+	"github.com/zarbchain/zarb-go/state"
+	"github.com/zarbchain/zarb-go/store"
+	"github.com/zarbchain/zarb-go/sync/message/payload"
+	"github.com/zarbchain/zarb-go/txpool"
+	"github.com/zarbchain/zarb-go/util"
+	"github.com/zarbchain/zarb-go/validator"
+)
+
+// Node is a validator in the simulation. Each node has its own state and consensus.
+type Node struct {
+	Index     int
+	Signer    crypto.Signer
+	State     state.Facade
+	Consensus consensus.Consensus
+	Behavior  Behavior
+
+	broadcastCh   chan payload.Payload
+	checkedHeight int
+}
+
+// Filter decides whether a message should be delivered or not.
+type Filter func(from, to int, pld payload.Payload) bool
+
+// Simulation runs several consensus instances in one process, connected by a virtual network.
+// Time is virtual and all the randomness is derived from the seed,
+// therefore running a simulation with the same config and the same actions results in the same trace.
+//
+// A simulation replaces the clock of the util package. Only one simulation should run at the same time.
+type Simulation struct {
+	config    *Config
+	clock     *Clock
+	prevClock util.Clock
+	rand      *rand.Rand
+	genTime   time.Time
+	nodes     []*Node
+	groups    []int
+	filter    Filter
+	commits   map[int]crypto.Hash
+	safetyErr error
+	trace     []string
+}
+
+func NewSimulation(conf *Config) (*Simulation, error) {
+	if err := conf.SanityCheck(); err != nil {
+		return nil, err
+	}
+
+	r := rand.New(rand.NewSource(conf.Seed))
+	signers := make([]crypto.Signer, conf.Validators)
+	vals := make([]*validator.Validator, conf.Validators)
+	for i := 0; i < conf.Validators; i++ {
+		seed := make([]byte, 32)
+		r.Read(seed)
+		prv, err := crypto.PrivateKeyFromSeed(seed)
+		if err != nil {
+			return nil, err
+		}
+		signers[i] = crypto.NewSigner(prv)
+		vals[i] = validator.NewValidator(prv.PublicKey(), i)
+	}
+
+	acc := account.NewAccount(crypto.TreasuryAddress, 0)
+	acc.AddToBalance(21 * 1e14)
+	params := param.DefaultParams()
+	params.CommitteeSize = conf.Validators
+	params.BlockTimeInSecond = conf.BlockTimeInSecond
+
+	genTime := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	genDoc := genesis.MakeGenesis(genTime, []*account.Account{acc}, vals, params)
+
+	sim := &Simulation{
+		config:  conf,
+		clock:   NewClock(genTime),
+		rand:    r,
+		genTime: genTime,
+		nodes:   make([]*Node, conf.Validators),
+		commits: make(map[int]crypto.Hash),
+	}
+	// The clock should be replaced before creating the states and the consensus instances
+	sim.prevClock = util.SetClock(sim.clock)
+
+	txPool := txpool.MockingTxPool()
+	consConf := consensus.DefaultConfig()
+	consConf.WALPath = ""
+	consConf.SignStatePath = ""
+	for i, signer := range signers {
+		st, err := state.LoadOrNewState(state.TestConfig(), genDoc, signer, store.MockingStore(), txPool)
+		if err != nil {
+			sim.Close()
+			return nil, err
+		}
+		broadcastCh := make(chan payload.Payload, 1024)
+		cons, err := consensus.NewConsensus(consConf, st, signer, broadcastCh)
+		if err != nil {
+			sim.Close()
+			return nil, err
+		}
+		sim.nodes[i] = &Node{
+			Index:       i,
+			Signer:      signer,
+			State:       st,
+			Consensus:   cons,
+			broadcastCh: broadcastCh,
+		}
+	}
+
+	for _, n := range sim.nodes {
+		n.Consensus.MoveToNewHeight()
+	}
+	if conf.HeartbeatInterval > 0 {
+		sim.scheduleHeartbeat()
+	}
+
+	return sim, nil
+}
+
+// Close stops the consensus instances and restores the previous clock.
+func (sim *Simulation) Close() {
+	for _, n := range sim.nodes {
+		if n != nil {
+			n.Consensus.Stop()
+		}
+	}
+	util.SetClock(sim.prevClock)
+}
+
+func (sim *Simulation) Nodes() []*Node {
+	return sim.nodes
+}
+
+func (sim *Simulation) Node(i int) *Node {
+	return sim.nodes[i]
+}
+
+func (sim *Simulation) Now() time.Time {
+	return sim.clock.Now()
+}
+
+// SetBehavior makes the node Byzantine. A nil behavior makes it honest again.
+func (sim *Simulation) SetBehavior(i int, b Behavior) {
+	sim.nodes[i].Behavior = b
+}
+
+// SetFilter sets a filter for all the messages. A nil filter removes it.
+func (sim *Simulation) SetFilter(f Filter) {
+	sim.filter = f
+}
+
+// Partition splits the network into the groups. Nodes in different groups can't communicate.
+// Nodes that are not in any group are isolated.
+func (sim *Simulation) Partition(groups ...[]int) {
+	sim.groups = make([]int, len(sim.nodes))
+	for i := range sim.groups {
+		sim.groups[i] = -(i + 1)
+	}
+	for g, group := range groups {
+		for _, i := range group {
+			sim.groups[i] = g
+		}
+	}
+	sim.tracef("partition %v", groups)
+}
+
+// Heal removes the partitions.
+func (sim *Simulation) Heal() {
+	sim.groups = nil
+	sim.tracef("heal")
+}
+
+// Trace returns the delivered messages and the committed blocks, in order.
+func (sim *Simulation) Trace() []string {
+	return sim.trace
+}
+
+// Run runs the simulation for the given duration.
+// It returns an error if the safety is violated.
+func (sim *Simulation) Run(d time.Duration) error {
+	deadline := sim.clock.Now().Add(d)
+	for sim.safetyErr == nil && sim.clock.step(deadline) {
+		sim.flush()
+	}
+	sim.clock.advance(deadline)
+	return sim.safetyErr
+}
+
+// RunUntilHeight runs the simulation until all the honest nodes commit the block at the given height.
+// It returns an error if the height is not reached within the timeout, or if the safety is violated.
+func (sim *Simulation) RunUntilHeight(height int, timeout time.Duration) error {
+	deadline := sim.clock.Now().Add(timeout)
+	for !sim.reached(height) {
+		if sim.safetyErr != nil {
+			return sim.safetyErr
+		}
+		if !sim.clock.step(deadline) {
+			return fmt.Errorf("height %v is not reached in %v", height, timeout)
+		}
+		sim.flush()
+	}
+	return sim.safetyErr
+}
+
+// CheckSafety returns an error if two nodes have committed different blocks at the same height.
+func (sim *Simulation) CheckSafety() error {
+	return sim.safetyErr
+}
+
+func (sim *Simulation) reached(height int) bool {
+	for _, n := range sim.nodes {
+		if n.Behavior == nil && n.State.LastBlockHeight() < height {
+			return false
+		}
+	}
+	return true
+}
+
+// flush sends the broadcasted messages to the peers
+func (sim *Simulation) flush() {
+	for _, n := range sim.nodes {
+	loop:
+		for {
+			select {
+			case pld := <-n.broadcastCh:
+				sim.send(n, pld)
+			default:
+				break loop
+			}
+		}
+	}
+	sim.checkSafety()
+}
+
+func (sim *Simulation) send(from *Node, pld payload.Payload) {
+	for _, to := range sim.nodes {
+		if to == from {
+			continue
+		}
+		msgs := []payload.Payload{pld}
+		if from.Behavior != nil {
+			msgs = from.Behavior.Outgoing(from, to.Index, pld)
+		}
+		for _, msg := range msgs {
+			if !sim.connected(from.Index, to.Index) {
+				continue
+			}
+			if sim.filter != nil && !sim.filter(from.Index, to.Index, msg) {
+				continue
+			}
+			if sim.rand.Float64() < sim.config.DropRate {
+				sim.tracef("drop %d -> %d %s", from.Index, to.Index, msg.Fingerprint())
+				continue
+			}
+			delay := sim.config.MinDelay
+			if sim.config.MaxDelay > sim.config.MinDelay {
+				delay += time.Duration(sim.rand.Int63n(int64(sim.config.MaxDelay - sim.config.MinDelay)))
+			}
+			if sim.rand.Float64() < sim.config.ReorderRate {
+				delay += sim.config.MaxDelay
+			}
+
+			f, t, m := from, to, msg
+			sim.clock.AfterFunc(delay, func() {
+				sim.deliver(f, t, m)
+			})
+		}
+	}
+}
+
+func (sim *Simulation) connected(from, to int) bool {
+	if sim.groups == nil {
+		return true
+	}
+	return sim.groups[from] == sim.groups[to]
+}
+
+func (sim *Simulation) deliver(from, to *Node, pld payload.Payload) {
+	sim.tracef("%d -> %d %s", from.Index, to.Index, pld.Fingerprint())
+
+	switch pld.Type() {
+	case payload.PayloadTypeVote:
+		to.Consensus.AddVote(pld.(*payload.VotePayload).Vote)
+
+	case payload.PayloadTypeProposal:
+		to.Consensus.SetProposal(pld.(*payload.ProposalPayload).Proposal)
+
+	case payload.PayloadTypeQueryProposal:
+		pld := pld.(*payload.QueryProposalPayload)
+		height, round := to.Consensus.HeightRound()
+		if pld.Height == height && pld.Round == round {
+			p := to.Consensus.RoundProposal(round)
+			if p != nil {
+				to.broadcastCh <- payload.NewProposalPayload(p)
+			}
+		}
+
+	case payload.PayloadTypeBlockAnnounce:
+		sim.commitBlock(from, to, pld.(*payload.BlockAnnouncePayload))
+	}
+}
+
+// commitBlock commits the announced block, like the sync module does.
+// The missing blocks are copied from the sender, as if they were downloaded.
+func (sim *Simulation) commitBlock(from, to *Node, pld *payload.BlockAnnouncePayload) {
+	if pld.Height <= to.State.LastBlockHeight() {
+		return
+	}
+	for h := to.State.LastBlockHeight() + 1; h < pld.Height; h++ {
+		b := from.State.Block(h)
+		next := from.State.Block(h + 1)
+		if b == nil || next == nil {
+			return
+		}
+		if err := to.State.CommitBlock(h, b, next.LastCertificate()); err != nil {
+			return
+		}
+	}
+	if err := to.State.CommitBlock(pld.Height, pld.Block, pld.Certificate); err != nil {
+		return
+	}
+	to.Consensus.MoveToNewHeight()
+}
+
+// scheduleHeartbeat makes each node gossip one of its votes periodically.
+// It helps the nodes to recover the lost messages.
+func (sim *Simulation) scheduleHeartbeat() {
+	sim.clock.AfterFunc(sim.config.HeartbeatInterval, func() {
+		for _, n := range sim.nodes {
+			votes := n.Consensus.AllVotes()
+			if len(votes) == 0 {
+				continue
+			}
+			sort.Slice(votes, func(i, j int) bool {
+				return votes[i].Hash().String() < votes[j].Hash().String()
+			})
+			n.broadcastCh <- payload.NewVotePayload(votes[sim.rand.Intn(len(votes))])
+		}
+		sim.scheduleHeartbeat()
+	})
+}
+
+func (sim *Simulation) checkSafety() {
+	for _, n := range sim.nodes {
+		for h := n.checkedHeight + 1; h <= n.State.LastBlockHeight(); h++ {
+			hash := n.State.Block(h).Hash()
+			committed, ok := sim.commits[h]
+			if !ok {
+				sim.commits[h] = hash
+			} else if !committed.EqualsTo(hash) && sim.safetyErr == nil {
+				sim.safetyErr = fmt.Errorf("safety violated at height %v: node %v committed %v, but %v is committed before",
+					h, n.Index, hash.Fingerprint(), committed.Fingerprint())
+			}
+			sim.tracef("node %d committed %v at height %v", n.Index, hash.Fingerprint(), h)
+			n.checkedHeight = h
+		}
+	}
+}
+
+func (sim *Simulation) tracef(format string, args ...interface{}) {
+	elapsed := sim.clock.Now().Sub(sim.genTime)
+	sim.trace = append(sim.trace, fmt.Sprintf("%v: ", elapsed)+fmt.Sprintf(format, args...))
+}
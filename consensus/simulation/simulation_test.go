@@ -0,0 +1,109 @@
+package simulation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zarbchain/zarb-go/logger"
+	"github.com/zarbchain/zarb-go/sync/message/payload"
+)
+
+func setup(t *testing.T, conf *Config) *Simulation {
+	logger.InitLogger(logger.TestConfig())
+
+	sim, err := NewSimulation(conf)
+	require.NoError(t, err)
+	t.Cleanup(sim.Close)
+
+	return sim
+}
+
+func TestHonestNodes(t *testing.T) {
+	sim := setup(t, DefaultConfig())
+
+	assert.NoError(t, sim.RunUntilHeight(5, 2*time.Minute))
+	for _, n := range sim.Nodes() {
+		assert.GreaterOrEqual(t, n.State.LastBlockHeight(), 5)
+	}
+}
+
+func TestSilentNode(t *testing.T) {
+	sim := setup(t, DefaultConfig())
+	sim.SetBehavior(0, Silent{})
+
+	assert.NoError(t, sim.RunUntilHeight(5, 5*time.Minute))
+}
+
+func TestPartition(t *testing.T) {
+	sim := setup(t, DefaultConfig())
+
+	assert.NoError(t, sim.RunUntilHeight(1, time.Minute))
+	height := sim.Node(0).State.LastBlockHeight()
+
+	sim.Partition([]int{0, 1}, []int{2, 3})
+	assert.NoError(t, sim.Run(5*time.Minute))
+	for _, n := range sim.Nodes() {
+		assert.LessOrEqual(t, n.State.LastBlockHeight(), height+1)
+	}
+
+	sim.Heal()
+	assert.NoError(t, sim.RunUntilHeight(height+3, 10*time.Minute))
+}
+
+func TestDropMessages(t *testing.T) {
+	conf := DefaultConfig()
+	conf.DropRate = 0.2
+	conf.ReorderRate = 0.1
+	conf.HeartbeatInterval = time.Second
+	sim := setup(t, conf)
+
+	assert.NoError(t, sim.RunUntilHeight(5, 10*time.Minute))
+}
+
+func TestEquivocator(t *testing.T) {
+	sim := setup(t, DefaultConfig())
+	sim.SetBehavior(1, Equivocator{})
+
+	assert.NoError(t, sim.RunUntilHeight(5, 10*time.Minute))
+	assert.NoError(t, sim.CheckSafety())
+}
+
+func TestFilter(t *testing.T) {
+	sim := setup(t, DefaultConfig())
+	sim.SetFilter(func(from, to int, pld payload.Payload) bool {
+		return pld.Type() != payload.PayloadTypeBlockAnnounce
+	})
+
+	assert.NoError(t, sim.RunUntilHeight(3, 5*time.Minute))
+}
+
+func TestReproducible(t *testing.T) {
+	run := func(seed int64) []string {
+		conf := DefaultConfig()
+		conf.Seed = seed
+		conf.DropRate = 0.1
+		conf.ReorderRate = 0.1
+		sim, err := NewSimulation(conf)
+		require.NoError(t, err)
+		defer sim.Close()
+
+		require.NoError(t, sim.RunUntilHeight(3, 10*time.Minute))
+		return sim.Trace()
+	}
+	logger.InitLogger(logger.TestConfig())
+
+	trace1 := run(7)
+	trace2 := run(7)
+	trace3 := run(8)
+	assert.Equal(t, trace1, trace2)
+	assert.NotEqual(t, trace1, trace3)
+}
+
+func TestInvalidConfig(t *testing.T) {
+	conf := DefaultConfig()
+	conf.MaxDelay = conf.MinDelay - 1
+	_, err := NewSimulation(conf)
+	assert.Error(t, err)
+}
@@ -1,6 +1,7 @@
 package vote
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/fxamacker/cbor/v2"
@@ -47,6 +48,16 @@ func (v *Vote) SignBytes() []byte {
 	return bz
 }
 
+// IsSignBytes checks if the data are the sign bytes of a vote.
+func IsSignBytes(data []byte) bool {
+	s := new(signVote)
+	if err := cbor.Unmarshal(data, s); err != nil {
+		return false
+	}
+	bz, _ := cbor.Marshal(s)
+	return bytes.Equal(bz, data)
+}
+
 func NewVote(voteType Type, height int, round int, blockHash crypto.Hash, signer crypto.Address) *Vote {
 	return &Vote{
 		data: voteData{
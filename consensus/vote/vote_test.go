@@ -92,3 +92,15 @@ func TestSignBytesMatchWithCommit(t *testing.T) {
 	fmt.Printf("%x", v.SignBytes())
 	assert.Equal(t, v.SignBytes(), d)
 }
+
+func TestIsSignBytes(t *testing.T) {
+	v1, _ := GenerateTestPrepareVote(2, 0)
+	v2, _ := GenerateTestPrecommitVote(2, 0)
+	v3, _ := GenerateTestChangeProposerVote(2, 0)
+
+	assert.True(t, IsSignBytes(v1.SignBytes()))
+	assert.True(t, IsSignBytes(v2.SignBytes()))
+	assert.True(t, IsSignBytes(v3.SignBytes()))
+	assert.False(t, IsSignBytes(crypto.GenerateTestHash().RawBytes()))
+	assert.False(t, IsSignBytes([]byte("zarb")))
+}
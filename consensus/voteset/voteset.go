@@ -138,6 +138,49 @@ func (vs *VoteSet) BlockHashHasOneThirdOfTotalPower(hash crypto.Hash) bool {
 	return vs.hasOneThirdOfTotalPower(blockVotes.power)
 }
 
+// TotalPower returns the power of all the validators in the committee
+func (vs *VoteSet) TotalPower() int64 {
+	return vs.totalPower
+}
+
+// BlockPowers returns the voting power that each block hash has received
+func (vs *VoteSet) BlockPowers() map[crypto.Hash]int64 {
+	powers := make(map[crypto.Hash]int64)
+	for h, bv := range vs.blockVotes {
+		if bv.power > 0 {
+			powers[h] = bv.power
+		}
+	}
+	return powers
+}
+
+// VotedPower returns the power of the validators who have voted
+func (vs *VoteSet) VotedPower() int64 {
+	power := int64(0)
+	for _, bv := range vs.blockVotes {
+		power += bv.power
+	}
+	return power
+}
+
+// MissingValidators returns the validators who haven't voted yet, in the committee order
+func (vs *VoteSet) MissingValidators() []*validator.Validator {
+	missing := make([]*validator.Validator, 0)
+	for _, val := range vs.validators {
+		voted := false
+		for _, bv := range vs.blockVotes {
+			if _, ok := bv.votes[val.Address()]; ok {
+				voted = true
+				break
+			}
+		}
+		if !voted {
+			missing = append(missing, val)
+		}
+	}
+	return missing
+}
+
 func (vs *VoteSet) QuorumHash() *crypto.Hash {
 	return vs.quorumHash
 }
@@ -220,3 +220,36 @@ func TestOneThirdPower(t *testing.T) {
 	assert.NoError(t, vs.AddVote(v2))
 	assert.True(t, vs.BlockHashHasOneThirdOfTotalPower(crypto.UndefHash))
 }
+
+func TestTally(t *testing.T) {
+	committee, signers := setupCommittee(t, 1000, 1500, 2500, 2000)
+
+	vs := NewVoteSet(1, 0, vote.VoteTypePrepare, committee.Validators())
+	assert.Equal(t, vs.TotalPower(), int64(7000))
+	assert.Zero(t, vs.VotedPower())
+	assert.Len(t, vs.MissingValidators(), 4)
+
+	h1 := crypto.GenerateTestHash()
+	h2 := crypto.GenerateTestHash()
+	v1 := vote.NewVote(vote.VoteTypePrepare, 1, 0, h1, signers[0].Address())
+	v2 := vote.NewVote(vote.VoteTypePrepare, 1, 0, h1, signers[1].Address())
+	v3 := vote.NewVote(vote.VoteTypePrepare, 1, 0, h2, signers[2].Address())
+	v4 := vote.NewVote(vote.VoteTypePrepare, 1, 0, h2, signers[0].Address())
+
+	signers[0].SignMsg(v1)
+	signers[1].SignMsg(v2)
+	signers[2].SignMsg(v3)
+	signers[0].SignMsg(v4)
+
+	assert.NoError(t, vs.AddVote(v1))
+	assert.NoError(t, vs.AddVote(v2))
+	assert.NoError(t, vs.AddVote(v3))
+	assert.Error(t, vs.AddVote(v4)) // duplicated
+
+	assert.Equal(t, vs.VotedPower(), int64(5000))
+	assert.Equal(t, vs.BlockPowers(), map[crypto.Hash]int64{h1: 2500, h2: 2500})
+
+	missing := vs.MissingValidators()
+	assert.Len(t, missing, 1)
+	assert.Equal(t, missing[0].Address(), signers[3].Address())
+}
@@ -0,0 +1,166 @@
+package wal
+
+import (
+	"bufio"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/zarbchain/zarb-go/consensus/proposal"
+	"github.com/zarbchain/zarb-go/consensus/vote"
+)
+
+// WAL is a write-ahead log for the consensus messages.
+// Each message is saved and flushed into the disk before it is broadcasted,
+// so the consensus can replay them after a crash.
+//
+// Each record has a header: 4 bytes for the length and 4 bytes for the CRC-32 checksum of the message.
+// A record that is not written completely, because of a crash, is removed on opening the log.
+type WAL struct {
+	lk sync.Mutex
+
+	path string
+	file *os.File
+}
+
+// Message is a record in the write-ahead log. It keeps a vote or a proposal
+type Message struct {
+	data messageData
+}
+
+type messageData struct {
+	Vote     *vote.Vote         `cbor:"1,keyasint,omitempty"`
+	Proposal *proposal.Proposal `cbor:"2,keyasint,omitempty"`
+}
+
+const headerSize = 8
+
+func (m *Message) Vote() *vote.Vote             { return m.data.Vote }
+func (m *Message) Proposal() *proposal.Proposal { return m.data.Proposal }
+
+// Open opens the write-ahead log file. The file is created if it doesn't exist.
+func Open(path string) (*WAL, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+	// Removing the incomplete records at the end of the log
+	_, size, err := readMessages(path)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	if err := file.Truncate(size); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &WAL{
+		path: path,
+		file: file,
+	}, nil
+}
+
+func (w *WAL) WriteVote(v *vote.Vote) error {
+	return w.write(&messageData{Vote: v})
+}
+
+func (w *WAL) WriteProposal(p *proposal.Proposal) error {
+	return w.write(&messageData{Proposal: p})
+}
+
+func (w *WAL) write(data *messageData) error {
+	w.lk.Lock()
+	defer w.lk.Unlock()
+
+	bs, err := cbor.Marshal(data)
+	if err != nil {
+		return err
+	}
+	rec := make([]byte, headerSize+len(bs))
+	binary.BigEndian.PutUint32(rec[0:4], uint32(len(bs)))
+	binary.BigEndian.PutUint32(rec[4:8], crc32.ChecksumIEEE(bs))
+	copy(rec[headerSize:], bs)
+
+	if _, err := w.file.Write(rec); err != nil {
+		return err
+	}
+	return w.file.Sync()
+}
+
+// Messages returns all the messages in the log, in the order they were written.
+func (w *WAL) Messages() ([]*Message, error) {
+	w.lk.Lock()
+	defer w.lk.Unlock()
+
+	msgs, _, err := readMessages(w.path)
+	return msgs, err
+}
+
+// readMessages reads the valid records of the log file.
+// It returns the messages and the size of the valid records.
+// Reading stops at the first record that is incomplete or corrupted.
+func readMessages(path string) ([]*Message, int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer file.Close()
+
+	msgs := make([]*Message, 0)
+	size := int64(0)
+	reader := bufio.NewReader(file)
+	header := make([]byte, headerSize)
+	for {
+		if _, err := io.ReadFull(reader, header); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				break
+			}
+			return nil, 0, err
+		}
+		length := binary.BigEndian.Uint32(header[0:4])
+		checksum := binary.BigEndian.Uint32(header[4:8])
+		bs := make([]byte, length)
+		if _, err := io.ReadFull(reader, bs); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				break
+			}
+			return nil, 0, err
+		}
+		if crc32.ChecksumIEEE(bs) != checksum {
+			break
+		}
+		msg := new(Message)
+		if err := cbor.Unmarshal(bs, &msg.data); err != nil {
+			break
+		}
+		msgs = append(msgs, msg)
+		size += int64(headerSize + len(bs))
+	}
+
+	return msgs, size, nil
+}
+
+// Reset removes all the messages from the log.
+func (w *WAL) Reset() error {
+	w.lk.Lock()
+	defer w.lk.Unlock()
+
+	if err := w.file.Truncate(0); err != nil {
+		return err
+	}
+	return w.file.Sync()
+}
+
+func (w *WAL) Close() error {
+	w.lk.Lock()
+	defer w.lk.Unlock()
+
+	return w.file.Close()
+}
@@ -0,0 +1,82 @@
+package wal
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zarbchain/zarb-go/consensus/proposal"
+	"github.com/zarbchain/zarb-go/consensus/vote"
+	"github.com/zarbchain/zarb-go/util"
+)
+
+func TestWriteAndRead(t *testing.T) {
+	path := util.TempFilePath()
+	w, err := Open(path)
+	require.NoError(t, err)
+
+	v, _ := vote.GenerateTestPrepareVote(5, 1)
+	p, _ := proposal.GenerateTestProposal(5, 1)
+	assert.NoError(t, w.WriteVote(v))
+	assert.NoError(t, w.WriteProposal(p))
+
+	msgs, err := w.Messages()
+	assert.NoError(t, err)
+	require.Equal(t, len(msgs), 2)
+	assert.Equal(t, msgs[0].Vote().Hash(), v.Hash())
+	assert.Nil(t, msgs[0].Proposal())
+	assert.Equal(t, msgs[1].Proposal().Hash(), p.Hash())
+	assert.Nil(t, msgs[1].Vote())
+	assert.NoError(t, w.Close())
+
+	// Reopening the log
+	w, err = Open(path)
+	require.NoError(t, err)
+	msgs, err = w.Messages()
+	assert.NoError(t, err)
+	assert.Equal(t, len(msgs), 2)
+
+	assert.NoError(t, w.Reset())
+	msgs, err = w.Messages()
+	assert.NoError(t, err)
+	assert.Empty(t, msgs)
+
+	assert.NoError(t, w.WriteVote(v))
+	msgs, err = w.Messages()
+	assert.NoError(t, err)
+	assert.Equal(t, len(msgs), 1)
+	assert.NoError(t, w.Close())
+}
+
+func TestIncompleteRecord(t *testing.T) {
+	path := util.TempFilePath()
+	w, err := Open(path)
+	require.NoError(t, err)
+
+	v1, _ := vote.GenerateTestPrepareVote(5, 1)
+	v2, _ := vote.GenerateTestPrecommitVote(5, 1)
+	assert.NoError(t, w.WriteVote(v1))
+	assert.NoError(t, w.WriteVote(v2))
+	assert.NoError(t, w.Close())
+
+	// Crashing while writing the second vote
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(path, info.Size()-5))
+
+	w, err = Open(path)
+	require.NoError(t, err)
+	msgs, err := w.Messages()
+	assert.NoError(t, err)
+	require.Equal(t, len(msgs), 1)
+	assert.Equal(t, msgs[0].Vote().Hash(), v1.Hash())
+
+	// New records are written after the last valid one
+	assert.NoError(t, w.WriteVote(v2))
+	msgs, err = w.Messages()
+	assert.NoError(t, err)
+	require.Equal(t, len(msgs), 2)
+	assert.Equal(t, msgs[1].Vote().Hash(), v2.Hash())
+	assert.NoError(t, w.Close())
+}
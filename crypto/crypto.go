@@ -38,3 +38,40 @@ func VerifyAggregated(aggregated Signature, pubs []PublicKey, msg []byte) bool {
 	}
 	return aggregated.data.Signature.FastAggregateVerify(pubVec, Hash256(msg))
 }
+
+// VerifyBatch verifies the signatures of different messages at once.
+// The signatures are combined with random coefficients, therefore a set of invalid signatures
+// can't cancel each other. It is faster than verifying the signatures one by one,
+// but if it fails, it doesn't tell which signature is invalid.
+func VerifyBatch(pubs []PublicKey, msgs [][]byte, sigs []Signature) bool {
+	n := len(sigs)
+	if n == 0 || len(pubs) != n || len(msgs) != n {
+		return false
+	}
+
+	g1Vec := make([]bls.G1, n+1)
+	g2Vec := make([]bls.G2, n+1)
+	coefs := make([]bls.Fr, n)
+	sigVec := make([]bls.G1, n)
+	for i := 0; i < n; i++ {
+		coefs[i].SetByCSPRNG()
+		sigVec[i] = *bls.CastFromSign(sigs[i].data.Signature)
+
+		hm := bls.HashAndMapToSignature(Hash256(msgs[i]))
+		bls.G1Mul(&g1Vec[i+1], bls.CastFromSign(hm), &coefs[i])
+		g2Vec[i+1] = *bls.CastFromPublicKey(pubs[i].data.PublicKey)
+	}
+
+	// e(-Σ rᵢσᵢ, g) · Π e(rᵢH(mᵢ), pkᵢ) should be one
+	aggregated := new(bls.G1)
+	bls.G1MulVec(aggregated, sigVec, coefs)
+	bls.G1Neg(&g1Vec[0], aggregated)
+	generator := new(bls.PublicKey)
+	bls.BlsGetGeneratorOfPublicKey(generator)
+	g2Vec[0] = *bls.CastFromPublicKey(generator)
+
+	gt := new(bls.GT)
+	bls.MillerLoopVec(gt, g1Vec, g2Vec)
+	bls.FinalExp(gt, gt)
+	return gt.IsOne()
+}
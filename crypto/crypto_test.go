@@ -123,3 +123,28 @@ func TestDuplicatedAggregation(t *testing.T) {
 
 	assert.False(t, VerifyAggregated(agg1, pks1, msg1))
 }
+
+func TestVerifyBatch(t *testing.T) {
+	_, pk1, pv1 := GenerateTestKeyPair()
+	_, pk2, pv2 := GenerateTestKeyPair()
+	_, pk3, pv3 := GenerateTestKeyPair()
+	msg1 := []byte("zarb1")
+	msg2 := []byte("zarb2")
+	msg3 := []byte("zarb3")
+
+	sig1 := pv1.Sign(msg1)
+	sig2 := pv2.Sign(msg2)
+	sig3 := pv3.Sign(msg3)
+	sig4 := pv1.Sign(msg2)
+
+	pubs := []PublicKey{pk1, pk2, pk3}
+	msgs := [][]byte{msg1, msg2, msg3}
+
+	assert.True(t, VerifyBatch(pubs, msgs, []Signature{sig1, sig2, sig3}))
+	assert.True(t, VerifyBatch([]PublicKey{pk1, pk1}, [][]byte{msg1, msg2}, []Signature{sig1, sig4}))
+	assert.True(t, VerifyBatch(pubs[:1], msgs[:1], []Signature{sig1}))
+	assert.False(t, VerifyBatch(pubs, msgs, []Signature{sig1, sig3, sig2}))
+	assert.False(t, VerifyBatch(pubs, [][]byte{msg1, msg2, msg1}, []Signature{sig1, sig2, sig3}))
+	assert.False(t, VerifyBatch(pubs, msgs, []Signature{sig1, sig2}))
+	assert.False(t, VerifyBatch(nil, nil, nil))
+}
@@ -0,0 +1,48 @@
+package remote
+
+import (
+	"time"
+
+	"github.com/zarbchain/zarb-go/errors"
+)
+
+type Config struct {
+	Address        string        `toml:"" comment:"Address of the remote signer. The validator key is kept by the signer and the node asks it to sign the messages. Empty address disables it."`
+	CertFile       string        `toml:"" comment:"CertFile is the certificate of the node to authenticate it to the remote signer. It is generated if it doesn't exist."`
+	KeyFile        string        `toml:"" comment:"KeyFile is the private key of the node certificate."`
+	SignerCertFile string        `toml:"" comment:"SignerCertFile is the certificate of the remote signer. Only the signer with this certificate is accepted."`
+	Timeout        time.Duration `toml:"" comment:"Timeout for connecting to the remote signer and receiving the signature. Default is 3 seconds."`
+}
+
+func DefaultConfig() *Config {
+	return &Config{
+		Address:        "",
+		CertFile:       "signer/node_cert.pem",
+		KeyFile:        "signer/node_key.pem",
+		SignerCertFile: "signer/signer_cert.pem",
+		Timeout:        3 * time.Second,
+	}
+}
+
+func TestConfig() *Config {
+	return &Config{
+		Address: "",
+		Timeout: 1 * time.Second,
+	}
+}
+
+func (conf *Config) SanityCheck() error {
+	if conf.Address == "" {
+		return nil
+	}
+	if conf.CertFile == "" || conf.KeyFile == "" {
+		return errors.Errorf(errors.ErrInvalidConfig, "certificate of the node is not set")
+	}
+	if conf.SignerCertFile == "" {
+		return errors.Errorf(errors.ErrInvalidConfig, "certificate of the remote signer is not set")
+	}
+	if conf.Timeout <= 0 {
+		return errors.Errorf(errors.ErrInvalidConfig, "Timeout can't be negative")
+	}
+	return nil
+}
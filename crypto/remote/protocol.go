@@ -0,0 +1,82 @@
+package remote
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/zarbchain/zarb-go/errors"
+)
+
+// maxMessageSize limits the size of the messages. A proposal contains the whole block.
+const maxMessageSize = 8 * 1024 * 1024
+
+type requestType int
+
+const (
+	requestTypePublicKey = requestType(1)
+	requestTypeVote      = requestType(2)
+	requestTypeProposal  = requestType(3)
+	requestTypeData      = requestType(4)
+)
+
+func (t requestType) String() string {
+	switch t {
+	case requestTypePublicKey:
+		return "public-key"
+	case requestTypeVote:
+		return "vote"
+	case requestTypeProposal:
+		return "proposal"
+	case requestTypeData:
+		return "data"
+	}
+	return "unknown"
+}
+
+// request asks the remote signer for the public key or a signature.
+// Votes and proposals are sent as they are, so the signer can check them against double signing.
+// Prepare and precommit votes are sent with their blocks.
+type request struct {
+	Type  requestType `cbor:"1,keyasint"`
+	Data  []byte      `cbor:"2,keyasint,omitempty"`
+	Block []byte      `cbor:"3,keyasint,omitempty"`
+}
+
+type response struct {
+	PublicKey []byte `cbor:"1,keyasint,omitempty"`
+	Signature []byte `cbor:"2,keyasint,omitempty"`
+	Error     string `cbor:"3,keyasint,omitempty"`
+}
+
+// writeMessage writes the message with a 4-byte length prefix
+func writeMessage(w io.Writer, msg interface{}) error {
+	bs, err := cbor.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	if len(bs) > maxMessageSize {
+		return errors.Errorf(errors.ErrGeneric, "message is too big: %v", len(bs))
+	}
+	buf := make([]byte, 4+len(bs))
+	binary.BigEndian.PutUint32(buf, uint32(len(bs)))
+	copy(buf[4:], bs)
+	_, err = w.Write(buf)
+	return err
+}
+
+func readMessage(r io.Reader, msg interface{}) error {
+	var header [4]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return err
+	}
+	size := binary.BigEndian.Uint32(header[:])
+	if size > maxMessageSize {
+		return errors.Errorf(errors.ErrGeneric, "message is too big: %v", size)
+	}
+	bs := make([]byte, size)
+	if _, err := io.ReadFull(r, bs); err != nil {
+		return err
+	}
+	return cbor.Unmarshal(bs, msg)
+}
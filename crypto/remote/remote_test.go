@@ -0,0 +1,189 @@
+package remote
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zarbchain/zarb-go/block"
+	"github.com/zarbchain/zarb-go/consensus/proposal"
+	"github.com/zarbchain/zarb-go/consensus/vote"
+	"github.com/zarbchain/zarb-go/crypto"
+	"github.com/zarbchain/zarb-go/logger"
+	"github.com/zarbchain/zarb-go/util"
+)
+
+func setup(t *testing.T) (*Server, *Signer, crypto.Signer) {
+	logger.InitLogger(logger.TestConfig())
+
+	dir := util.TempDirPath()
+	conf := TestConfig()
+	conf.CertFile = filepath.Join(dir, "node_cert.pem")
+	conf.KeyFile = filepath.Join(dir, "node_key.pem")
+	conf.SignerCertFile = filepath.Join(dir, "signer_cert.pem")
+	_, err := LoadOrGenerateCertificate(conf.CertFile, conf.KeyFile)
+	require.NoError(t, err)
+
+	serverConf := &ServerConfig{
+		Listen:        "127.0.0.1:0",
+		CertFile:      conf.SignerCertFile,
+		KeyFile:       filepath.Join(dir, "signer_key.pem"),
+		NodeCertFile:  conf.CertFile,
+		SignStatePath: filepath.Join(dir, "sign_state.json"),
+	}
+	localSigner := crypto.GenerateTestSigner()
+	server, err := NewServer(serverConf, localSigner)
+	require.NoError(t, err)
+	server.Start()
+
+	conf.Address = server.Address()
+	signer, err := NewSigner(conf)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		signer.Close()
+		server.Stop()
+	})
+
+	return server, signer, localSigner
+}
+
+func TestPublicKey(t *testing.T) {
+	_, signer, localSigner := setup(t)
+
+	assert.Equal(t, signer.PublicKey().RawBytes(), localSigner.PublicKey().RawBytes())
+	assert.Equal(t, signer.Address(), localSigner.Address())
+}
+
+func TestSignVote(t *testing.T) {
+	_, signer, _ := setup(t)
+
+	lastBlockHash := crypto.GenerateTestHash()
+	b1, _ := block.GenerateTestBlock(nil, &lastBlockHash)
+	b2, _ := block.GenerateTestBlock(nil, &lastBlockHash)
+	v1 := vote.NewVote(vote.VoteTypePrepare, 2, 0, b1.Hash(), signer.Address())
+	signer.SignBlockVote(v1, b1)
+	require.NotNil(t, v1.Signature())
+	assert.NoError(t, v1.Verify(signer.PublicKey()))
+
+	// Signing the same vote again
+	v2 := vote.NewVote(vote.VoteTypePrepare, 2, 0, b1.Hash(), signer.Address())
+	signer.SignBlockVote(v2, b1)
+	assert.Equal(t, v1.Signature().RawBytes(), v2.Signature().RawBytes())
+
+	// Conflicting vote
+	v3 := vote.NewVote(vote.VoteTypePrepare, 2, 0, b2.Hash(), signer.Address())
+	signer.SignBlockVote(v3, b2)
+	assert.Nil(t, v3.Signature())
+
+	// Conflicting vote under another height
+	v4 := vote.NewVote(vote.VoteTypePrepare, 3, 0, b2.Hash(), signer.Address())
+	signer.SignBlockVote(v4, b2)
+	assert.Nil(t, v4.Signature())
+
+	// Vote without its block
+	v5 := vote.NewVote(vote.VoteTypePrecommit, 2, 0, b1.Hash(), signer.Address())
+	signer.SignMsg(v5)
+	assert.Nil(t, v5.Signature())
+
+	// Change-proposer votes have no block
+	v6 := vote.NewVote(vote.VoteTypeChangeProposer, 2, 0, crypto.UndefHash, signer.Address())
+	signer.SignMsg(v6)
+	assert.NotNil(t, v6.Signature())
+
+	// Vote of another validator
+	addr, _, _ := crypto.GenerateTestKeyPair()
+	v7 := vote.NewVote(vote.VoteTypePrecommit, 2, 0, b1.Hash(), addr)
+	signer.SignBlockVote(v7, b1)
+	assert.Nil(t, v7.Signature())
+}
+
+func TestSignProposal(t *testing.T) {
+	_, signer, _ := setup(t)
+
+	addr := signer.Address()
+	b1, _ := block.GenerateTestBlock(&addr, nil)
+	b2, _ := block.GenerateTestBlock(&addr, nil)
+	p1 := proposal.NewProposal(2, 1, b1)
+	signer.SignMsg(p1)
+	require.NotNil(t, p1.Signature())
+	assert.NoError(t, p1.Verify(signer.PublicKey()))
+
+	p2 := proposal.NewProposal(2, 1, b2)
+	signer.SignMsg(p2)
+	assert.Nil(t, p2.Signature())
+
+	// Round regression
+	p3 := proposal.NewProposal(2, 0, b2)
+	signer.SignMsg(p3)
+	assert.Nil(t, p3.Signature())
+}
+
+func TestSignData(t *testing.T) {
+	_, signer, localSigner := setup(t)
+
+	data := []byte("zarb")
+	assert.Equal(t, signer.SignData(data).RawBytes(), localSigner.SignData(data).RawBytes())
+
+	t.Run("Sign bytes of votes and proposals are refused", func(t *testing.T) {
+		v, _ := vote.GenerateTestPrecommitVote(2, 0)
+		p, _ := proposal.GenerateTestProposal(2, 0)
+		assert.Nil(t, signer.SignData(v.SignBytes()).RawBytes())
+		assert.Nil(t, signer.SignData(p.SignBytes()).RawBytes())
+
+		msg := &signableData{data: v.SignBytes()}
+		signer.SignMsg(msg)
+		assert.Nil(t, msg.sig.RawBytes())
+	})
+
+	t.Run("Other messages are signed as data", func(t *testing.T) {
+		msg := &signableData{data: data}
+		signer.SignMsg(msg)
+		assert.Equal(t, msg.sig.RawBytes(), localSigner.SignData(data).RawBytes())
+		assert.True(t, msg.pub.EqualsTo(localSigner.PublicKey()))
+	})
+}
+
+func TestReconnect(t *testing.T) {
+	server, signer, _ := setup(t)
+
+	data := []byte("zarb")
+	assert.NotNil(t, signer.SignData(data).RawBytes())
+
+	// Breaking the connection
+	server.lk.Lock()
+	for conn := range server.conns {
+		conn.Close()
+	}
+	server.lk.Unlock()
+
+	assert.NotNil(t, signer.SignData(data).RawBytes())
+}
+
+func TestUnknownCertificate(t *testing.T) {
+	server, _, _ := setup(t)
+
+	dir := util.TempDirPath()
+	conf := TestConfig()
+	conf.Address = server.Address()
+	conf.CertFile = filepath.Join(dir, "node_cert.pem")
+	conf.KeyFile = filepath.Join(dir, "node_key.pem")
+	conf.SignerCertFile = filepath.Join(dir, "signer_cert.pem")
+
+	// The signer certificate is unknown
+	_, err := LoadOrGenerateCertificate(conf.SignerCertFile, filepath.Join(dir, "signer_key.pem"))
+	require.NoError(t, err)
+	_, err = NewSigner(conf)
+	assert.Error(t, err)
+}
+
+type signableData struct {
+	data []byte
+	sig  crypto.Signature
+	pub  crypto.PublicKey
+}
+
+func (d *signableData) SignBytes() []byte                 { return d.data }
+func (d *signableData) SetSignature(sig crypto.Signature) { d.sig = sig }
+func (d *signableData) SetPublicKey(pub crypto.PublicKey) { d.pub = pub }
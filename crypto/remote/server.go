@@ -0,0 +1,190 @@
+package remote
+
+import (
+	"crypto/tls"
+	"net"
+	"sync"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/zarbchain/zarb-go/block"
+	"github.com/zarbchain/zarb-go/consensus/guard"
+	"github.com/zarbchain/zarb-go/consensus/proposal"
+	"github.com/zarbchain/zarb-go/consensus/vote"
+	"github.com/zarbchain/zarb-go/crypto"
+	"github.com/zarbchain/zarb-go/errors"
+	"github.com/zarbchain/zarb-go/logger"
+)
+
+// ServerConfig is the configuration of the signer daemon
+type ServerConfig struct {
+	Listen        string
+	CertFile      string
+	KeyFile       string
+	NodeCertFile  string
+	SignStatePath string
+}
+
+// Server keeps the validator key and signs the messages for the node.
+// Votes and proposals are checked against double signing before signing them.
+type Server struct {
+	lk sync.Mutex
+
+	signer   crypto.Signer
+	guard    *guard.Signer
+	listener net.Listener
+	conns    map[net.Conn]bool
+	wg       sync.WaitGroup
+	logger   *logger.Logger
+}
+
+func NewServer(conf *ServerConfig, signer crypto.Signer) (*Server, error) {
+	cert, err := LoadOrGenerateCertificate(conf.CertFile, conf.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	pinned, err := loadPinnedCertificate(conf.NodeCertFile)
+	if err != nil {
+		return nil, err
+	}
+	g, err := guard.NewSigner(signer, conf.SignStatePath)
+	if err != nil {
+		return nil, err
+	}
+	listener, err := tls.Listen("tcp", conf.Listen, newTLSConfig(cert, pinned))
+	if err != nil {
+		g.Close()
+		return nil, err
+	}
+
+	s := &Server{
+		signer:   signer,
+		guard:    g,
+		listener: listener,
+		conns:    make(map[net.Conn]bool),
+	}
+	s.logger = logger.NewLogger("_signer", s)
+	return s, nil
+}
+
+// Address returns the address that the server is listening on
+func (s *Server) Address() string {
+	return s.listener.Addr().String()
+}
+
+func (s *Server) Start() {
+	s.wg.Add(1)
+	go s.acceptLoop()
+	s.logger.Info("Signer started", "addr", s.Address())
+}
+
+func (s *Server) Stop() {
+	s.listener.Close()
+
+	s.lk.Lock()
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.lk.Unlock()
+
+	s.wg.Wait()
+	s.guard.Close()
+}
+
+func (s *Server) acceptLoop() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		s.lk.Lock()
+		s.conns[conn] = true
+		s.lk.Unlock()
+
+		s.wg.Add(1)
+		go s.handleConn(conn)
+	}
+}
+
+func (s *Server) handleConn(conn net.Conn) {
+	defer s.wg.Done()
+	defer func() {
+		conn.Close()
+		s.lk.Lock()
+		delete(s.conns, conn)
+		s.lk.Unlock()
+	}()
+
+	s.logger.Info("Node connected", "addr", conn.RemoteAddr())
+	for {
+		req := new(request)
+		if err := readMessage(conn, req); err != nil {
+			s.logger.Info("Node disconnected", "addr", conn.RemoteAddr(), "err", err)
+			return
+		}
+
+		res := s.handleRequest(req)
+		if res.Error != "" {
+			s.logger.Warn("Refused to sign", "type", req.Type, "err", res.Error)
+		}
+		if err := writeMessage(conn, res); err != nil {
+			s.logger.Warn("Unable to send the response", "err", err)
+			return
+		}
+	}
+}
+
+func (s *Server) handleRequest(req *request) *response {
+	switch req.Type {
+	case requestTypePublicKey:
+		return &response{PublicKey: s.signer.PublicKey().RawBytes()}
+
+	case requestTypeVote:
+		v := new(vote.Vote)
+		if err := cbor.Unmarshal(req.Data, v); err != nil {
+			return &response{Error: err.Error()}
+		}
+		if !v.Signer().EqualsTo(s.signer.Address()) {
+			return &response{Error: "vote is not for this validator"}
+		}
+		var b *block.Block
+		if req.Block != nil {
+			b = new(block.Block)
+			if err := b.Decode(req.Block); err != nil {
+				return &response{Error: err.Error()}
+			}
+		}
+		if err := s.guard.SignVote(v, b); err != nil {
+			return &response{Error: err.Error()}
+		}
+		return &response{Signature: v.Signature().RawBytes()}
+
+	case requestTypeProposal:
+		p := new(proposal.Proposal)
+		if err := cbor.Unmarshal(req.Data, p); err != nil {
+			return &response{Error: err.Error()}
+		}
+		if p.Block() == nil {
+			return &response{Error: "proposal has no block"}
+		}
+		if err := s.guard.SignProposal(p); err != nil {
+			return &response{Error: err.Error()}
+		}
+		return &response{Signature: p.Signature().RawBytes()}
+
+	case requestTypeData:
+		// Signing votes and proposals as raw data would bypass the double signing protection
+		if vote.IsSignBytes(req.Data) || proposal.IsSignBytes(req.Data) {
+			return &response{Error: "votes and proposals should be sent as they are"}
+		}
+		return &response{Signature: s.signer.SignData(req.Data).RawBytes()}
+	}
+
+	return &response{Error: errors.Errorf(errors.ErrGeneric, "invalid request type: %v", req.Type).Error()}
+}
+
+func (s *Server) Fingerprint() string {
+	return s.signer.Address().Fingerprint()
+}
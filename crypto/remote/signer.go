@@ -0,0 +1,222 @@
+package remote
+
+import (
+	"crypto/tls"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/zarbchain/zarb-go/block"
+	"github.com/zarbchain/zarb-go/consensus/proposal"
+	"github.com/zarbchain/zarb-go/consensus/vote"
+	"github.com/zarbchain/zarb-go/crypto"
+	"github.com/zarbchain/zarb-go/errors"
+	"github.com/zarbchain/zarb-go/logger"
+)
+
+// Signer implements crypto.Signer by asking a remote signer to sign the messages.
+// The connection is authenticated on both sides by the pinned TLS certificates.
+//
+// The crypto.Signer interface doesn't return errors. If the remote signer fails or refuses,
+// the error is logged and the signature of the message is not set.
+type Signer struct {
+	lk sync.Mutex
+
+	address   string
+	timeout   time.Duration
+	tlsConfig *tls.Config
+	conn      net.Conn
+	publicKey crypto.PublicKey
+	logger    *logger.Logger
+}
+
+// NewSigner connects to the remote signer and retrieves the public key of the validator
+func NewSigner(conf *Config) (*Signer, error) {
+	cert, err := LoadOrGenerateCertificate(conf.CertFile, conf.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	pinned, err := loadPinnedCertificate(conf.SignerCertFile)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Signer{
+		address:   conf.Address,
+		timeout:   conf.Timeout,
+		tlsConfig: newTLSConfig(cert, pinned),
+	}
+	s.logger = logger.NewLogger("_signer", s)
+
+	res, err := s.call(request{Type: requestTypePublicKey})
+	if err != nil {
+		return nil, err
+	}
+	pub, err := crypto.PublicKeyFromRawBytes(res.PublicKey)
+	if err != nil {
+		return nil, errors.Errorf(errors.ErrGeneric, "invalid public key: %v", err)
+	}
+	s.publicKey = pub
+
+	return s, nil
+}
+
+func (s *Signer) Address() crypto.Address {
+	return s.publicKey.Address()
+}
+
+func (s *Signer) PublicKey() crypto.PublicKey {
+	return s.publicKey
+}
+
+func (s *Signer) SignData(data []byte) crypto.Signature {
+	sig, err := s.sign(requestTypeData, data)
+	if err != nil {
+		s.logger.Error("Unable to sign the data", "err", err)
+		return crypto.Signature{}
+	}
+	return sig
+}
+
+// SignMsg sends votes and proposals as they are, other messages are sent as data
+func (s *Signer) SignMsg(msg crypto.SignableMsg) {
+	var sig crypto.Signature
+	var err error
+	switch m := msg.(type) {
+	case *vote.Vote:
+		var data []byte
+		data, err = cbor.Marshal(m)
+		if err == nil {
+			sig, err = s.sign(requestTypeVote, data)
+		}
+	case *proposal.Proposal:
+		var data []byte
+		data, err = cbor.Marshal(m)
+		if err == nil {
+			sig, err = s.sign(requestTypeProposal, data)
+		}
+	default:
+		sig, err = s.sign(requestTypeData, msg.SignBytes())
+	}
+	if err != nil {
+		s.logger.Error("Unable to sign the message", "err", err)
+		return
+	}
+	msg.SetSignature(sig)
+	msg.SetPublicKey(s.publicKey)
+}
+
+// SignBlockVote sends the vote with its block.
+// The remote signer refuses to sign prepare and precommit votes without their blocks.
+func (s *Signer) SignBlockVote(v *vote.Vote, b *block.Block) {
+	sig, err := s.signVote(v, b)
+	if err != nil {
+		s.logger.Error("Unable to sign the vote", "err", err)
+		return
+	}
+	v.SetSignature(sig)
+}
+
+func (s *Signer) signVote(v *vote.Vote, b *block.Block) (crypto.Signature, error) {
+	data, err := cbor.Marshal(v)
+	if err != nil {
+		return crypto.Signature{}, err
+	}
+	bs, err := b.Encode()
+	if err != nil {
+		return crypto.Signature{}, err
+	}
+	return s.signRequest(request{Type: requestTypeVote, Data: data, Block: bs})
+}
+
+func (s *Signer) sign(t requestType, data []byte) (crypto.Signature, error) {
+	return s.signRequest(request{Type: t, Data: data})
+}
+
+func (s *Signer) signRequest(req request) (crypto.Signature, error) {
+	res, err := s.call(req)
+	if err != nil {
+		return crypto.Signature{}, err
+	}
+	sig, err := crypto.SignatureFromRawBytes(res.Signature)
+	if err != nil {
+		return crypto.Signature{}, errors.Errorf(errors.ErrGeneric, "invalid signature: %v", err)
+	}
+	return sig, nil
+}
+
+// call sends the request and waits for the response.
+// If the connection is broken, it reconnects once.
+func (s *Signer) call(req request) (*response, error) {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	res, err := s.roundTrip(req)
+	if err != nil {
+		if _, ok := err.(*signerError); ok {
+			return nil, err
+		}
+		s.closeConn()
+		res, err = s.roundTrip(req)
+		if err != nil {
+			s.closeConn()
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (s *Signer) roundTrip(req request) (*response, error) {
+	if s.conn == nil {
+		dialer := &net.Dialer{Timeout: s.timeout}
+		conn, err := tls.DialWithDialer(dialer, "tcp", s.address, s.tlsConfig)
+		if err != nil {
+			return nil, errors.Errorf(errors.ErrGeneric, "unable to connect to the remote signer: %v", err)
+		}
+		s.conn = conn
+	}
+
+	if err := s.conn.SetDeadline(time.Now().Add(s.timeout)); err != nil {
+		return nil, err
+	}
+	if err := writeMessage(s.conn, req); err != nil {
+		return nil, err
+	}
+	res := new(response)
+	if err := readMessage(s.conn, res); err != nil {
+		return nil, err
+	}
+	if res.Error != "" {
+		return nil, &signerError{msg: res.Error}
+	}
+	return res, nil
+}
+
+func (s *Signer) closeConn() {
+	if s.conn != nil {
+		s.conn.Close()
+		s.conn = nil
+	}
+}
+
+// Close closes the connection to the remote signer
+func (s *Signer) Close() {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	s.closeConn()
+}
+
+func (s *Signer) Fingerprint() string {
+	return s.address
+}
+
+// signerError is returned when the remote signer refuses to sign
+type signerError struct {
+	msg string
+}
+
+func (e *signerError) Error() string {
+	return "remote signer refused: " + e.msg
+}
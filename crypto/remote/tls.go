@@ -0,0 +1,93 @@
+package remote
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"time"
+
+	"github.com/zarbchain/zarb-go/errors"
+	"github.com/zarbchain/zarb-go/util"
+)
+
+// LoadOrGenerateCertificate loads the TLS certificate from the files.
+// If they don't exist, a new self-signed certificate is generated and saved.
+func LoadOrGenerateCertificate(certFile, keyFile string) (tls.Certificate, error) {
+	if !util.PathExists(certFile) && !util.PathExists(keyFile) {
+		if err := generateCertificate(certFile, keyFile); err != nil {
+			return tls.Certificate{}, err
+		}
+	}
+	return tls.LoadX509KeyPair(certFile, keyFile)
+}
+
+func generateCertificate(certFile, keyFile string) error {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return err
+	}
+	template := x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{Organization: []string{"zarb"}},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().AddDate(10, 0, 0),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &priv.PublicKey, priv)
+	if err != nil {
+		return err
+	}
+	keyDer, err := x509.MarshalECPrivateKey(priv)
+	if err != nil {
+		return err
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	if err := util.WriteFile(certFile, certPEM); err != nil {
+		return err
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+	return util.WriteFile(keyFile, keyPEM)
+}
+
+// loadPinnedCertificate returns the DER bytes of the certificate of the other party
+func loadPinnedCertificate(certFile string) ([]byte, error) {
+	data, err := util.ReadFile(certFile)
+	if err != nil {
+		return nil, errors.Errorf(errors.ErrGeneric, "unable to read the certificate of the other party: %v", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.Errorf(errors.ErrGeneric, "invalid certificate file: %v", certFile)
+	}
+	return block.Bytes, nil
+}
+
+// newTLSConfig creates a TLS config that only accepts the pinned certificate.
+// The certificates are self-signed, so they are verified by comparing them with the pinned one,
+// instead of the chain of trust.
+func newTLSConfig(cert tls.Certificate, pinned []byte) *tls.Config {
+	return &tls.Config{
+		Certificates:       []tls.Certificate{cert},
+		MinVersion:         tls.VersionTLS13,
+		ClientAuth:         tls.RequireAnyClientCert,
+		InsecureSkipVerify: true, // #nosec G402: the peer certificate is verified in VerifyPeerCertificate
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 || !bytes.Equal(rawCerts[0], pinned) {
+				return errors.Errorf(errors.ErrGeneric, "unknown certificate")
+			}
+			return nil
+		},
+	}
+}
@@ -3,13 +3,15 @@ module github.com/zarbchain/zarb-go
 go 1.15
 
 require (
+	github.com/DataDog/zstd v1.4.1
 	github.com/btcsuite/btcd v0.21.0-beta // indirect
 	github.com/btcsuite/btcutil v1.0.3-0.20201201144236-d63d9f2b44dd
 	github.com/davidlazar/go-crypto v0.0.0-20190912175916-7055855a373f // indirect
 	github.com/dchest/blake2b v1.0.0
+	github.com/dgraph-io/badger/v2 v2.2007.2
 	github.com/fxamacker/cbor/v2 v2.2.0
 	github.com/golang/protobuf v1.4.3
-	github.com/golang/snappy v0.0.2-0.20190904063534-ff6b7dc882cf // indirect
+	github.com/golang/snappy v0.0.2-0.20190904063534-ff6b7dc882cf
 	github.com/google/btree v1.0.0
 	github.com/google/go-cmp v0.5.5 // indirect
 	github.com/google/uuid v1.2.0 // indirect
@@ -27,7 +29,9 @@ require (
 	github.com/libp2p/go-libp2p-kad-dht v0.11.0
 	github.com/libp2p/go-libp2p-noise v0.1.2 // indirect
 	github.com/libp2p/go-libp2p-pubsub v0.4.1
+	github.com/libp2p/go-libp2p-swarm v0.3.1
 	github.com/libp2p/go-libp2p-yamux v0.4.1 // indirect
+	github.com/libp2p/go-msgio v0.0.6
 	github.com/mattn/go-runewidth v0.0.8 // indirect
 	github.com/multiformats/go-multiaddr v0.3.1
 	github.com/onsi/ginkgo v1.14.2 // indirect
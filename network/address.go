@@ -1,6 +1,8 @@
 package network
 
 import (
+	"net"
+
 	lp2ppeer "github.com/libp2p/go-libp2p-core/peer"
 	"github.com/multiformats/go-multiaddr"
 )
@@ -23,3 +25,29 @@ func PeerAddrsToAddrInfo(addrs []string) ([]lp2ppeer.AddrInfo, error) {
 	}
 	return pis, nil
 }
+
+// PeerIDsFromStrings decodes a slice of string peer IDs.
+func PeerIDsFromStrings(ids []string) ([]lp2ppeer.ID, error) {
+	var pids []lp2ppeer.ID
+	for _, id := range ids {
+		pid, err := lp2ppeer.Decode(id)
+		if err != nil {
+			return nil, err
+		}
+		pids = append(pids, pid)
+	}
+	return pids, nil
+}
+
+// parseCIDRs parses a slice of subnets in CIDR notation.
+func parseCIDRs(cidrs []string) ([]*net.IPNet, error) {
+	var subnets []*net.IPNet
+	for _, cidr := range cidrs {
+		_, subnet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, err
+		}
+		subnets = append(subnets, subnet)
+	}
+	return subnets, nil
+}
@@ -0,0 +1,207 @@
+package network
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	lp2ppeer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/zarbchain/zarb-go/errors"
+	"github.com/zarbchain/zarb-go/util"
+)
+
+const (
+	maxAddressBookSize = 1000
+	maxAddressScore    = 10
+	// addressExpiry is the time after that a peer that we haven't seen is forgotten
+	addressExpiry = 30 * 24 * time.Hour
+)
+
+// AddressBook keeps the addresses of the good peers that we have been connected to.
+// Each time we see a peer connected its score is increased and each failed dial decreases it.
+// The peers with negative score are removed. The book is saved in a file, an empty path keeps it in memory.
+type AddressBook struct {
+	lk sync.RWMutex
+
+	path    string
+	entries map[lp2ppeer.ID]*addressEntry
+}
+
+type addressEntry struct {
+	Addresses []string  `json:"addresses"`
+	LastSeen  time.Time `json:"last_seen"`
+	Score     int       `json:"score"`
+}
+
+// AddressInfo contains the information of a peer in the address book
+type AddressInfo struct {
+	PeerID    lp2ppeer.ID
+	Addresses []string
+	LastSeen  time.Time
+	Score     int
+}
+
+// LoadAddressBook loads the address book from the file, if it exists
+func LoadAddressBook(path string) (*AddressBook, error) {
+	ab := &AddressBook{
+		path:    path,
+		entries: make(map[lp2ppeer.ID]*addressEntry),
+	}
+	if path == "" || !util.PathExists(path) {
+		return ab, nil
+	}
+
+	bs, err := util.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(bs, &ab.entries); err != nil {
+		return nil, errors.Errorf(errors.ErrGeneric, "invalid address book: %v", err)
+	}
+	ab.prune()
+	return ab, nil
+}
+
+// Seen records the addresses of a connected peer
+func (ab *AddressBook) Seen(pid lp2ppeer.ID, addrs []multiaddr.Multiaddr) {
+	if len(addrs) == 0 {
+		return
+	}
+	ab.lk.Lock()
+	defer ab.lk.Unlock()
+
+	e, ok := ab.entries[pid]
+	if !ok {
+		e = &addressEntry{}
+		ab.entries[pid] = e
+	}
+	e.Addresses = make([]string, 0, len(addrs))
+	for _, a := range addrs {
+		e.Addresses = append(e.Addresses, a.String())
+	}
+	e.LastSeen = util.Now()
+	if e.Score < maxAddressScore {
+		e.Score++
+	}
+}
+
+// Failed decreases the score of the peer that we couldn't connect to
+func (ab *AddressBook) Failed(pid lp2ppeer.ID) {
+	ab.lk.Lock()
+	defer ab.lk.Unlock()
+
+	e, ok := ab.entries[pid]
+	if !ok {
+		return
+	}
+	e.Score--
+	if e.Score < 0 {
+		delete(ab.entries, pid)
+	}
+}
+
+// Remove removes the peer from the address book
+func (ab *AddressBook) Remove(pid lp2ppeer.ID) {
+	ab.lk.Lock()
+	defer ab.lk.Unlock()
+
+	delete(ab.entries, pid)
+}
+
+func (ab *AddressBook) Len() int {
+	ab.lk.RLock()
+	defer ab.lk.RUnlock()
+
+	return len(ab.entries)
+}
+
+// Entries returns the peers in the address book, the best peers first
+func (ab *AddressBook) Entries() []AddressInfo {
+	ab.lk.RLock()
+	defer ab.lk.RUnlock()
+
+	return ab.sortedEntries()
+}
+
+// Best returns the address of the best peers that are not excluded
+func (ab *AddressBook) Best(count int, exclude func(lp2ppeer.ID) bool) []lp2ppeer.AddrInfo {
+	ab.lk.RLock()
+	defer ab.lk.RUnlock()
+
+	infos := []lp2ppeer.AddrInfo{}
+	for _, e := range ab.sortedEntries() {
+		if len(infos) >= count {
+			break
+		}
+		if exclude(e.PeerID) {
+			continue
+		}
+		info := lp2ppeer.AddrInfo{ID: e.PeerID}
+		for _, s := range e.Addresses {
+			a, err := multiaddr.NewMultiaddr(s)
+			if err != nil {
+				continue
+			}
+			info.Addrs = append(info.Addrs, a)
+		}
+		if len(info.Addrs) > 0 {
+			infos = append(infos, info)
+		}
+	}
+	return infos
+}
+
+func (ab *AddressBook) sortedEntries() []AddressInfo {
+	infos := make([]AddressInfo, 0, len(ab.entries))
+	for pid, e := range ab.entries {
+		infos = append(infos, AddressInfo{
+			PeerID:    pid,
+			Addresses: e.Addresses,
+			LastSeen:  e.LastSeen,
+			Score:     e.Score,
+		})
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		if infos[i].Score != infos[j].Score {
+			return infos[i].Score > infos[j].Score
+		}
+		return infos[i].LastSeen.After(infos[j].LastSeen)
+	})
+	return infos
+}
+
+// prune removes the expired peers and keeps the book smaller than the maximum size
+func (ab *AddressBook) prune() {
+	for pid, e := range ab.entries {
+		if util.Now().Sub(e.LastSeen) > addressExpiry {
+			delete(ab.entries, pid)
+		}
+	}
+	infos := ab.sortedEntries()
+	for i := maxAddressBookSize; i < len(infos); i++ {
+		delete(ab.entries, infos[i].PeerID)
+	}
+}
+
+// Save writes the address book into a temporary file and then renames it
+func (ab *AddressBook) Save() error {
+	ab.lk.Lock()
+	defer ab.lk.Unlock()
+
+	if ab.path == "" {
+		return nil
+	}
+	ab.prune()
+	bs, err := json.MarshalIndent(ab.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := ab.path + ".tmp"
+	if err := util.WriteFile(tmp, bs); err != nil {
+		return err
+	}
+	return os.Rename(tmp, ab.path)
+}
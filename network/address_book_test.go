@@ -0,0 +1,123 @@
+package network
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	lp2peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zarbchain/zarb-go/logger"
+	"github.com/zarbchain/zarb-go/util"
+)
+
+func generateTestAddrs(t *testing.T) []multiaddr.Multiaddr {
+	a, err := multiaddr.NewMultiaddr("/ip4/127.0.0.1/tcp/12345")
+	require.NoError(t, err)
+	return []multiaddr.Multiaddr{a}
+}
+
+func TestAddressBook(t *testing.T) {
+	ab, err := LoadAddressBook("")
+	assert.NoError(t, err)
+	pid1 := util.RandomPeerID()
+	pid2 := util.RandomPeerID()
+
+	ab.Seen(pid1, generateTestAddrs(t))
+	ab.Seen(pid2, generateTestAddrs(t))
+	ab.Seen(pid2, generateTestAddrs(t))
+	ab.Seen(util.RandomPeerID(), nil)
+	assert.Equal(t, ab.Len(), 2)
+
+	t.Run("Best peers first", func(t *testing.T) {
+		entries := ab.Entries()
+		assert.Equal(t, entries[0].PeerID, pid2)
+		assert.Equal(t, entries[0].Score, 2)
+		assert.Equal(t, entries[0].Addresses, []string{"/ip4/127.0.0.1/tcp/12345"})
+
+		best := ab.Best(1, func(lp2peer.ID) bool { return false })
+		assert.Equal(t, len(best), 1)
+		assert.Equal(t, best[0].ID, pid2)
+
+		best = ab.Best(2, func(pid lp2peer.ID) bool { return pid == pid2 })
+		assert.Equal(t, len(best), 1)
+		assert.Equal(t, best[0].ID, pid1)
+	})
+
+	t.Run("Peers with negative score are removed", func(t *testing.T) {
+		ab.Failed(pid1)
+		assert.Equal(t, ab.Len(), 2)
+		ab.Failed(pid1)
+		assert.Equal(t, ab.Len(), 1)
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		ab.Remove(pid2)
+		assert.Zero(t, ab.Len())
+	})
+}
+
+func TestSaveAddressBook(t *testing.T) {
+	path := util.TempDirPath() + "/address_book.json"
+	ab, err := LoadAddressBook(path)
+	assert.NoError(t, err)
+
+	pid1 := util.RandomPeerID()
+	pid2 := util.RandomPeerID()
+	ab.Seen(pid1, generateTestAddrs(t))
+	ab.Seen(pid2, generateTestAddrs(t))
+	ab.entries[pid2].LastSeen = util.Now().Add(-addressExpiry - time.Hour)
+	assert.NoError(t, ab.Save())
+
+	ab2, err := LoadAddressBook(path)
+	assert.NoError(t, err)
+	assert.Equal(t, ab2.Len(), 1)
+	assert.Equal(t, ab2.Entries()[0].PeerID, pid1)
+
+	t.Run("Invalid file", func(t *testing.T) {
+		assert.NoError(t, util.WriteFile(path, []byte("invalid")))
+		_, err := LoadAddressBook(path)
+		assert.Error(t, err)
+	})
+}
+
+func TestReconnectToKnownPeers(t *testing.T) {
+	logger.InitLogger(logger.TestConfig())
+	net1, _ := setupNetwork(t)
+	net2, _ := setupNetwork(t)
+	defer net1.Stop()
+	defer net2.Stop()
+
+	require.NoError(t, net1.host.Connect(context.Background(), lp2peer.AddrInfo{
+		ID:    net2.SelfID(),
+		Addrs: net2.host.Addrs(),
+	}))
+	net1.bootstrapper.checkConnectivity()
+	require.Equal(t, len(net1.AddressBook()), 1)
+	assert.Equal(t, net1.AddressBook()[0].PeerID, net2.SelfID())
+
+	t.Run("Disconnected node connects to the peers of the address book", func(t *testing.T) {
+		net1.CloseConnection(net2.SelfID())
+		assert.Empty(t, net1.AddressBook())
+
+		net1.addressBook.Seen(net2.SelfID(), net2.host.Addrs())
+		net1.bootstrapper.checkConnectivity()
+		assert.Contains(t, net1.host.Network().Peers(), net2.SelfID())
+	})
+
+	t.Run("Unreachable peers lose their score", func(t *testing.T) {
+		pid := util.RandomPeerID()
+		net1.addressBook.Seen(pid, generateTestAddrs(t))
+		net1.bootstrapper.checkConnectivity()
+		found := false
+		for _, e := range net1.AddressBook() {
+			if e.PeerID == pid {
+				found = true
+				assert.Zero(t, e.Score)
+			}
+		}
+		assert.True(t, found)
+	})
+}
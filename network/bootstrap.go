@@ -15,13 +15,16 @@ import (
 
 // Bootstrapper attempts to keep the p2p host connected to the network
 // by keeping a minimum threshold of connections. If the threshold isn't met it
-// connects to a random subset of the bootstrap peers. It does not use peer routing
-// to discover new peers. To stop a Bootstrapper cancel the context passed in Start()
-// or call Stop().
+// connects to a random subset of the bootstrap peers and the best peers of the address book.
+// It does not use peer routing to discover new peers. To stop a Bootstrapper cancel the context
+// passed in Start() or call Stop().
 type Bootstrapper struct {
 	config *BootstrapConfig
 
-	bootstrapPeers []lp2ppeer.AddrInfo
+	bootstrapPeers  []lp2ppeer.AddrInfo
+	persistentPeers []lp2ppeer.AddrInfo
+	addressBook     *AddressBook
+	isPrivate       func(lp2ppeer.ID) bool
 
 	// Dependencies
 	host    lp2phost.Host
@@ -38,22 +41,30 @@ type Bootstrapper struct {
 
 // NewBootstrapper returns a new Bootstrapper that will attempt to keep connected
 // to the network by connecting to the given bootstrap peers.
-func NewBootstrapper(ctx context.Context, h lp2phost.Host, d lp2pnet.Dialer, r lp2prouting.Routing, conf *BootstrapConfig, logger *logger.Logger) *Bootstrapper {
+func NewBootstrapper(ctx context.Context, h lp2phost.Host, d lp2pnet.Dialer, r lp2prouting.Routing,
+	ab *AddressBook, conf *BootstrapConfig, isPrivate func(lp2ppeer.ID) bool, logger *logger.Logger) *Bootstrapper {
 	b := &Bootstrapper{
-		ctx:     ctx,
-		config:  conf,
-		host:    h,
-		dialer:  d,
-		routing: r,
-		logger:  logger,
+		ctx:         ctx,
+		config:      conf,
+		host:        h,
+		dialer:      d,
+		routing:     r,
+		addressBook: ab,
+		isPrivate:   isPrivate,
+		logger:      logger,
 	}
 
 	addresses, err := PeerAddrsToAddrInfo(conf.Addresses)
 	if err != nil {
 		b.logger.Panic("couldn't parse bootstrap addresses", "addressed", conf.Addresses)
 	}
+	persistentPeers, err := PeerAddrsToAddrInfo(conf.PersistentPeers)
+	if err != nil {
+		b.logger.Panic("couldn't parse persistent peers", "addressed", conf.PersistentPeers)
+	}
 
 	b.bootstrapPeers = addresses
+	b.persistentPeers = persistentPeers
 	b.checkConnectivity()
 
 	return b
@@ -87,7 +98,8 @@ func (b *Bootstrapper) Stop() {
 
 // checkConnectivity does the actual work. If the number of connected peers
 // has fallen below b.MinPeerThreshold it will attempt to connect to
-// a random subset of its bootstrap peers.
+// a random subset of its bootstrap peers and the peers of the address book.
+// The connected peers are recorded in the address book.
 func (b *Bootstrapper) checkConnectivity() {
 	currentPeers := b.dialer.Peers()
 	b.logger.Debug("Check connectivity", "peers", len(currentPeers))
@@ -102,6 +114,8 @@ func (b *Bootstrapper) checkConnectivity() {
 			b.logger.Warn("Peer is not connected to us", "peer", p)
 		}
 	}
+	b.updateAddressBook(connectedPeers)
+	b.connectPersistentPeers(connectedPeers)
 
 	if len(connectedPeers) > b.config.MaxThreshold {
 		b.logger.Debug("peer count is about maximum threshold", "count", len(connectedPeers), "threshold", b.config.MaxThreshold)
@@ -144,6 +158,25 @@ func (b *Bootstrapper) checkConnectivity() {
 			}(pinfo)
 		}
 
+		// When the bootstrap nodes are down, we can still find the network through the peers that we knew before
+		needed := b.config.MinThreshold - len(connectedPeers)
+		candidates := b.addressBook.Best(needed, func(pid lp2ppeer.ID) bool {
+			return pid == b.host.ID() || hasPID(connectedPeers, pid) ||
+				hasAddrInfo(b.bootstrapPeers, pid) || hasAddrInfo(b.persistentPeers, pid)
+		})
+		for _, pinfo := range candidates {
+			b.logger.Trace("Try connecting to a known peer.", "peer", pinfo.String())
+
+			wg.Add(1)
+			go func(pi lp2ppeer.AddrInfo) {
+				if err := b.host.Connect(ctx, pi); err != nil {
+					b.logger.Debug("unable to connect to a known peer", "info", pi, "err", err.Error())
+					b.addressBook.Failed(pi.ID)
+				}
+				wg.Done()
+			}(pinfo)
+		}
+
 		peers := b.host.Peerstore().Peers()
 		b.logger.Debug("Peer store info", "peers", peers)
 	}
@@ -158,6 +191,51 @@ func hasPID(pids []lp2ppeer.ID, pid lp2ppeer.ID) bool {
 	return false
 }
 
+func hasAddrInfo(infos []lp2ppeer.AddrInfo, pid lp2ppeer.ID) bool {
+	for _, i := range infos {
+		if i.ID == pid {
+			return true
+		}
+	}
+	return false
+}
+
+// connectPersistentPeers reconnects to the persistent peers, regardless of the number of connections
+func (b *Bootstrapper) connectPersistentPeers(connectedPeers []lp2ppeer.ID) {
+	var wg sync.WaitGroup
+	ctx, cancel := context.WithTimeout(b.ctx, time.Second*10)
+	defer cancel()
+
+	for _, pinfo := range b.persistentPeers {
+		if hasPID(connectedPeers, pinfo.ID) {
+			continue
+		}
+		b.logger.Debug("Try connecting to a persistent peer.", "peer", pinfo.String())
+
+		wg.Add(1)
+		go func(pi lp2ppeer.AddrInfo) {
+			if err := b.host.Connect(ctx, pi); err != nil {
+				b.logger.Warn("unable to connect to a persistent peer", "info", pi, "err", err.Error())
+			}
+			wg.Done()
+		}(pinfo)
+	}
+	wg.Wait()
+}
+
+// updateAddressBook records the connected peers, except the private peers, and saves the address book
+func (b *Bootstrapper) updateAddressBook(connectedPeers []lp2ppeer.ID) {
+	for _, p := range connectedPeers {
+		if b.isPrivate(p) {
+			continue
+		}
+		b.addressBook.Seen(p, b.host.Peerstore().Addrs(p))
+	}
+	if err := b.addressBook.Save(); err != nil {
+		b.logger.Warn("Unable to save the address book", "err", err)
+	}
+}
+
 func (b *Bootstrapper) bootstrapIpfsRouting() error {
 	dht, ok := b.routing.(*lp2pdht.IpfsDHT)
 	if !ok {
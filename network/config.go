@@ -3,26 +3,44 @@ package network
 import (
 	"time"
 
+	"github.com/zarbchain/zarb-go/errors"
 	"github.com/zarbchain/zarb-go/util"
 )
 
 type Config struct {
-	Name             string           `toml:"" comment:"Name dispay network name."`
-	ListenAddress    []string         `toml:"" comment:"ListenAddress which support multiaddrs."`
-	NodeKeyFile      string           `toml:"" comment:"NodeKeyFile contains the private key to use for node authentication in the p2p protocol."`
-	EnableNATService bool             `toml:"" comment:"EnableNATService NAT allows many machines to share a single public address."`
-	EnableRelay      bool             `toml:"" comment:"EnableRelay is a transport protocol that routes traffic between two peers over a third-party “relay” peer."`
-	EnableMDNS       bool             `toml:"" comment:"EnableMDNS is a protocol to discover local peers quickly and efficiently."`
-	EnableKademlia   bool             `toml:"" comment:"EnableKademlia which is used a routing algorithm and it uses the dht routing table."`
-	Bootstrap        *BootstrapConfig `toml:"" comment:"Bootstrap comma separated list of peers to be added to the peer store on startup bootstrap peers."`
+	Name              string           `toml:"" comment:"Name dispay network name."`
+	ListenAddress     []string         `toml:"" comment:"ListenAddress which support multiaddrs."`
+	NodeKeyFile       string           `toml:"" comment:"NodeKeyFile contains the private key to use for node authentication in the p2p protocol."`
+	EnableNATService  bool             `toml:"" comment:"EnableNATService NAT allows many machines to share a single public address."`
+	EnableRelay       bool             `toml:"" comment:"EnableRelay is a transport protocol that routes traffic between two peers over a third-party “relay” peer."`
+	EnableMDNS        bool             `toml:"" comment:"EnableMDNS is a protocol to discover local peers quickly and efficiently."`
+	EnableKademlia    bool             `toml:"" comment:"EnableKademlia which is used a routing algorithm and it uses the dht routing table."`
+	Bootstrap         *BootstrapConfig `toml:"" comment:"Bootstrap comma separated list of peers to be added to the peer store on startup bootstrap peers."`
+	StreamTimeout     time.Duration    `toml:"" comment:"StreamTimeout is the timeout for opening, writing and reading a direct stream."`
+	MaxInboundStreams int              `toml:"" comment:"MaxInboundStreams is the number of direct streams that are handled at the same time. Other peers wait until a stream is handled."`
+	AddressBookPath   string           `toml:"" comment:"AddressBookPath is the path of the file that keeps the addresses of the known peers. Empty path keeps them in memory. Default is ./data/address_book.json"`
+	Gater             *GaterConfig     `toml:"" comment:"Gater decides which connections are allowed."`
 }
 
 // BootstrapConfig holds all configuration options related to bootstrap nodes
+// A validator can run behind sentry nodes. The validator sets the sentry nodes as persistent peers
+// and disables mDNS and Kademlia. Each sentry node sets the validator as a persistent, private and unconditional peer.
 type BootstrapConfig struct {
-	Addresses    []string      `toml:"" comment:"Addresses it is List of peers address needed for peer discovery."`
-	MinThreshold int           `toml:"" comment:"MinPeerThreshold is the number of connections it attempts to maintain."`
-	MaxThreshold int           `toml:"" comment:"MaxThreshold is the threshold of maximum number of connections."`
-	Period       time.Duration `toml:"" comment:"Period periodically checks to see if the threshold is maintained."`
+	Addresses            []string      `toml:"" comment:"Addresses it is List of peers address needed for peer discovery."`
+	MinThreshold         int           `toml:"" comment:"MinPeerThreshold is the number of connections it attempts to maintain."`
+	MaxThreshold         int           `toml:"" comment:"MaxThreshold is the threshold of maximum number of connections."`
+	Period               time.Duration `toml:"" comment:"Period periodically checks to see if the threshold is maintained."`
+	PersistentPeers      []string      `toml:"" comment:"PersistentPeers is the list of peer addresses that the node always reconnects to. They can connect even if MaxThreshold is reached."`
+	PrivatePeerIDs       []string      `toml:"" comment:"PrivatePeerIDs is the list of peer IDs that their addresses are never shared with other peers, like the validator behind a sentry node."`
+	UnconditionalPeerIDs []string      `toml:"" comment:"UnconditionalPeerIDs is the list of peer IDs that can connect even if MaxThreshold is reached."`
+}
+
+// GaterConfig holds the configuration of the connection gater
+type GaterConfig struct {
+	AllowedCIDRs      []string `toml:"" comment:"AllowedCIDRs is the list of subnets that the node accepts connections from and dials to, like 10.0.0.0/8. Empty list allows all addresses."`
+	DeniedCIDRs       []string `toml:"" comment:"DeniedCIDRs is the list of subnets that the node never accepts connections from or dials to."`
+	MaxConnsPerIP     int      `toml:"" comment:"MaxConnsPerIP is the maximum number of connections from one IP address. Zero disables the limit."`
+	MaxConnsPerSubnet int      `toml:"" comment:"MaxConnsPerSubnet is the maximum number of connections from one /24 IPv4 or /64 IPv6 subnet. Zero disables the limit."`
 }
 
 func DefaultConfig() *Config {
@@ -39,6 +57,19 @@ func DefaultConfig() *Config {
 			MinThreshold: 8,
 			MaxThreshold: 16,
 			Period:       1 * time.Minute,
+
+			PersistentPeers:      []string{},
+			PrivatePeerIDs:       []string{},
+			UnconditionalPeerIDs: []string{},
+		},
+		StreamTimeout:     20 * time.Second,
+		MaxInboundStreams: 16,
+		AddressBookPath:   "data/address_book.json",
+		Gater: &GaterConfig{
+			AllowedCIDRs:      []string{},
+			DeniedCIDRs:       []string{},
+			MaxConnsPerIP:     4,
+			MaxConnsPerSubnet: 8,
 		},
 	}
 }
@@ -57,11 +88,51 @@ func TestConfig() *Config {
 			MinThreshold: 4,
 			MaxThreshold: 8,
 			Period:       1 * time.Minute,
+
+			PersistentPeers:      []string{},
+			PrivatePeerIDs:       []string{},
+			UnconditionalPeerIDs: []string{},
+		},
+		StreamTimeout:     5 * time.Second,
+		MaxInboundStreams: 4,
+		AddressBookPath:   "",
+		Gater: &GaterConfig{
+			AllowedCIDRs:      []string{},
+			DeniedCIDRs:       []string{},
+			MaxConnsPerIP:     0,
+			MaxConnsPerSubnet: 0,
 		},
 	}
 }
 
 // SanityCheck is a basic checks for config
 func (conf *Config) SanityCheck() error {
+	if conf.StreamTimeout <= 0 {
+		return errors.Errorf(errors.ErrInvalidConfig, "StreamTimeout should be positive")
+	}
+	if conf.MaxInboundStreams < 1 {
+		return errors.Errorf(errors.ErrInvalidConfig, "MaxInboundStreams should be at least one")
+	}
+	if _, err := PeerAddrsToAddrInfo(conf.Bootstrap.Addresses); err != nil {
+		return errors.Errorf(errors.ErrInvalidConfig, "invalid bootstrap address: %v", err)
+	}
+	if _, err := PeerAddrsToAddrInfo(conf.Bootstrap.PersistentPeers); err != nil {
+		return errors.Errorf(errors.ErrInvalidConfig, "invalid persistent peer: %v", err)
+	}
+	if _, err := PeerIDsFromStrings(conf.Bootstrap.PrivatePeerIDs); err != nil {
+		return errors.Errorf(errors.ErrInvalidConfig, "invalid private peer: %v", err)
+	}
+	if _, err := PeerIDsFromStrings(conf.Bootstrap.UnconditionalPeerIDs); err != nil {
+		return errors.Errorf(errors.ErrInvalidConfig, "invalid unconditional peer: %v", err)
+	}
+	if _, err := parseCIDRs(conf.Gater.AllowedCIDRs); err != nil {
+		return errors.Errorf(errors.ErrInvalidConfig, "invalid allowed subnet: %v", err)
+	}
+	if _, err := parseCIDRs(conf.Gater.DeniedCIDRs); err != nil {
+		return errors.Errorf(errors.ErrInvalidConfig, "invalid denied subnet: %v", err)
+	}
+	if conf.Gater.MaxConnsPerIP < 0 || conf.Gater.MaxConnsPerSubnet < 0 {
+		return errors.Errorf(errors.ErrInvalidConfig, "connection limits should not be negative")
+	}
 	return nil
 }
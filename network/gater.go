@@ -0,0 +1,243 @@
+package network
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	lp2pconnmgr "github.com/libp2p/go-libp2p-core/connmgr"
+	lp2pcontrol "github.com/libp2p/go-libp2p-core/control"
+	lp2pnetwork "github.com/libp2p/go-libp2p-core/network"
+	lp2peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+	"github.com/zarbchain/zarb-go/util"
+)
+
+var _ lp2pconnmgr.ConnectionGater = &connectionGater{}
+
+// The subnet of an IP address, for limiting the connections per subnet
+var (
+	subnetMaskIPv4 = net.CIDRMask(24, 32)
+	subnetMaskIPv6 = net.CIDRMask(64, 128)
+)
+
+// connectionGater decides which connections are allowed:
+//   - Addresses outside the allowed subnets or inside the denied subnets are rejected.
+//   - Banned peers and their addresses are rejected until their ban expires.
+//   - Inbound connections are rejected when the node has reached MaxThreshold,
+//     or too many connections are from the same IP address or subnet.
+//
+// Unconditional and persistent peers are not limited by the bans of IP addresses or the connection limits.
+type connectionGater struct {
+	lk sync.RWMutex
+
+	config        *GaterConfig
+	maxConns      int
+	allowed       []*net.IPNet
+	denied        []*net.IPNet
+	unconditional map[lp2peer.ID]bool
+	bannedPeers   map[lp2peer.ID]time.Time
+	bannedIPs     map[string]time.Time
+	net           lp2pnetwork.Network
+}
+
+func newConnectionGater(conf *GaterConfig, maxConns int, unconditional []lp2peer.ID) (*connectionGater, error) {
+	allowed, err := parseCIDRs(conf.AllowedCIDRs)
+	if err != nil {
+		return nil, err
+	}
+	denied, err := parseCIDRs(conf.DeniedCIDRs)
+	if err != nil {
+		return nil, err
+	}
+	g := &connectionGater{
+		config:        conf,
+		maxConns:      maxConns,
+		allowed:       allowed,
+		denied:        denied,
+		unconditional: make(map[lp2peer.ID]bool),
+		bannedPeers:   make(map[lp2peer.ID]time.Time),
+		bannedIPs:     make(map[string]time.Time),
+	}
+	for _, pid := range unconditional {
+		g.unconditional[pid] = true
+	}
+	return g, nil
+}
+
+// setNetwork sets the network of the host, that is created after the gater
+func (g *connectionGater) setNetwork(net lp2pnetwork.Network) {
+	g.lk.Lock()
+	defer g.lk.Unlock()
+
+	g.net = net
+}
+
+// banPeer bans the peer until `until` and its IP addresses until `ipsUntil`.
+// Zero time bans the peer permanently, but the IP addresses are not banned permanently.
+func (g *connectionGater) banPeer(pid lp2peer.ID, until time.Time, ips []net.IP, ipsUntil time.Time) {
+	g.lk.Lock()
+	defer g.lk.Unlock()
+
+	g.bannedPeers[pid] = until
+	if ipsUntil.IsZero() {
+		return
+	}
+	for _, ip := range ips {
+		g.bannedIPs[ip.String()] = ipsUntil
+	}
+}
+
+func isBanExpired(until time.Time) bool {
+	return !until.IsZero() && util.Now().After(until)
+}
+
+func (g *connectionGater) isPeerBanned(pid lp2peer.ID) bool {
+	g.lk.Lock()
+	defer g.lk.Unlock()
+
+	until, ok := g.bannedPeers[pid]
+	if !ok {
+		return false
+	}
+	if isBanExpired(until) {
+		delete(g.bannedPeers, pid)
+		return false
+	}
+	return true
+}
+
+func (g *connectionGater) isIPBanned(ip net.IP) bool {
+	g.lk.Lock()
+	defer g.lk.Unlock()
+
+	until, ok := g.bannedIPs[ip.String()]
+	if !ok {
+		return false
+	}
+	if isBanExpired(until) {
+		delete(g.bannedIPs, ip.String())
+		return false
+	}
+	return true
+}
+
+// isAddressAllowed checks the IP address against the allowed and denied subnets
+func (g *connectionGater) isAddressAllowed(ip net.IP) bool {
+	for _, subnet := range g.denied {
+		if subnet.Contains(ip) {
+			return false
+		}
+	}
+	if len(g.allowed) == 0 {
+		return true
+	}
+	for _, subnet := range g.allowed {
+		if subnet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func sameSubnet(ip1, ip2 net.IP) bool {
+	if ip4 := ip1.To4(); ip4 != nil {
+		ip2 = ip2.To4()
+		return ip2 != nil && ip4.Mask(subnetMaskIPv4).Equal(ip2.Mask(subnetMaskIPv4))
+	}
+	return ip1.Mask(subnetMaskIPv6).Equal(ip2.Mask(subnetMaskIPv6))
+}
+
+// isFull returns true if we have reached the maximum connections and we are not connected to this peer.
+func (g *connectionGater) isFull(p lp2peer.ID) bool {
+	g.lk.RLock()
+	defer g.lk.RUnlock()
+
+	if g.net == nil {
+		return false
+	}
+	if g.net.Connectedness(p) == lp2pnetwork.Connected {
+		return false
+	}
+	return len(g.net.Peers()) >= g.maxConns
+}
+
+// exceedsLimits returns true if there are too many connections from this IP address or its subnet.
+func (g *connectionGater) exceedsLimits(ip net.IP) bool {
+	g.lk.RLock()
+	defer g.lk.RUnlock()
+
+	if g.net == nil {
+		return false
+	}
+	perIP := 0
+	perSubnet := 0
+	for _, c := range g.net.Conns() {
+		remote, err := manet.ToIP(c.RemoteMultiaddr())
+		if err != nil {
+			continue
+		}
+		if remote.Equal(ip) {
+			perIP++
+		}
+		if sameSubnet(remote, ip) {
+			perSubnet++
+		}
+	}
+	if g.config.MaxConnsPerIP > 0 && perIP >= g.config.MaxConnsPerIP {
+		return true
+	}
+	if g.config.MaxConnsPerSubnet > 0 && perSubnet >= g.config.MaxConnsPerSubnet {
+		return true
+	}
+	return false
+}
+
+func (g *connectionGater) InterceptPeerDial(p lp2peer.ID) bool {
+	return !g.isPeerBanned(p)
+}
+
+func (g *connectionGater) InterceptAddrDial(p lp2peer.ID, a multiaddr.Multiaddr) bool {
+	ip, err := manet.ToIP(a)
+	if err != nil {
+		// Not an IP address, like relay addresses
+		return true
+	}
+	if !g.isAddressAllowed(ip) {
+		return false
+	}
+	return g.unconditional[p] || !g.isIPBanned(ip)
+}
+
+func (g *connectionGater) InterceptAccept(cma lp2pnetwork.ConnMultiaddrs) bool {
+	ip, err := manet.ToIP(cma.RemoteMultiaddr())
+	if err != nil {
+		return true
+	}
+	return g.isAddressAllowed(ip)
+}
+
+func (g *connectionGater) InterceptSecured(dir lp2pnetwork.Direction, p lp2peer.ID, cma lp2pnetwork.ConnMultiaddrs) bool {
+	if g.isPeerBanned(p) {
+		return false
+	}
+	if dir != lp2pnetwork.DirInbound {
+		return true
+	}
+	if g.unconditional[p] {
+		return true
+	}
+	if g.isFull(p) {
+		return false
+	}
+	ip, err := manet.ToIP(cma.RemoteMultiaddr())
+	if err != nil {
+		return true
+	}
+	return !g.isIPBanned(ip) && !g.exceedsLimits(ip)
+}
+
+func (g *connectionGater) InterceptUpgraded(conn lp2pnetwork.Conn) (bool, lp2pcontrol.DisconnectReason) {
+	return true, 0
+}
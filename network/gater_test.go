@@ -0,0 +1,221 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	lp2pnetwork "github.com/libp2p/go-libp2p-core/network"
+	lp2peer "github.com/libp2p/go-libp2p-core/peer"
+	swarm "github.com/libp2p/go-libp2p-swarm"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zarbchain/zarb-go/logger"
+	"github.com/zarbchain/zarb-go/util"
+)
+
+type connAddrs struct {
+	local  multiaddr.Multiaddr
+	remote multiaddr.Multiaddr
+}
+
+func (c *connAddrs) LocalMultiaddr() multiaddr.Multiaddr  { return c.local }
+func (c *connAddrs) RemoteMultiaddr() multiaddr.Multiaddr { return c.remote }
+
+func newConnAddrs(t *testing.T, remote string) *connAddrs {
+	return &connAddrs{
+		local:  multiaddr.StringCast("/ip4/127.0.0.1/tcp/21888"),
+		remote: multiaddr.StringCast(remote),
+	}
+}
+
+func connect(from, to *network) error {
+	return from.host.Connect(context.Background(), lp2peer.AddrInfo{
+		ID:    to.SelfID(),
+		Addrs: to.host.Addrs(),
+	})
+}
+
+func p2pAddress(t *testing.T, n *network) string {
+	addrs, err := lp2peer.AddrInfoToP2pAddrs(&lp2peer.AddrInfo{ID: n.SelfID(), Addrs: n.host.Addrs()})
+	require.NoError(t, err)
+	return addrs[0].String()
+}
+
+func TestUnconditionalPeers(t *testing.T) {
+	logger.InitLogger(logger.TestConfig())
+	net2, _ := setupNetwork(t)
+	net3, _ := setupNetwork(t)
+	net4, _ := setupNetwork(t)
+	defer net2.Stop()
+	defer net3.Stop()
+	defer net4.Stop()
+
+	conf := testNetworkConfig()
+	conf.Bootstrap.MaxThreshold = 1
+	conf.Bootstrap.UnconditionalPeerIDs = []string{net4.SelfID().String()}
+	net1, _ := setupNetworkWithConfig(t, conf)
+	defer net1.Stop()
+
+	assert.NoError(t, connect(net2, net1))
+	assert.Error(t, connect(net3, net1), "net1 has reached the maximum connections")
+	assert.NoError(t, connect(net4, net1), "net4 is unconditional")
+	assert.NoError(t, connect(net1, net3), "net1 can dial other peers")
+}
+
+func TestPersistentPeers(t *testing.T) {
+	logger.InitLogger(logger.TestConfig())
+	net2, _ := setupNetwork(t)
+	defer net2.Stop()
+
+	conf := testNetworkConfig()
+	conf.Bootstrap.MaxThreshold = 0
+	conf.Bootstrap.PersistentPeers = []string{p2pAddress(t, net2)}
+	net1, _ := setupNetworkWithConfig(t, conf)
+	defer net1.Stop()
+
+	assert.Contains(t, net1.host.Network().Peers(), net2.SelfID(), "net1 should connect to the persistent peer on start")
+
+	t.Run("Persistent peer can connect even if the node is full", func(t *testing.T) {
+		net1.CloseConnection(net2.SelfID())
+		assert.NoError(t, connect(net2, net1))
+	})
+
+	t.Run("Node reconnects to the persistent peer", func(t *testing.T) {
+		net1.CloseConnection(net2.SelfID())
+		assert.NotContains(t, net1.host.Network().Peers(), net2.SelfID())
+
+		net1.bootstrapper.checkConnectivity()
+		assert.Contains(t, net1.host.Network().Peers(), net2.SelfID())
+	})
+}
+
+func TestPrivatePeers(t *testing.T) {
+	logger.InitLogger(logger.TestConfig())
+	net2, _ := setupNetwork(t)
+	net3, _ := setupNetwork(t)
+	defer net2.Stop()
+	defer net3.Stop()
+
+	conf := testNetworkConfig()
+	conf.Bootstrap.PrivatePeerIDs = []string{net2.SelfID().String()}
+	net1, _ := setupNetworkWithConfig(t, conf)
+	defer net1.Stop()
+
+	assert.NoError(t, connect(net1, net2))
+	assert.NoError(t, connect(net1, net3))
+	net1.bootstrapper.checkConnectivity()
+
+	assert.True(t, net1.isPrivate(net2.SelfID()))
+	assert.False(t, net1.isPrivate(net3.SelfID()))
+	entries := net1.AddressBook()
+	require.Equal(t, len(entries), 1, fmt.Sprintf("%v", entries))
+	assert.Equal(t, entries[0].PeerID, net3.SelfID())
+}
+
+func TestAllowedAndDeniedSubnets(t *testing.T) {
+	conf := TestConfig().Gater
+	conf.AllowedCIDRs = []string{"10.0.0.0/8", "fd00::/8"}
+	conf.DeniedCIDRs = []string{"10.1.0.0/16"}
+	g, err := newConnectionGater(conf, 10, nil)
+	require.NoError(t, err)
+	pid := util.RandomPeerID()
+
+	assert.True(t, g.InterceptAccept(newConnAddrs(t, "/ip4/10.0.0.1/tcp/21888")))
+	assert.True(t, g.InterceptAccept(newConnAddrs(t, "/ip6/fd00::1/tcp/21888")))
+	assert.False(t, g.InterceptAccept(newConnAddrs(t, "/ip4/10.1.0.1/tcp/21888")), "denied subnet")
+	assert.False(t, g.InterceptAccept(newConnAddrs(t, "/ip4/192.168.0.1/tcp/21888")), "not in the allowed subnets")
+
+	assert.True(t, g.InterceptAddrDial(pid, multiaddr.StringCast("/ip4/10.0.0.1/tcp/21888")))
+	assert.False(t, g.InterceptAddrDial(pid, multiaddr.StringCast("/ip4/10.1.0.1/tcp/21888")))
+	assert.True(t, g.InterceptAddrDial(pid, multiaddr.StringCast("/dns4/example.com/tcp/21888")), "not an IP address")
+
+	t.Run("Invalid subnets", func(t *testing.T) {
+		conf := TestConfig().Gater
+		conf.DeniedCIDRs = []string{"invalid"}
+		_, err := newConnectionGater(conf, 10, nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestConnectionsPerIP(t *testing.T) {
+	logger.InitLogger(logger.TestConfig())
+	net2, _ := setupNetwork(t)
+	net3, _ := setupNetwork(t)
+	net4, _ := setupNetwork(t)
+	defer net2.Stop()
+	defer net3.Stop()
+	defer net4.Stop()
+
+	conf := testNetworkConfig()
+	conf.Gater.MaxConnsPerIP = 1
+	conf.Bootstrap.UnconditionalPeerIDs = []string{net4.SelfID().String()}
+	net1, _ := setupNetworkWithConfig(t, conf)
+	defer net1.Stop()
+
+	assert.NoError(t, connect(net2, net1))
+	assert.Error(t, connect(net3, net1), "Too many connections from 127.0.0.1")
+	assert.NoError(t, connect(net4, net1), "net4 is unconditional")
+}
+
+func TestBanPeer(t *testing.T) {
+	logger.InitLogger(logger.TestConfig())
+	net1, _ := setupNetwork(t)
+	net2, _ := setupNetwork(t)
+	net3, _ := setupNetwork(t)
+	defer net1.Stop()
+	defer net2.Stop()
+	defer net3.Stop()
+
+	require.NoError(t, connect(net2, net1))
+	net1.BanPeer(net2.SelfID(), util.Now().Add(time.Hour), util.Now().Add(time.Hour))
+	assert.NotContains(t, net1.host.Network().Peers(), net2.SelfID())
+	// Waiting for net2 to see the closed connection
+	require.Eventually(t, func() bool {
+		return net2.host.Network().Connectedness(net1.SelfID()) != lp2pnetwork.Connected
+	}, 5*time.Second, 10*time.Millisecond)
+
+	t.Run("Banned peer can't reconnect", func(t *testing.T) {
+		assert.Error(t, connect(net2, net1))
+		assert.Error(t, connect(net1, net2))
+	})
+
+	t.Run("Other peers from the banned address are rejected", func(t *testing.T) {
+		assert.Error(t, connect(net3, net1))
+		assert.Error(t, connect(net1, net3))
+	})
+
+	t.Run("Ban expires", func(t *testing.T) {
+		for pid := range net1.gater.bannedPeers {
+			net1.gater.bannedPeers[pid] = util.Now().Add(-time.Second)
+		}
+		for ip := range net1.gater.bannedIPs {
+			net1.gater.bannedIPs[ip] = util.Now().Add(-time.Second)
+		}
+		// Clearing the dial backoffs of the failed dials
+		net1.host.Network().(*swarm.Swarm).Backoff().Clear(net3.SelfID())
+		net2.host.Network().(*swarm.Swarm).Backoff().Clear(net1.SelfID())
+		assert.NoError(t, connect(net2, net1))
+		assert.NoError(t, connect(net1, net3))
+	})
+
+	t.Run("Permanent ban", func(t *testing.T) {
+		net1.BanPeer(net2.SelfID(), time.Time{}, util.Now().Add(time.Hour))
+		assert.True(t, net1.gater.isPeerBanned(net2.SelfID()))
+		assert.True(t, net1.gater.bannedPeers[net2.SelfID()].IsZero())
+	})
+
+	t.Run("Addresses are not banned permanently", func(t *testing.T) {
+		for ip, until := range net1.gater.bannedIPs {
+			assert.False(t, until.IsZero(), ip)
+		}
+
+		pid := util.RandomPeerID()
+		net1.gater.banPeer(pid, time.Time{}, []net.IP{net.ParseIP("1.2.3.4")}, time.Time{})
+		assert.True(t, net1.gater.isPeerBanned(pid))
+		assert.False(t, net1.gater.isIPBanned(net.ParseIP("1.2.3.4")))
+	})
+}
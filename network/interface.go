@@ -1,19 +1,27 @@
 package network
 
 import (
+	"time"
+
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/zarbchain/zarb-go/sync/message"
 )
 
+// CallbackFn is called for the received messages with the peer that has sent them.
+// The sender is verified: it's the signer of the gossip messages, or the remote peer of the stream.
 type CallbackFn func([]byte, peer.ID)
 
 type Network interface {
 	Start() error
 	Stop()
 	PublishMessage(msg *message.Message) error
+	SendMessage(msg *message.Message, pid peer.ID) error
 	JoinTopics(CallbackFn) error
 	JoinDownloadTopic() error
 	LeaveDownloadTopic()
 	CloseConnection(pid peer.ID)
+	BanPeer(pid peer.ID, until time.Time, addrsUntil time.Time)
 	SelfID() peer.ID
+	Name() string
+	AddressBook() []AddressInfo
 }
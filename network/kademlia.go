@@ -6,20 +6,73 @@ import (
 
 	lp2pcore "github.com/libp2p/go-libp2p-core"
 	lp2phost "github.com/libp2p/go-libp2p-core/host"
+	lp2pnetwork "github.com/libp2p/go-libp2p-core/network"
+	lp2peer "github.com/libp2p/go-libp2p-core/peer"
+	lp2ppeerstore "github.com/libp2p/go-libp2p-core/peerstore"
 	lp2pdht "github.com/libp2p/go-libp2p-kad-dht"
+	"github.com/multiformats/go-multiaddr"
 )
 
-func (n *network) setupKademlia(ctx context.Context, host lp2phost.Host) (*lp2pdht.IpfsDHT, error) {
+func (n *network) setupKademlia(ctx context.Context, host lp2phost.Host, extraOpts ...lp2pdht.Option) (*lp2pdht.IpfsDHT, error) {
 
 	opts := []lp2pdht.Option{
 		lp2pdht.Mode(lp2pdht.ModeAuto),
 		lp2pdht.ProtocolPrefix(lp2pcore.ProtocolID(fmt.Sprintf("/zarb/kad/%s", n.config.Name))),
+		// Private peers are not added to the routing table, so they are never suggested as the closer peers
+		lp2pdht.RoutingTableFilter(func(dht *lp2pdht.IpfsDHT, conns []lp2pnetwork.Conn) bool {
+			for _, c := range conns {
+				if n.isPrivate(c.RemotePeer()) {
+					return false
+				}
+			}
+			return true
+		}),
 	}
+	opts = append(opts, extraOpts...)
 
-	dht, err := lp2pdht.New(ctx, host, opts...)
+	// The DHT answers FIND_PEER queries by the addresses inside the peerstore,
+	// even if the target is not in the routing table. It sees the peerstore without the private peers.
+	h := &privateHost{
+		Host: host,
+		ps: &privatePeerstore{
+			Peerstore: host.Peerstore(),
+			isPrivate: n.isPrivate,
+		},
+	}
+	dht, err := lp2pdht.New(ctx, h, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	return dht, nil
 }
+
+// privateHost is the host that the DHT runs on, it hides the addresses of the private peers
+type privateHost struct {
+	lp2phost.Host
+	ps *privatePeerstore
+}
+
+func (h *privateHost) Peerstore() lp2ppeerstore.Peerstore {
+	return h.ps
+}
+
+// privatePeerstore returns no address for the private peers
+type privatePeerstore struct {
+	lp2ppeerstore.Peerstore
+	isPrivate func(lp2peer.ID) bool
+}
+
+func (ps *privatePeerstore) Addrs(pid lp2peer.ID) []multiaddr.Multiaddr {
+	if ps.isPrivate(pid) {
+		return nil
+	}
+	return ps.Peerstore.Addrs(pid)
+}
+
+func (ps *privatePeerstore) PeerInfo(pid lp2peer.ID) lp2peer.AddrInfo {
+	if ps.isPrivate(pid) {
+		return lp2peer.AddrInfo{ID: pid}
+	}
+	return ps.Peerstore.PeerInfo(pid)
+}
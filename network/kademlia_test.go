@@ -0,0 +1,83 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	lp2pnetwork "github.com/libp2p/go-libp2p-core/network"
+	lp2peer "github.com/libp2p/go-libp2p-core/peer"
+	lp2pprotocol "github.com/libp2p/go-libp2p-core/protocol"
+	lp2pdht "github.com/libp2p/go-libp2p-kad-dht"
+	lp2pdhtpb "github.com/libp2p/go-libp2p-kad-dht/pb"
+	"github.com/libp2p/go-msgio"
+	"github.com/libp2p/go-msgio/protoio"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zarbchain/zarb-go/logger"
+)
+
+// findPeer sends a FIND_PEER query to the peer and returns the closer peers of the response
+func findPeer(t *testing.T, from, to *network, target lp2peer.ID) []*lp2peer.AddrInfo {
+	protocol := lp2pprotocol.ID(fmt.Sprintf("/zarb/kad/%s/kad/1.0.0", to.config.Name))
+	s, err := from.host.NewStream(context.Background(), to.SelfID(), protocol)
+	require.NoError(t, err)
+	defer s.Close()
+
+	req := lp2pdhtpb.NewMessage(lp2pdhtpb.Message_FIND_NODE, []byte(target), 0)
+	require.NoError(t, protoio.NewDelimitedWriter(s).WriteMsg(req))
+
+	r := msgio.NewVarintReaderSize(s, lp2pnetwork.MessageSizeMax)
+	bs, err := r.ReadMsg()
+	require.NoError(t, err)
+	res := new(lp2pdhtpb.Message)
+	require.NoError(t, res.Unmarshal(bs))
+
+	return lp2pdhtpb.PBPeersToPeerInfos(res.GetCloserPeers())
+}
+
+func TestKademliaPrivatePeers(t *testing.T) {
+	logger.InitLogger(logger.TestConfig())
+
+	private, _ := setupNetwork(t)
+	public, _ := setupNetwork(t)
+	client, _ := setupNetwork(t)
+	defer private.Stop()
+	defer public.Stop()
+	defer client.Stop()
+
+	conf := testNetworkConfig()
+	conf.Bootstrap.PrivatePeerIDs = []string{private.SelfID().String()}
+	sentry, _ := setupNetworkWithConfig(t, conf)
+	defer sentry.Stop()
+	dht, err := sentry.setupKademlia(sentry.ctx, sentry.host, lp2pdht.Mode(lp2pdht.ModeServer))
+	require.NoError(t, err)
+	sentry.kademlia = dht
+
+	for _, n := range []*network{private, public, client} {
+		require.NoError(t, connect(n, sentry))
+	}
+	require.Eventually(t, func() bool {
+		return len(sentry.host.Peerstore().Addrs(private.SelfID())) > 0 &&
+			len(sentry.host.Peerstore().Addrs(public.SelfID())) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	t.Run("The address of a public peer is returned", func(t *testing.T) {
+		infos := findPeer(t, client, sentry, public.SelfID())
+		found := false
+		for _, info := range infos {
+			if info.ID == public.SelfID() {
+				found = len(info.Addrs) > 0
+			}
+		}
+		assert.True(t, found)
+	})
+
+	t.Run("The address of a private peer is not returned", func(t *testing.T) {
+		infos := findPeer(t, client, sentry, private.SelfID())
+		for _, info := range infos {
+			assert.NotEqual(t, info.ID, private.SelfID())
+		}
+	})
+}
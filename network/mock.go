@@ -1,6 +1,8 @@
 package network
 
 import (
+	"time"
+
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/zarbchain/zarb-go/logger"
 	"github.com/zarbchain/zarb-go/sync/message"
@@ -12,13 +14,17 @@ type MockNetwork struct {
 	id          peer.ID
 	CallbackFn  CallbackFn
 	OtherNet    *MockNetwork
+	SentTo      peer.ID
 	Closed      bool
+	Addresses   []AddressInfo
+	BannedUntil map[peer.ID]time.Time
 }
 
 func MockingNetwork(id peer.ID) *MockNetwork {
 	return &MockNetwork{
 		BroadcastCh: make(chan *message.Message, 1000),
 		id:          id,
+		BannedUntil: make(map[peer.ID]time.Time),
 	}
 }
 func (mock *MockNetwork) Start() error {
@@ -37,6 +43,9 @@ func (mock *MockNetwork) LeaveDownloadTopic() {}
 func (mock *MockNetwork) SelfID() peer.ID {
 	return mock.id
 }
+func (mock *MockNetwork) Name() string {
+	return "zarb-test"
+}
 func (mock *MockNetwork) ReceivingMessageFromOtherPeer(initiator peer.ID, pld payload.Payload) {
 	msg := message.NewMessage(initiator, pld)
 	d, _ := msg.Encode()
@@ -52,6 +61,14 @@ func (mock *MockNetwork) PublishMessage(msg *message.Message) error {
 	mock.BroadcastCh <- msg
 	return nil
 }
+func (mock *MockNetwork) SendMessage(msg *message.Message, pid peer.ID) error {
+	if err := msg.SanityCheck(); err != nil {
+		return err
+	}
+	mock.SentTo = pid
+	mock.BroadcastCh <- msg
+	return nil
+}
 func (mock *MockNetwork) SendMessageToOthePeer(msg *message.Message) {
 	d, _ := msg.Encode()
 	if d != nil {
@@ -62,3 +79,10 @@ func (mock *MockNetwork) SendMessageToOthePeer(msg *message.Message) {
 func (mock *MockNetwork) CloseConnection(pid peer.ID) {
 	mock.Closed = true
 }
+func (mock *MockNetwork) BanPeer(pid peer.ID, until time.Time, addrsUntil time.Time) {
+	mock.BannedUntil[pid] = until
+	mock.Closed = true
+}
+func (mock *MockNetwork) AddressBook() []AddressInfo {
+	return mock.Addresses
+}
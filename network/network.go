@@ -4,17 +4,23 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"net"
 	"sync"
 	syncer "sync"
+	"time"
 
 	lp2p "github.com/libp2p/go-libp2p"
 	lp2pcircuit "github.com/libp2p/go-libp2p-circuit"
 	lp2pcrypto "github.com/libp2p/go-libp2p-core/crypto"
 	lp2phost "github.com/libp2p/go-libp2p-core/host"
+	lp2pnetwork "github.com/libp2p/go-libp2p-core/network"
 	lp2peer "github.com/libp2p/go-libp2p-core/peer"
+	lp2ppeerstore "github.com/libp2p/go-libp2p-core/peerstore"
+	lp2prouting "github.com/libp2p/go-libp2p-core/routing"
 	lp2pdht "github.com/libp2p/go-libp2p-kad-dht"
 	lp2pps "github.com/libp2p/go-libp2p-pubsub"
 	lp2pdiscovery "github.com/libp2p/go-libp2p/p2p/discovery"
+	manet "github.com/multiformats/go-multiaddr/net"
 	"github.com/zarbchain/zarb-go/errors"
 	"github.com/zarbchain/zarb-go/logger"
 	"github.com/zarbchain/zarb-go/util"
@@ -25,7 +31,9 @@ type network struct {
 	lk sync.RWMutex
 
 	ctx            context.Context
+	cancel         context.CancelFunc
 	config         *Config
+	nodeKey        lp2pcrypto.PrivKey
 	host           lp2phost.Host
 	wg             syncer.WaitGroup
 	mdns           lp2pdiscovery.Service
@@ -40,7 +48,13 @@ type network struct {
 	dataSub        *lp2pps.Subscription
 	consensusSub   *lp2pps.Subscription
 	callback       CallbackFn
+	streamSlots    chan struct{}
+	streamsLk      sync.Mutex
+	inboundStreams map[sessionKey]lp2pnetwork.Stream
 	bootstrapper   *Bootstrapper
+	gater          *connectionGater
+	addressBook    *AddressBook
+	privatePeers   map[lp2peer.ID]bool
 	logger         *logger.Logger
 }
 
@@ -83,12 +97,32 @@ func NewNetwork(conf *Config) (Network, error) {
 	if err != nil {
 		return nil, errors.Errorf(errors.ErrNetwork, err.Error())
 	}
+	persistentPeers, err := PeerAddrsToAddrInfo(conf.Bootstrap.PersistentPeers)
+	if err != nil {
+		return nil, errors.Errorf(errors.ErrNetwork, err.Error())
+	}
+	privatePeers, err := PeerIDsFromStrings(conf.Bootstrap.PrivatePeerIDs)
+	if err != nil {
+		return nil, errors.Errorf(errors.ErrNetwork, err.Error())
+	}
+	unconditionalPeers, err := PeerIDsFromStrings(conf.Bootstrap.UnconditionalPeerIDs)
+	if err != nil {
+		return nil, errors.Errorf(errors.ErrNetwork, err.Error())
+	}
+	for _, pi := range persistentPeers {
+		unconditionalPeers = append(unconditionalPeers, pi.ID)
+	}
+	gater, err := newConnectionGater(conf.Gater, conf.Bootstrap.MaxThreshold, unconditionalPeers)
+	if err != nil {
+		return nil, errors.Errorf(errors.ErrNetwork, err.Error())
+	}
 
 	opts := []lp2p.Option{
 		lp2p.Identity(nodeKey),
 		lp2p.ListenAddrStrings(conf.ListenAddress...),
 		lp2p.Ping(true),
 		lp2p.UserAgent("zarb-" + version.Version()),
+		lp2p.ConnectionGater(gater),
 	}
 	if conf.EnableNATService {
 		opts = append(opts,
@@ -103,17 +137,42 @@ func NewNetwork(conf *Config) (Network, error) {
 	if err != nil {
 		return nil, errors.Errorf(errors.ErrNetwork, err.Error())
 	}
+	gater.setNetwork(host.Network())
+	for _, pi := range persistentPeers {
+		host.Peerstore().AddAddrs(pi.ID, pi.Addrs, lp2ppeerstore.PermanentAddrTTL)
+	}
 
-	pubsub, err := lp2pps.NewGossipSub(ctx, host)
+	// The messages are signed by their publishers, see onReceiveMessage
+	pubsub, err := lp2pps.NewGossipSub(ctx, host, lp2pps.WithMessageSignaturePolicy(lp2pps.StrictSign))
 	if err != nil {
 		return nil, errors.Errorf(errors.ErrNetwork, err.Error())
 	}
 
+	addressBookPath := ""
+	if conf.AddressBookPath != "" {
+		addressBookPath = util.MakeAbs(conf.AddressBookPath)
+	}
+	addressBook, err := LoadAddressBook(addressBookPath)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
 	n := &network{
-		ctx:    ctx,
-		config: conf,
-		host:   host,
-		pubsub: pubsub,
+		ctx:            ctx,
+		cancel:         cancel,
+		config:         conf,
+		nodeKey:        nodeKey,
+		host:           host,
+		pubsub:         pubsub,
+		gater:          gater,
+		addressBook:    addressBook,
+		privatePeers:   make(map[lp2peer.ID]bool),
+		streamSlots:    make(chan struct{}, conf.MaxInboundStreams),
+		inboundStreams: make(map[sessionKey]lp2pnetwork.Stream),
+	}
+	for _, pid := range privatePeers {
+		n.privatePeers[pid] = true
 	}
 	n.logger = logger.NewLogger("_network", n)
 	n.logger.Info("network started", "id", n.host.ID(), "address", conf.ListenAddress)
@@ -132,24 +191,28 @@ func NewNetwork(conf *Config) (Network, error) {
 			n.logger.Error("Unable to setup Kademlia DHT", "err", err)
 		}
 		n.kademlia = kademlia
-		n.bootstrapper = NewBootstrapper(ctx,
-			host, host.Network(), kademlia,
-			conf.Bootstrap, n.logger)
 	}
 
+	var routing lp2prouting.Routing
+	if n.kademlia != nil {
+		routing = n.kademlia
+	}
+	n.bootstrapper = NewBootstrapper(ctx,
+		host, host.Network(), routing, addressBook,
+		conf.Bootstrap, n.isPrivate, n.logger)
+
 	return n, nil
 }
 
 func (n *network) Start() error {
-	if n.bootstrapper != nil {
-		n.bootstrapper.Start()
-	}
+	n.bootstrapper.Start()
 	return nil
 }
 
 func (n *network) Stop() {
-	n.ctx.Done()
+	n.host.RemoveStreamHandler(n.streamProtocol())
 	n.closeTopics()
+	n.cancel()
 
 	if n.mdns != nil {
 		if err := n.mdns.Close(); err != nil {
@@ -161,8 +224,9 @@ func (n *network) Stop() {
 			n.logger.Error("Unable to close Kademlia", "err", err)
 		}
 	}
-	if n.bootstrapper != nil {
-		n.bootstrapper.Stop()
+	n.bootstrapper.Stop()
+	if err := n.addressBook.Save(); err != nil {
+		n.logger.Error("Unable to save the address book", "err", err)
 	}
 	if err := n.host.Close(); err != nil {
 		n.logger.Error("Unable to close the network", "err", err)
@@ -173,12 +237,43 @@ func (n *network) SelfID() lp2peer.ID {
 	return n.host.ID()
 }
 
+// Name returns the name of the network, like "zarb" or "zarb-testnet"
+func (n *network) Name() string {
+	return n.config.Name
+}
+
+// CloseConnection closes the connection to the peer and removes it from the address book.
 func (n *network) CloseConnection(pid lp2peer.ID) {
+	n.addressBook.Remove(pid)
 	if err := n.host.Network().ClosePeer(pid); err != nil {
 		n.logger.Warn("Unable to close connection", "peer", pid)
 	}
 }
 
+// BanPeer bans the peer until `until` and the addresses that it is connected from until `addrsUntil`.
+// Zero time bans the peer permanently. The addresses can be shared by other peers, like behind a NAT,
+// so they are never banned permanently. The connections to the peer are closed.
+func (n *network) BanPeer(pid lp2peer.ID, until time.Time, addrsUntil time.Time) {
+	ips := []net.IP{}
+	for _, c := range n.host.Network().ConnsToPeer(pid) {
+		ip, err := manet.ToIP(c.RemoteMultiaddr())
+		if err == nil {
+			ips = append(ips, ip)
+		}
+	}
+	n.gater.banPeer(pid, until, ips, addrsUntil)
+	n.CloseConnection(pid)
+}
+
+// isPrivate returns true if the address of the peer should not be shared with other peers
+func (n *network) isPrivate(pid lp2peer.ID) bool {
+	return n.privatePeers[pid]
+}
+
+func (n *network) AddressBook() []AddressInfo {
+	return n.addressBook.Entries()
+}
+
 func (n *network) Fingerprint() string {
 	return fmt.Sprintf("{%d}", len(n.host.Network().Peers()))
 }
@@ -0,0 +1,222 @@
+package network
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	lp2pnetwork "github.com/libp2p/go-libp2p-core/network"
+	lp2peer "github.com/libp2p/go-libp2p-core/peer"
+	lp2pprotocol "github.com/libp2p/go-libp2p-core/protocol"
+	"github.com/zarbchain/zarb-go/errors"
+	"github.com/zarbchain/zarb-go/sync/message"
+	"github.com/zarbchain/zarb-go/sync/message/payload"
+	"github.com/zarbchain/zarb-go/util"
+)
+
+// maxStreamMessageSize is the maximum size of a message on streams.
+// It is same as the maximum size of pubsub messages.
+const maxStreamMessageSize = 1 << 20
+
+// sessionKey identifies the inbound stream of a session
+type sessionKey struct {
+	pid       lp2peer.ID
+	sessionID int
+}
+
+func (n *network) streamProtocol() lp2pprotocol.ID {
+	return lp2pprotocol.ID(fmt.Sprintf("/zarb/stream/%s/v1", n.config.Name))
+}
+
+// sessionOf returns the session ID of the download, latest blocks, snapshot and chunk messages.
+func sessionOf(msg *message.Message) (sessionID int, isRequest bool, ok bool) {
+	switch pld := msg.Payload.(type) {
+	case *payload.DownloadRequestPayload:
+		return pld.SessionID, true, true
+	case *payload.LatestBlocksRequestPayload:
+		return pld.SessionID, true, true
+	case *payload.QuerySnapshotPayload:
+		return pld.SessionID, true, true
+	case *payload.ChunkRequestPayload:
+		return pld.SessionID, true, true
+	case *payload.DownloadResponsePayload:
+		return pld.SessionID, false, true
+	case *payload.LatestBlocksResponsePayload:
+		return pld.SessionID, false, true
+	case *payload.SnapshotPayload:
+		return pld.SessionID, false, true
+	case *payload.ChunkResponsePayload:
+		return pld.SessionID, false, true
+	}
+	return 0, false, false
+}
+
+// SendMessage sends the message of a session directly to the peer.
+// The whole session goes over one stream: a request opens a new stream,
+// and the responses are written back on the stream of their request, in order.
+// The messages are published on the topics if the peer doesn't support the stream protocol.
+func (n *network) SendMessage(msg *message.Message, pid lp2peer.ID) error {
+	if err := msg.SanityCheck(); err != nil {
+		return err
+	}
+	sessionID, isRequest, ok := sessionOf(msg)
+	if !ok {
+		return errors.Errorf(errors.ErrNetwork, "%s is not a session message", msg.Payload.Type())
+	}
+	data, err := n.encodeMessage(msg)
+	if err != nil {
+		return err
+	}
+	if len(data) > maxStreamMessageSize {
+		return errors.Errorf(errors.ErrNetwork, "message is too large: %v", len(data))
+	}
+
+	if isRequest {
+		if !n.supportsStreams(pid) {
+			return n.publish(msg, data)
+		}
+		return n.openSession(data, pid)
+	}
+
+	n.streamsLk.Lock()
+	s, ok := n.inboundStreams[sessionKey{pid, sessionID}]
+	n.streamsLk.Unlock()
+	if !ok {
+		// The request is received from the topics
+		return n.publish(msg, data)
+	}
+	return n.writeFrame(s, data)
+}
+
+// supportsStreams returns true if the peer has announced our stream protocol
+func (n *network) supportsStreams(pid lp2peer.ID) bool {
+	protocols, err := n.host.Peerstore().SupportsProtocols(pid, string(n.streamProtocol()))
+	return err == nil && len(protocols) > 0
+}
+
+// openSession sends the request on a new stream and reads the responses from the same stream
+func (n *network) openSession(data []byte, pid lp2peer.ID) error {
+	ctx, cancel := context.WithTimeout(n.ctx, n.config.StreamTimeout)
+	defer cancel()
+
+	s, err := n.host.NewStream(ctx, pid, n.streamProtocol())
+	if err != nil {
+		return errors.Errorf(errors.ErrNetwork, "unable to open stream: %v", err)
+	}
+	if err := n.writeFrame(s, data); err != nil {
+		_ = s.Reset()
+		return err
+	}
+	if err := s.CloseWrite(); err != nil {
+		_ = s.Reset()
+		return errors.Errorf(errors.ErrNetwork, err.Error())
+	}
+
+	go n.readSession(s, pid)
+
+	return nil
+}
+
+// readSession passes the responses to the callback one by one, so they are handled in order
+func (n *network) readSession(s lp2pnetwork.Stream, pid lp2peer.ID) {
+	r := bufio.NewReader(s)
+	for {
+		data, err := n.readFrame(s, r)
+		if err != nil {
+			if err == io.EOF {
+				_ = s.Close()
+			} else {
+				n.logger.Debug("Unable to read stream", "from", util.FingerprintPeerID(pid), "err", err)
+				_ = s.Reset()
+			}
+			return
+		}
+		n.callback(data, pid)
+	}
+}
+
+// onStream handles a request and writes its responses on the same stream.
+// The responses are written while the request is being handled, see SendMessage.
+func (n *network) onStream(s lp2pnetwork.Stream) {
+	from := s.Conn().RemotePeer()
+
+	// Waiting here blocks the sender, because we don't read the stream.
+	select {
+	case n.streamSlots <- struct{}{}:
+	case <-n.ctx.Done():
+		_ = s.Reset()
+		return
+	}
+	defer func() { <-n.streamSlots }()
+
+	data, err := n.readFrame(s, bufio.NewReader(s))
+	if err != nil {
+		n.logger.Debug("Unable to read stream", "from", util.FingerprintPeerID(from), "err", err)
+		_ = s.Reset()
+		return
+	}
+	msg := new(message.Message)
+	if err := msg.Decode(data); err != nil {
+		_ = s.Reset()
+		return
+	}
+	sessionID, isRequest, _ := sessionOf(msg)
+	if !isRequest {
+		n.logger.Debug("Stream doesn't start with a request", "from", util.FingerprintPeerID(from), "msg", msg)
+		_ = s.Reset()
+		return
+	}
+
+	key := sessionKey{from, sessionID}
+	n.streamsLk.Lock()
+	if _, ok := n.inboundStreams[key]; ok {
+		n.streamsLk.Unlock()
+		n.logger.Debug("Session is already open", "from", util.FingerprintPeerID(from), "session", sessionID)
+		_ = s.Reset()
+		return
+	}
+	n.inboundStreams[key] = s
+	n.streamsLk.Unlock()
+
+	n.callback(data, from)
+
+	n.streamsLk.Lock()
+	delete(n.inboundStreams, key)
+	n.streamsLk.Unlock()
+	_ = s.Close()
+}
+
+// writeFrame writes the length of the data and then the data itself
+func (n *network) writeFrame(s lp2pnetwork.Stream, data []byte) error {
+	if err := s.SetWriteDeadline(util.Now().Add(n.config.StreamTimeout)); err != nil {
+		return errors.Errorf(errors.ErrNetwork, err.Error())
+	}
+	buf := make([]byte, binary.MaxVarintLen64, binary.MaxVarintLen64+len(data))
+	l := binary.PutUvarint(buf, uint64(len(data)))
+	buf = append(buf[:l], data...)
+	if _, err := s.Write(buf); err != nil {
+		return errors.Errorf(errors.ErrNetwork, "unable to write on stream: %v", err)
+	}
+	return nil
+}
+
+// readFrame reads a frame that is written by writeFrame. It returns io.EOF if the stream is closed.
+func (n *network) readFrame(s lp2pnetwork.Stream, r *bufio.Reader) ([]byte, error) {
+	if err := s.SetReadDeadline(util.Now().Add(n.config.StreamTimeout)); err != nil {
+		return nil, err
+	}
+	l, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	if l > maxStreamMessageSize {
+		return nil, errors.Errorf(errors.ErrNetwork, "stream message is too large: %v", l)
+	}
+	data := make([]byte, l)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
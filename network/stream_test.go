@@ -0,0 +1,214 @@
+package network
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	lp2peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zarbchain/zarb-go/crypto"
+	"github.com/zarbchain/zarb-go/logger"
+	"github.com/zarbchain/zarb-go/sync/message"
+	"github.com/zarbchain/zarb-go/sync/message/payload"
+	"github.com/zarbchain/zarb-go/tx"
+	"github.com/zarbchain/zarb-go/util"
+)
+
+type received struct {
+	data []byte
+	from lp2peer.ID
+}
+
+func testNetworkConfig() *Config {
+	conf := TestConfig()
+	conf.ListenAddress = []string{"/ip4/127.0.0.1/tcp/0"}
+	conf.EnableNATService = false
+	conf.EnableRelay = false
+	conf.EnableMDNS = false
+	conf.EnableKademlia = false
+	return conf
+}
+
+func setupNetwork(t *testing.T) (*network, chan received) {
+	return setupNetworkWithConfig(t, testNetworkConfig())
+}
+
+func setupNetworkWithConfig(t *testing.T, conf *Config) (*network, chan received) {
+	net, err := NewNetwork(conf)
+	require.NoError(t, err)
+	ch := make(chan received, 10)
+	require.NoError(t, net.JoinTopics(func(data []byte, from lp2peer.ID) {
+		ch <- received{data: data, from: from}
+	}))
+	return net.(*network), ch
+}
+
+func TestSendMessage(t *testing.T) {
+	logger.InitLogger(logger.TestConfig())
+	net1, ch1 := setupNetwork(t)
+	net2, err := NewNetwork(testNetworkConfig())
+	require.NoError(t, err)
+	defer net1.Stop()
+	defer net2.Stop()
+
+	// net2 responds to each download request with three responses
+	ch2 := make(chan received, 10)
+	require.NoError(t, net2.JoinTopics(func(data []byte, from lp2peer.ID) {
+		ch2 <- received{data: data, from: from}
+		req := new(message.Message)
+		if err := req.Decode(data); err != nil {
+			return
+		}
+		if req.Payload.Type() != payload.PayloadTypeDownloadRequest {
+			return
+		}
+		sessionID, _, _ := sessionOf(req)
+		for _, code := range []payload.ResponseCode{
+			payload.ResponseCodeMoreBlocks,
+			payload.ResponseCodeMoreBlocks,
+			payload.ResponseCodeNoMoreBlocks} {
+			pld := payload.NewDownloadResponsePayload(code, sessionID, from, 0, nil, nil)
+			assert.NoError(t, net2.SendMessage(message.NewMessage(net2.SelfID(), pld), from))
+		}
+	}))
+
+	require.NoError(t, net1.host.Connect(context.Background(), lp2peer.AddrInfo{
+		ID:    net2.SelfID(),
+		Addrs: net2.(*network).host.Addrs(),
+	}))
+	require.Eventually(t, func() bool {
+		return net1.supportsStreams(net2.SelfID())
+	}, 5*time.Second, 10*time.Millisecond)
+
+	t.Run("The whole session should go over one stream, in order", func(t *testing.T) {
+		pld := payload.NewDownloadRequestPayload(1, net2.SelfID(), 100, 200)
+		msg := message.NewMessage(net1.SelfID(), pld)
+		assert.NoError(t, net1.SendMessage(msg, net2.SelfID()))
+
+		select {
+		case r := <-ch2:
+			assert.Equal(t, r.from, net1.SelfID())
+			msg2 := new(message.Message)
+			assert.NoError(t, msg2.Decode(r.data))
+			assert.NoError(t, msg2.Verify())
+			assert.Equal(t, msg2.Payload.Type(), payload.PayloadTypeDownloadRequest)
+		case <-time.After(5 * time.Second):
+			assert.Fail(t, "Timeout")
+		}
+
+		for _, code := range []payload.ResponseCode{
+			payload.ResponseCodeMoreBlocks,
+			payload.ResponseCodeMoreBlocks,
+			payload.ResponseCodeNoMoreBlocks} {
+			select {
+			case r := <-ch1:
+				assert.Equal(t, r.from, net2.SelfID())
+				msg2 := new(message.Message)
+				assert.NoError(t, msg2.Decode(r.data))
+				assert.NoError(t, msg2.Verify())
+				assert.Equal(t, msg2.Payload.(*payload.DownloadResponsePayload).SessionID, 1)
+				assert.Equal(t, msg2.Payload.(*payload.DownloadResponsePayload).ResponseCode, code)
+			case <-time.After(5 * time.Second):
+				assert.Fail(t, "Timeout")
+			}
+		}
+	})
+
+	t.Run("Response without an open session should be published on the topics", func(t *testing.T) {
+		pld := payload.NewLatestBlocksResponsePayload(payload.ResponseCodeSynced, 2, net2.SelfID(), 0, nil, nil, nil)
+		msg := message.NewMessage(net1.SelfID(), pld)
+		assert.NoError(t, net1.SendMessage(msg, net2.SelfID()))
+	})
+
+	t.Run("Peers that don't support streams should receive the request on the topics", func(t *testing.T) {
+		pid := util.RandomPeerID()
+		pld := payload.NewLatestBlocksRequestPayload(3, pid, 100, 200)
+		msg := message.NewMessage(net1.SelfID(), pld)
+		assert.NoError(t, net1.SendMessage(msg, pid))
+
+		pld2 := payload.NewDownloadRequestPayload(4, pid, 100, 200)
+		msg2 := message.NewMessage(net1.SelfID(), pld2)
+		// Not joined to the download topic
+		assert.Error(t, net1.SendMessage(msg2, pid))
+	})
+
+	t.Run("Only session messages can be sent directly", func(t *testing.T) {
+		msg := message.NewMessage(net1.SelfID(), payload.NewQueryTransactionsPayload([]tx.ID{crypto.GenerateTestHash()}))
+		assert.Error(t, net1.SendMessage(msg, net2.SelfID()))
+	})
+
+	t.Run("Snapshot and chunk messages are session messages", func(t *testing.T) {
+		pid := util.RandomPeerID()
+		requests := []payload.Payload{
+			payload.NewQuerySnapshotPayload(5, pid),
+			payload.NewChunkRequestPayload(6, pid, crypto.GenerateTestHash(), 0),
+		}
+		for _, pld := range requests {
+			sessionID, isRequest, ok := sessionOf(message.NewMessage(net1.SelfID(), pld))
+			assert.True(t, ok)
+			assert.True(t, isRequest)
+			assert.NotZero(t, sessionID)
+		}
+		responses := []payload.Payload{
+			payload.NewSnapshotPayload(payload.ResponseCodeRejected, 5, pid, nil, nil, nil, nil),
+			payload.NewChunkResponsePayload(payload.ResponseCodeRejected, 6, pid, nil),
+		}
+		for _, pld := range responses {
+			sessionID, isRequest, ok := sessionOf(message.NewMessage(net1.SelfID(), pld))
+			assert.True(t, ok)
+			assert.False(t, isRequest)
+			assert.NotZero(t, sessionID)
+		}
+	})
+
+	t.Run("Too large message", func(t *testing.T) {
+		ids := make([]tx.ID, 0, maxStreamMessageSize/32)
+		for i := 0; i < maxStreamMessageSize/32; i++ {
+			ids = append(ids, crypto.GenerateTestHash())
+		}
+		msg := message.NewMessage(net1.SelfID(), payload.NewQueryTransactionsPayload(ids))
+		assert.Error(t, net1.SendMessage(msg, net2.SelfID()))
+	})
+}
+
+func TestConfigCheck(t *testing.T) {
+	conf := TestConfig()
+	assert.NoError(t, conf.SanityCheck())
+
+	conf.StreamTimeout = 0
+	assert.Error(t, conf.SanityCheck())
+
+	conf = TestConfig()
+	conf.MaxInboundStreams = 0
+	assert.Error(t, conf.SanityCheck())
+
+	conf = TestConfig()
+	conf.Bootstrap.PersistentPeers = []string{"/ip4/127.0.0.1/tcp/21777"}
+	assert.Error(t, conf.SanityCheck())
+
+	conf = TestConfig()
+	conf.Bootstrap.PrivatePeerIDs = []string{"invalid"}
+	assert.Error(t, conf.SanityCheck())
+
+	conf = TestConfig()
+	conf.Bootstrap.UnconditionalPeerIDs = []string{"invalid"}
+	assert.Error(t, conf.SanityCheck())
+
+	conf = TestConfig()
+	conf.Gater.AllowedCIDRs = []string{"10.0.0.0"}
+	assert.Error(t, conf.SanityCheck())
+
+	conf = TestConfig()
+	conf.Gater.DeniedCIDRs = []string{"10.0.0.0/33"}
+	assert.Error(t, conf.SanityCheck())
+
+	conf = TestConfig()
+	conf.Gater.MaxConnsPerIP = -1
+	assert.Error(t, conf.SanityCheck())
+
+	conf = TestConfig()
+	conf.Gater.MaxConnsPerSubnet = -1
+	assert.Error(t, conf.SanityCheck())
+}
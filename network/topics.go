@@ -8,21 +8,35 @@ import (
 )
 
 func (n *network) PublishMessage(msg *message.Message) error {
-	topic := n.topic(msg)
-	if topic == nil {
-		return errors.Errorf(errors.ErrNetwork, "invalid topic.")
-	}
 	if err := msg.SanityCheck(); err != nil {
 		return err
 	}
-	data, err := msg.Encode()
+	data, err := n.encodeMessage(msg)
 	if err != nil {
 		return err
 	}
 
+	return n.publish(msg, data)
+}
+
+func (n *network) publish(msg *message.Message, data []byte) error {
+	topic := n.topic(msg)
+	if topic == nil {
+		return errors.Errorf(errors.ErrNetwork, "invalid topic.")
+	}
 	return topic.Publish(n.ctx, data)
 }
 
+func (n *network) encodeMessage(msg *message.Message) ([]byte, error) {
+	// We only sign our own messages
+	if msg.Initiator == n.SelfID() {
+		if err := msg.Sign(n.nodeKey); err != nil {
+			return nil, errors.Errorf(errors.ErrNetwork, err.Error())
+		}
+	}
+	return msg.Encode()
+}
+
 func (n *network) JoinTopics(callbackFn CallbackFn) error {
 	generalTopic, err := n.joinTopic("general")
 	if err != nil {
@@ -50,6 +64,7 @@ func (n *network) JoinTopics(callbackFn CallbackFn) error {
 	}
 
 	n.callback = callbackFn
+	n.host.SetStreamHandler(n.streamProtocol(), n.onStream)
 	n.generalTopic = generalTopic
 	n.dataTopic = dataTopic
 	n.consensusTopic = consensusTopic
@@ -182,6 +197,8 @@ func (n *network) topic(msg *message.Message) *lp2pps.Topic {
 		payload.PayloadTypeHeartBeat:
 		return n.generalTopic
 
+	// Latest blocks and download sessions are sent over direct streams, see SendMessage.
+	// The topics are kept for the peers that don't support streams.
 	case payload.PayloadTypeLatestBlocksRequest,
 		payload.PayloadTypeLatestBlocksResponse,
 		payload.PayloadTypeQueryTransactions,
@@ -196,7 +213,11 @@ func (n *network) topic(msg *message.Message) *lp2pps.Topic {
 		return n.consensusTopic
 
 	case payload.PayloadTypeDownloadRequest,
-		payload.PayloadTypeDownloadResponse:
+		payload.PayloadTypeDownloadResponse,
+		payload.PayloadTypeQuerySnapshot,
+		payload.PayloadTypeSnapshot,
+		payload.PayloadTypeChunkRequest,
+		payload.PayloadTypeChunkResponse:
 		return n.downloadTopic
 
 	default:
@@ -210,5 +231,7 @@ func (n *network) onReceiveMessage(m *lp2pps.Message) {
 		return
 	}
 
-	n.callback(m.Data, m.ReceivedFrom)
+	// The publisher of the message is verified by its signature.
+	// The peer that delivered it might only relay the message, so it shouldn't be blamed for it.
+	n.callback(m.Data, m.GetFrom())
 }
@@ -0,0 +1,43 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zarbchain/zarb-go/consensus/vote"
+	"github.com/zarbchain/zarb-go/logger"
+	"github.com/zarbchain/zarb-go/sync/message"
+	"github.com/zarbchain/zarb-go/sync/message/payload"
+)
+
+func TestRelayedMessages(t *testing.T) {
+	logger.InitLogger(logger.TestConfig())
+	net1, _ := setupNetwork(t)
+	net2, _ := setupNetwork(t)
+	net3, ch3 := setupNetwork(t)
+	defer net1.Stop()
+	defer net2.Stop()
+	defer net3.Stop()
+
+	// net1 and net3 are connected through net2
+	require.NoError(t, connect(net1, net2))
+	require.NoError(t, connect(net2, net3))
+
+	v, _ := vote.GenerateTestPrecommitVote(1, 0)
+	msg := message.NewMessage(net1.SelfID(), payload.NewVotePayload(v))
+
+	// Waiting for the mesh to be formed
+	require.Eventually(t, func() bool {
+		assert.NoError(t, net1.PublishMessage(msg))
+		select {
+		case r := <-ch3:
+			// The publisher, not the relay
+			assert.Equal(t, r.from, net1.SelfID())
+			return true
+		case <-time.After(100 * time.Millisecond):
+			return false
+		}
+	}, 10*time.Second, 10*time.Millisecond)
+}
@@ -81,7 +81,7 @@ func NewNode(genDoc *genesis.Genesis, conf *config.Config, signer crypto.Signer)
 		return nil, errors.Wrap(err, "could not create http server")
 	}
 
-	grpc, err := grpc.NewServer(conf.GRPC, state, sync)
+	grpc, err := grpc.NewServer(conf.GRPC, state, sync, consensus)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not create grpc server")
 	}
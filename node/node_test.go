@@ -23,6 +23,9 @@ func TestRunningNode(t *testing.T) {
 	conf := config.DefaultConfig()
 	conf.Store.Path = util.TempDirPath()
 	conf.Network.NodeKeyFile = util.TempFilePath()
+	conf.Consensus.WALPath = util.TempFilePath()
+	conf.Consensus.SignStatePath = util.TempFilePath()
+	conf.Network.AddressBookPath = util.TempFilePath()
 
 	signer := crypto.NewSigner(pv)
 	n, err := NewNode(gen, conf, signer)
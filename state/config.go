@@ -7,12 +7,17 @@ import (
 
 // Config holds the configuration of the node
 type Config struct {
-	MintbaseAddress string `toml:"" comment:"Mintbase Address to collect the rewards."`
+	MintbaseAddress  string `toml:"" comment:"Mintbase Address to collect the rewards."`
+	SnapshotInterval int    `toml:"" comment:"SnapshotInterval is the number of blocks between two state snapshots. Snapshots are served to other peers for state syncing. Zero disables it."`
+	VerifyOnStartup  bool   `toml:"" comment:"VerifyOnStartup replays all the blocks in background when the node starts, to check the integrity of the store. The result is logged. Stopping the node waits for it. Default is false"`
 }
 
 // DefaultConfig instantiates the default configuration for the node
 func DefaultConfig() *Config {
-	return &Config{}
+	return &Config{
+		SnapshotInterval: 1000,
+		VerifyOnStartup:  false,
+	}
 }
 
 // TestConfig instantiates the test configuration
@@ -28,5 +33,8 @@ func (conf *Config) SanityCheck() error {
 			return errors.Errorf(errors.ErrInvalidConfig, "invalid mintbase address: %s", err.Error())
 		}
 	}
+	if conf.SnapshotInterval < 0 {
+		return errors.Errorf(errors.ErrInvalidConfig, "invalid snapshot interval")
+	}
 	return nil
 }
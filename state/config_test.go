@@ -14,4 +14,10 @@ func TestDefaultConfigCheck(t *testing.T) {
 		c.MintbaseAddress = "invalid"
 		assert.Error(t, c.SanityCheck())
 	})
+
+	t.Run("Invalid snapshot interval", func(t *testing.T) {
+		c := DefaultConfig()
+		c.SnapshotInterval = -1
+		assert.Error(t, c.SanityCheck())
+	})
 }
@@ -14,7 +14,6 @@ func (st *state) executeBlock(block *block.Block, sb sandbox.Sandbox) ([]*tx.Tx,
 
 	ids := block.TxIDs().IDs()
 	trxs := make([]*tx.Tx, len(ids))
-	var mintbaseTrx *tx.Tx
 	for i := 0; i < len(ids); i++ {
 		trx := st.txPool.QueryTx(ids[i])
 		if trx == nil {
@@ -28,20 +27,28 @@ func (st *state) executeBlock(block *block.Block, sb sandbox.Sandbox) ([]*tx.Tx,
 				return nil, errors.Errorf(errors.ErrInvalidTx,
 					"first transaction should be a subsidy transaction")
 			}
-			mintbaseTrx = trx
 		} else {
 			if trx.IsMintbaseTx() {
 				return nil, errors.Errorf(errors.ErrInvalidTx,
 					"duplicated subsidy transaction")
 			}
 		}
+		trxs[i] = trx
+	}
 
+	// Checking signatures and stateless rules in parallel.
+	// The result is cached on the transactions, so execution doesn't check them again.
+	if err := tx.CheckTransactions(trxs); err != nil {
+		return nil, err
+	}
+
+	for _, trx := range trxs {
 		err := exe.Execute(trx, sb)
 		if err != nil {
 			return nil, err
 		}
-		trxs[i] = trx
 	}
+	mintbaseTrx := trxs[0]
 
 	accumulatedFee := exe.AccumulatedFee()
 	subsidyAmt := st.params.BlockReward + exe.AccumulatedFee()
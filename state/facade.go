@@ -6,6 +6,8 @@ import (
 	"github.com/zarbchain/zarb-go/account"
 	"github.com/zarbchain/zarb-go/block"
 	"github.com/zarbchain/zarb-go/crypto"
+	"github.com/zarbchain/zarb-go/state/snapshot"
+	"github.com/zarbchain/zarb-go/store"
 	"github.com/zarbchain/zarb-go/tx"
 	"github.com/zarbchain/zarb-go/validator"
 )
@@ -20,8 +22,10 @@ type Facade interface {
 	UpdateLastCertificate(lastCertificate *block.Certificate) error
 	ProposeBlock(round int) (*block.Block, error)
 	ValidateBlock(block *block.Block) error
+	VerifyCertificate(cert *block.Certificate) error
 	CommitBlock(height int, block *block.Block, cert *block.Certificate) error
 	CommitteeValidators() []*validator.Validator
+	CommitteeChange(height int) *store.CommitteeChange
 	IsInCommittee(addr crypto.Address) bool
 	Proposer(round int) *validator.Validator
 	IsProposer(addr crypto.Address, round int) bool
@@ -37,6 +41,8 @@ type Facade interface {
 	Account(addr crypto.Address) *account.Account
 	Validator(addr crypto.Address) *validator.Validator
 	ValidatorByNumber(number int) *validator.Validator
+	LastSnapshot() *snapshot.Snapshot
+	RestoreSnapshot(s *snapshot.Snapshot, b *block.Block, cert *block.Certificate, trxs []*tx.Tx) error
 	Close() error
 	Fingerprint() string
 }
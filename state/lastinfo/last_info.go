@@ -112,13 +112,18 @@ func (li *LastInfo) SetBlockTime(lastBlockTime time.Time) {
 }
 
 func (li *LastInfo) SaveLastInfo() {
+	bs, _ := li.Encode()
+	li.store.SaveLastInfo(bs)
+}
+
+// Encode returns the data that SaveLastInfo saves in the store
+func (li *LastInfo) Encode() ([]byte, error) {
 	lid := lastInfoData{
 		LastBlockHeight: li.lastBlockHeight,
 		LastCertificate: li.lastCertificate,
 	}
 
-	bs, _ := cbor.Marshal(&lid)
-	li.store.SaveLastInfo(bs)
+	return cbor.Marshal(&lid)
 }
 
 func (li *LastInfo) RestoreLastInfo(committeeSize int, srt *sortition.Sortition) (*committee.Committee, error) {
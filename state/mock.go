@@ -10,6 +10,7 @@ import (
 	"github.com/zarbchain/zarb-go/committee"
 	"github.com/zarbchain/zarb-go/crypto"
 	"github.com/zarbchain/zarb-go/errors"
+	"github.com/zarbchain/zarb-go/state/snapshot"
 	"github.com/zarbchain/zarb-go/store"
 	"github.com/zarbchain/zarb-go/tx"
 	"github.com/zarbchain/zarb-go/txpool"
@@ -26,6 +27,7 @@ type MockState struct {
 	TxPool               *txpool.MockTxPool
 	InvalidBlockHash     crypto.Hash
 	Committee            *committee.Committee
+	Snapshot             *snapshot.Snapshot
 	Lock                 sync.RWMutex
 }
 
@@ -106,6 +108,9 @@ func (m *MockState) ProposeBlock(round int) (*block.Block, error) {
 func (m *MockState) ValidateBlock(block *block.Block) error {
 	return nil
 }
+func (m *MockState) VerifyCertificate(cert *block.Certificate) error {
+	return nil
+}
 func (m *MockState) AddBlock(h int, b *block.Block, trxs []*tx.Tx) {
 	m.Lock.Lock()
 	defer m.Lock.Unlock()
@@ -121,6 +126,12 @@ func (m *MockState) CommitteeValidators() []*validator.Validator {
 	defer m.Lock.RUnlock()
 	return m.Committee.Validators()
 }
+func (m *MockState) CommitteeChange(height int) *store.CommitteeChange {
+	m.Lock.RLock()
+	defer m.Lock.RUnlock()
+	c, _ := m.Store.CommitteeChange(height)
+	return c
+}
 func (m *MockState) IsInCommittee(addr crypto.Address) bool {
 	m.Lock.RLock()
 	defer m.Lock.RUnlock()
@@ -205,6 +216,27 @@ func (m *MockState) ValidatorByNumber(n int) *validator.Validator {
 	v, _ := m.Store.ValidatorByNumber(n)
 	return v
 }
+func (m *MockState) LastSnapshot() *snapshot.Snapshot {
+	m.Lock.RLock()
+	defer m.Lock.RUnlock()
+	return m.Snapshot
+}
+func (m *MockState) RestoreSnapshot(s *snapshot.Snapshot, b *block.Block, cert *block.Certificate, trxs []*tx.Tx) error {
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+	if err := s.VerifyCertifiedBlock(b, cert); err != nil {
+		return err
+	}
+	if err := s.Restore(m.Store, m.GenHash, b.Header().LastBlockHash(), b.Header().StateHash()); err != nil {
+		return err
+	}
+	m.Store.SaveBlock(s.Manifest().BlockHeight()+1, b)
+	for _, trx := range trxs {
+		m.Store.SaveTransaction(trx)
+	}
+	m.LastBlockCertificate = cert
+	return nil
+}
 func (m *MockState) PendingTx(id tx.ID) *tx.Tx {
 	m.Lock.RLock()
 	defer m.Lock.RUnlock()
@@ -0,0 +1,62 @@
+package state
+
+import (
+	"github.com/zarbchain/zarb-go/account"
+	"github.com/zarbchain/zarb-go/errors"
+	"github.com/zarbchain/zarb-go/genesis"
+	"github.com/zarbchain/zarb-go/sortition"
+	"github.com/zarbchain/zarb-go/state/lastinfo"
+	"github.com/zarbchain/zarb-go/state/snapshot"
+	"github.com/zarbchain/zarb-go/store"
+	"github.com/zarbchain/zarb-go/validator"
+)
+
+// Rollback reverts the state to the given height.
+// Blocks and transactions above the height are removed and
+// the accounts and validators are restored to their state at that height.
+// The certificate of the block at the height is kept by the next block,
+// and the state hash of the next block is used to verify the restored state before changing the store.
+//
+// The node should be stopped before rolling back the state.
+func Rollback(genDoc *genesis.Genesis, st store.Store, height int) error {
+	next, err := st.Block(height + 1)
+	if err != nil {
+		return errors.Errorf(errors.ErrGeneric, "no block above height %v", height)
+	}
+	cert := next.LastCertificate()
+	if cert == nil {
+		return errors.Errorf(errors.ErrGeneric, "no certificate for block %v", height)
+	}
+	expectedStateHash := next.Header().StateHash()
+
+	li := lastinfo.NewLastInfo(st)
+	li.SetBlockHeight(height)
+	li.SetCertificate(cert)
+	info, err := li.Encode()
+	if err != nil {
+		return err
+	}
+
+	// Verifying the state at the height, before changing the store.
+	// The last info is written with the rolled back state in one batch.
+	err = st.Rollback(height, info, func(accs []*account.Account, vals []*validator.Validator) error {
+		stateHash, err := snapshot.CalcStateHash(accs, vals)
+		if err != nil {
+			return err
+		}
+		if !stateHash.EqualsTo(expectedStateHash) {
+			return errors.Errorf(errors.ErrGeneric, "state hash is not same as we expected. Expected %v, got %v", expectedStateHash, stateHash)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Rebuilding the committee and the sortition at the height
+	if _, err := li.RestoreLastInfo(genDoc.Params().CommitteeSize, sortition.NewSortition()); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zarbchain/zarb-go/crypto"
+	"github.com/zarbchain/zarb-go/store"
+	"github.com/zarbchain/zarb-go/tx"
+)
+
+func TestRollback(t *testing.T) {
+	setup(t)
+
+	st1, err := store.NewStore(store.TestConfig())
+	require.NoError(t, err)
+	st, err := LoadOrNewState(TestConfig(), tState1.genDoc, tValSigner1, st1, tCommonTxPool)
+	require.NoError(t, err)
+
+	// Add a bond transactions to change the validators
+	_, pub, _ := crypto.GenerateTestKeyPair()
+	trx := tx.NewBondTx(crypto.UndefHash, 1, tValSigner1.Address(), pub, 8888000, 8888, "")
+	tValSigner1.SignMsg(trx)
+	assert.NoError(t, tCommonTxPool.AppendTx(trx))
+
+	for i := 0; i < 6; i++ {
+		b, c := makeBlockAndCertificate(t, 0, tValSigner1, tValSigner2, tValSigner3, tValSigner4)
+		require.NoError(t, st.CommitBlock(i+1, b, c))
+		CommitBlockForAllStates(t, b, c)
+	}
+	assert.Equal(t, st1.TotalValidators(), 5)
+
+	t.Run("No block above the height", func(t *testing.T) {
+		assert.Error(t, Rollback(tState1.genDoc, st1, 6))
+	})
+
+	require.NoError(t, Rollback(tState1.genDoc, st1, 1))
+	assert.Equal(t, st1.TotalValidators(), 4)
+	_, err = st1.Block(2)
+	assert.Error(t, err)
+
+	st2, err := LoadOrNewState(TestConfig(), tState1.genDoc, tValSigner1, st1, tCommonTxPool)
+	require.NoError(t, err)
+	assert.Equal(t, st2.LastBlockHeight(), 1)
+	assert.Equal(t, st2.LastBlockHash(), tState1.Block(1).Hash())
+	assert.Equal(t, st2.LastCertificate().Hash(), tState1.Block(2).LastCertificate().Hash())
+
+	// Committing the removed blocks again
+	for h := 2; h <= 6; h++ {
+		b := tState1.Block(h)
+		for _, id := range b.TxIDs().IDs() {
+			trx, err := tState1.store.Transaction(id)
+			require.NoError(t, err)
+			assert.NoError(t, tCommonTxPool.AppendTx(trx))
+		}
+		c := tState1.LastCertificate()
+		if h < 6 {
+			c = tState1.Block(h + 1).LastCertificate()
+		}
+		require.NoError(t, st2.CommitBlock(h, b, c))
+	}
+	assert.Equal(t, st2.LastBlockHash(), tState1.LastBlockHash())
+	assert.Equal(t, st2.(*state).stateHash(), tState1.stateHash())
+}
@@ -0,0 +1,74 @@
+package snapshot
+
+import (
+	"github.com/fxamacker/cbor/v2"
+	"github.com/zarbchain/zarb-go/account"
+	"github.com/zarbchain/zarb-go/block"
+	"github.com/zarbchain/zarb-go/crypto"
+	"github.com/zarbchain/zarb-go/tx"
+	"github.com/zarbchain/zarb-go/validator"
+)
+
+// Chunk is a part of a snapshot. Blocks are kept in ascending order of height
+type Chunk struct {
+	data chunkData
+	// bytes is the size of the encoded items, while the chunk is being filled
+	bytes int
+}
+
+type encodable interface {
+	Encode() ([]byte, error)
+}
+
+type chunkData struct {
+	Index        int                    `cbor:"1,keyasint"`
+	Accounts     []*account.Account     `cbor:"2,keyasint"`
+	Validators   []*validator.Validator `cbor:"3,keyasint"`
+	Blocks       []*block.Block         `cbor:"4,keyasint"`
+	Transactions []*tx.Tx               `cbor:"5,keyasint"`
+}
+
+func newChunk(index int) *Chunk {
+	return &Chunk{
+		data: chunkData{
+			Index: index,
+		},
+	}
+}
+
+func (c *Chunk) Index() int                         { return c.data.Index }
+func (c *Chunk) Accounts() []*account.Account       { return c.data.Accounts }
+func (c *Chunk) Validators() []*validator.Validator { return c.data.Validators }
+func (c *Chunk) Blocks() []*block.Block             { return c.data.Blocks }
+func (c *Chunk) Transactions() []*tx.Tx             { return c.data.Transactions }
+
+func (c *Chunk) size() int {
+	return len(c.data.Accounts) +
+		len(c.data.Validators) +
+		len(c.data.Blocks) +
+		len(c.data.Transactions)
+}
+
+func (c *Chunk) Hash() crypto.Hash {
+	bs, err := c.Encode()
+	if err != nil {
+		panic(err)
+	}
+	return crypto.HashH(bs)
+}
+
+func (c *Chunk) Encode() ([]byte, error) {
+	return cbor.Marshal(c.data)
+}
+
+func (c *Chunk) Decode(bs []byte) error {
+	return cbor.Unmarshal(bs, &c.data)
+}
+
+func (c *Chunk) MarshalCBOR() ([]byte, error) {
+	return cbor.Marshal(c.data)
+}
+
+func (c *Chunk) UnmarshalCBOR(bs []byte) error {
+	return cbor.Unmarshal(bs, &c.data)
+}
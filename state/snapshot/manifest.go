@@ -0,0 +1,117 @@
+package snapshot
+
+import (
+	"github.com/fxamacker/cbor/v2"
+	"github.com/zarbchain/zarb-go/block"
+	"github.com/zarbchain/zarb-go/crypto"
+	"github.com/zarbchain/zarb-go/errors"
+)
+
+// Version is the current version of the snapshot format
+const Version = 1
+
+// Manifest describes a snapshot and keeps the hashes of its chunks
+type Manifest struct {
+	data manifestData
+}
+
+type manifestData struct {
+	Version          int                `cbor:"1,keyasint"`
+	GenesisHash      crypto.Hash        `cbor:"2,keyasint"`
+	BlockHeight      int                `cbor:"3,keyasint"`
+	BlockHash        crypto.Hash        `cbor:"4,keyasint"`
+	StateHash        crypto.Hash        `cbor:"5,keyasint"`
+	LastCertificate  *block.Certificate `cbor:"6,keyasint"`
+	FirstBlockHeight int                `cbor:"7,keyasint"`
+	ChunkHashes      []crypto.Hash      `cbor:"8,keyasint"`
+}
+
+func NewManifest(genHash crypto.Hash, height int, blockHash, stateHash crypto.Hash,
+	lastCert *block.Certificate, firstBlockHeight int, chunkHashes []crypto.Hash) *Manifest {
+	return &Manifest{
+		data: manifestData{
+			Version:          Version,
+			GenesisHash:      genHash,
+			BlockHeight:      height,
+			BlockHash:        blockHash,
+			StateHash:        stateHash,
+			LastCertificate:  lastCert,
+			FirstBlockHeight: firstBlockHeight,
+			ChunkHashes:      chunkHashes,
+		},
+	}
+}
+
+func (m *Manifest) Version() int                        { return m.data.Version }
+func (m *Manifest) GenesisHash() crypto.Hash            { return m.data.GenesisHash }
+func (m *Manifest) BlockHeight() int                    { return m.data.BlockHeight }
+func (m *Manifest) BlockHash() crypto.Hash              { return m.data.BlockHash }
+func (m *Manifest) StateHash() crypto.Hash              { return m.data.StateHash }
+func (m *Manifest) LastCertificate() *block.Certificate { return m.data.LastCertificate }
+func (m *Manifest) FirstBlockHeight() int               { return m.data.FirstBlockHeight }
+func (m *Manifest) ChunkHashes() []crypto.Hash          { return m.data.ChunkHashes }
+func (m *Manifest) TotalChunks() int                    { return len(m.data.ChunkHashes) }
+
+func (m *Manifest) SanityCheck() error {
+	if m.data.Version != Version {
+		return errors.Errorf(errors.ErrGeneric, "unsupported snapshot version: %v", m.data.Version)
+	}
+	if err := m.data.GenesisHash.SanityCheck(); err != nil {
+		return errors.Errorf(errors.ErrGeneric, "invalid genesis hash: %v", err)
+	}
+	if err := m.data.BlockHash.SanityCheck(); err != nil {
+		return errors.Errorf(errors.ErrGeneric, "invalid block hash: %v", err)
+	}
+	if err := m.data.StateHash.SanityCheck(); err != nil {
+		return errors.Errorf(errors.ErrGeneric, "invalid state hash: %v", err)
+	}
+	if m.data.LastCertificate == nil {
+		return errors.Errorf(errors.ErrGeneric, "no certificate")
+	}
+	if err := m.data.LastCertificate.SanityCheck(); err != nil {
+		return err
+	}
+	if m.data.FirstBlockHeight < 1 || m.data.FirstBlockHeight > m.data.BlockHeight {
+		return errors.Errorf(errors.ErrGeneric, "invalid range of blocks: %v-%v", m.data.FirstBlockHeight, m.data.BlockHeight)
+	}
+	if len(m.data.ChunkHashes) == 0 {
+		return errors.Errorf(errors.ErrGeneric, "no chunk")
+	}
+	return nil
+}
+
+// VerifyNextBlock checks if the block is committed right after the snapshot.
+// The state hash of the next block should be same as the state hash of the snapshot.
+func (m *Manifest) VerifyNextBlock(b *block.Block) error {
+	if !b.Header().LastBlockHash().EqualsTo(m.data.BlockHash) {
+		return errors.Errorf(errors.ErrInvalidBlock, "block is not linked to the snapshot")
+	}
+	if !b.Header().StateHash().EqualsTo(m.data.StateHash) {
+		return errors.Errorf(errors.ErrInvalidBlock, "state hash is not same as we expected. Expected %v, got %v", m.data.StateHash, b.Header().StateHash())
+	}
+	return nil
+}
+
+func (m *Manifest) Hash() crypto.Hash {
+	bs, err := m.Encode()
+	if err != nil {
+		panic(err)
+	}
+	return crypto.HashH(bs)
+}
+
+func (m *Manifest) Encode() ([]byte, error) {
+	return cbor.Marshal(m.data)
+}
+
+func (m *Manifest) Decode(bs []byte) error {
+	return cbor.Unmarshal(bs, &m.data)
+}
+
+func (m *Manifest) MarshalCBOR() ([]byte, error) {
+	return cbor.Marshal(m.data)
+}
+
+func (m *Manifest) UnmarshalCBOR(bs []byte) error {
+	return cbor.Unmarshal(bs, &m.data)
+}
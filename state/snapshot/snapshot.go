@@ -0,0 +1,476 @@
+package snapshot
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/zarbchain/zarb-go/account"
+	"github.com/zarbchain/zarb-go/block"
+	"github.com/zarbchain/zarb-go/crypto"
+	"github.com/zarbchain/zarb-go/errors"
+	"github.com/zarbchain/zarb-go/genesis"
+	simplemerkle "github.com/zarbchain/zarb-go/libs/merkle"
+	"github.com/zarbchain/zarb-go/param"
+	"github.com/zarbchain/zarb-go/sortition"
+	"github.com/zarbchain/zarb-go/state/lastinfo"
+	"github.com/zarbchain/zarb-go/store"
+	"github.com/zarbchain/zarb-go/tx"
+	"github.com/zarbchain/zarb-go/util"
+	"github.com/zarbchain/zarb-go/validator"
+)
+
+// MinimumRecentBlocks is the minimum number of blocks that a snapshot keeps.
+// Restoring the last info needs them to rebuild the committee and the sortition parameters.
+const MinimumRecentBlocks = 8
+
+// ChunkSize is the maximum number of items (accounts, validators, blocks or transactions) in a chunk
+var ChunkSize = 1000
+
+// ChunkBytes is the maximum size of the encoded items in a chunk.
+// Each chunk is sent in one message, and the size of the messages is limited to 1 MiB.
+// An item bigger than this size is kept in a chunk alone.
+var ChunkBytes = 512 * 1024
+
+// Snapshot contains the state of the blockchain at a specific height.
+// Accounts, validators and the recent blocks are split into chunks.
+type Snapshot struct {
+	manifest *Manifest
+	chunks   []*Chunk
+}
+
+func NewSnapshot(manifest *Manifest, chunks []*Chunk) *Snapshot {
+	return &Snapshot{
+		manifest: manifest,
+		chunks:   chunks,
+	}
+}
+
+func (s *Snapshot) Manifest() *Manifest { return s.manifest }
+func (s *Snapshot) Chunks() []*Chunk    { return s.chunks }
+
+// Export takes a snapshot of the store at the last committed height.
+// The snapshot keeps `recentBlocks` blocks with their transactions.
+func Export(st store.Store, genDoc *genesis.Genesis, recentBlocks int) (*Snapshot, error) {
+	if !st.HasAnyBlock() {
+		return nil, errors.Errorf(errors.ErrGeneric, "store has no block")
+	}
+
+	li := lastinfo.NewLastInfo(st)
+	if _, err := li.RestoreLastInfo(genDoc.Params().CommitteeSize, sortition.NewSortition()); err != nil {
+		return nil, err
+	}
+
+	height := li.BlockHeight()
+	if recentBlocks < MinimumRecentBlocks {
+		recentBlocks = MinimumRecentBlocks
+	}
+	firstBlockHeight := util.Max(height-recentBlocks+1, 1)
+
+	chunks := []*Chunk{newChunk(0)}
+	// current returns the chunk that the item should be added to
+	current := func(item encodable) (*Chunk, error) {
+		bs, err := item.Encode()
+		if err != nil {
+			return nil, err
+		}
+		c := chunks[len(chunks)-1]
+		if c.size() >= ChunkSize || (c.size() > 0 && c.bytes+len(bs) > ChunkBytes) {
+			c = newChunk(len(chunks))
+			chunks = append(chunks, c)
+		}
+		c.bytes += len(bs)
+		return c, nil
+	}
+
+	var err error
+	accs := make([]*account.Account, 0, st.TotalAccounts())
+	st.IterateAccounts(func(acc *account.Account) (stop bool) {
+		accs = append(accs, acc)
+		var c *Chunk
+		c, err = current(acc)
+		if err != nil {
+			return true
+		}
+		c.data.Accounts = append(c.data.Accounts, acc)
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	vals := make([]*validator.Validator, 0, st.TotalValidators())
+	st.IterateValidators(func(val *validator.Validator) (stop bool) {
+		vals = append(vals, val)
+		var c *Chunk
+		c, err = current(val)
+		if err != nil {
+			return true
+		}
+		c.data.Validators = append(c.data.Validators, val)
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for h := firstBlockHeight; h <= height; h++ {
+		b, err := st.Block(h)
+		if err != nil {
+			return nil, errors.Errorf(errors.ErrGeneric, "unable to retrieve block %v: %v", h, err)
+		}
+		c, err := current(b)
+		if err != nil {
+			return nil, err
+		}
+		c.data.Blocks = append(c.data.Blocks, b)
+
+		for _, id := range b.TxIDs().IDs() {
+			trx, err := st.Transaction(id)
+			if err != nil {
+				return nil, errors.Errorf(errors.ErrGeneric, "unable to retrieve transaction %v: %v", id, err)
+			}
+			c, err := current(trx)
+			if err != nil {
+				return nil, err
+			}
+			c.data.Transactions = append(c.data.Transactions, trx)
+		}
+	}
+
+	stateHash, err := CalcStateHash(accs, vals)
+	if err != nil {
+		return nil, err
+	}
+
+	chunkHashes := make([]crypto.Hash, len(chunks))
+	for i, c := range chunks {
+		chunkHashes[i] = c.Hash()
+	}
+
+	manifest := NewManifest(genDoc.Hash(), height, li.BlockHash(), stateHash,
+		li.Certificate(), firstBlockHeight, chunkHashes)
+
+	return NewSnapshot(manifest, chunks), nil
+}
+
+// Verify checks the snapshot against the trusted block hash and state hash
+func (s *Snapshot) Verify(genHash, blockHash, stateHash crypto.Hash) error {
+	if err := s.manifest.SanityCheck(); err != nil {
+		return err
+	}
+	if !s.manifest.GenesisHash().EqualsTo(genHash) {
+		return errors.Errorf(errors.ErrGeneric, "invalid genesis hash. Expected %v, got %v", genHash, s.manifest.GenesisHash())
+	}
+	if !s.manifest.BlockHash().EqualsTo(blockHash) {
+		return errors.Errorf(errors.ErrInvalidBlock, "invalid block hash. Expected %v, got %v", blockHash, s.manifest.BlockHash())
+	}
+	if !s.manifest.StateHash().EqualsTo(stateHash) {
+		return errors.Errorf(errors.ErrGeneric, "invalid state hash. Expected %v, got %v", stateHash, s.manifest.StateHash())
+	}
+	if len(s.chunks) != s.manifest.TotalChunks() {
+		return errors.Errorf(errors.ErrGeneric, "invalid number of chunks. Expected %v, got %v", s.manifest.TotalChunks(), len(s.chunks))
+	}
+	for i, c := range s.chunks {
+		if err := s.VerifyChunk(c); err != nil {
+			return err
+		}
+		if c.Index() != i {
+			return errors.Errorf(errors.ErrGeneric, "chunk %v is out of order", c.Index())
+		}
+	}
+
+	accs, vals, blocks, txs := s.collect()
+
+	calculatedStateHash, err := CalcStateHash(accs, vals)
+	if err != nil {
+		return err
+	}
+	if !calculatedStateHash.EqualsTo(stateHash) {
+		return errors.Errorf(errors.ErrGeneric, "state hash is not same as we expected. Expected %v, got %v", stateHash, calculatedStateHash)
+	}
+
+	if len(blocks) != s.manifest.BlockHeight()-s.manifest.FirstBlockHeight()+1 {
+		return errors.Errorf(errors.ErrInvalidBlock, "invalid number of blocks: %v", len(blocks))
+	}
+	for i, b := range blocks {
+		if err := b.SanityCheck(); err != nil {
+			return err
+		}
+		if i > 0 {
+			prevHash := blocks[i-1].Hash()
+			if !b.Header().LastBlockHash().EqualsTo(prevHash) {
+				return errors.Errorf(errors.ErrInvalidBlock, "block %v is not linked to the previous block", s.manifest.FirstBlockHeight()+i)
+			}
+			if b.LastCertificate() == nil || !b.LastCertificate().BlockHash().EqualsTo(prevHash) {
+				return errors.Errorf(errors.ErrInvalidBlock, "block %v has invalid certificate", s.manifest.FirstBlockHeight()+i)
+			}
+		}
+		for _, id := range b.TxIDs().IDs() {
+			if _, ok := txs[id]; !ok {
+				return errors.Errorf(errors.ErrInvalidTx, "transaction %v is missing", id)
+			}
+		}
+	}
+	if !blocks[len(blocks)-1].HashesTo(blockHash) {
+		return errors.Errorf(errors.ErrInvalidBlock, "last block hash is not same as we expected. Expected %v, got %v", blockHash, blocks[len(blocks)-1].Hash())
+	}
+
+	return checkCertificate(s.manifest.LastCertificate(), blockHash, vals)
+}
+
+// VerifyCertifiedBlock checks the block that is committed right after the snapshot.
+// The block should be certified by the committee. The committee members are taken from the snapshot,
+// so a forged snapshot can certify a forged block. The block should be trusted by other means, like a trusted hash.
+func (s *Snapshot) VerifyCertifiedBlock(b *block.Block, cert *block.Certificate) error {
+	if err := b.SanityCheck(); err != nil {
+		return err
+	}
+	if err := cert.SanityCheck(); err != nil {
+		return err
+	}
+	if err := s.manifest.VerifyNextBlock(b); err != nil {
+		return err
+	}
+
+	_, vals, _, _ := s.collect()
+	return checkCertificate(cert, b.Hash(), vals)
+}
+
+// VerifyChunk checks the chunk against the hashes inside the manifest
+func (s *Snapshot) VerifyChunk(c *Chunk) error {
+	if c.Index() < 0 || c.Index() >= s.manifest.TotalChunks() {
+		return errors.Errorf(errors.ErrGeneric, "invalid chunk index: %v", c.Index())
+	}
+	if !c.Hash().EqualsTo(s.manifest.ChunkHashes()[c.Index()]) {
+		return errors.Errorf(errors.ErrGeneric, "chunk %v has invalid hash", c.Index())
+	}
+	return nil
+}
+
+// Restore verifies the snapshot and writes it into an empty store
+func (s *Snapshot) Restore(st store.Store, genHash, blockHash, stateHash crypto.Hash) error {
+	if st.HasAnyBlock() {
+		return errors.Errorf(errors.ErrGeneric, "store is not empty")
+	}
+	if err := s.Verify(genHash, blockHash, stateHash); err != nil {
+		return err
+	}
+
+	accs, vals, blocks, txs := s.collect()
+	for _, acc := range accs {
+		st.UpdateAccount(acc)
+	}
+	for _, val := range vals {
+		st.UpdateValidator(val)
+	}
+	for i, b := range blocks {
+		st.SaveBlock(s.manifest.FirstBlockHeight()+i, b)
+	}
+	for _, trx := range txs {
+		st.SaveTransaction(trx)
+	}
+
+	li := lastinfo.NewLastInfo(st)
+	li.SetBlockHeight(s.manifest.BlockHeight())
+	li.SetCertificate(s.manifest.LastCertificate())
+	li.SaveLastInfo()
+	st.SaveRestoredHeight(s.manifest.BlockHeight())
+
+	return st.WriteBatch()
+}
+
+func (s *Snapshot) collect() ([]*account.Account, []*validator.Validator, []*block.Block, map[tx.ID]*tx.Tx) {
+	accs := make([]*account.Account, 0)
+	vals := make([]*validator.Validator, 0)
+	blocks := make([]*block.Block, 0)
+	txs := make(map[tx.ID]*tx.Tx)
+
+	for _, c := range s.chunks {
+		accs = append(accs, c.Accounts()...)
+		vals = append(vals, c.Validators()...)
+		blocks = append(blocks, c.Blocks()...)
+		for _, trx := range c.Transactions() {
+			txs[trx.ID()] = trx
+		}
+	}
+
+	return accs, vals, blocks, txs
+}
+
+// SaveToFile writes the manifest and the chunks into the file, one after another
+func (s *Snapshot) SaveToFile(path string) error {
+	buf := new(bytes.Buffer)
+	enc := cbor.NewEncoder(buf)
+	if err := enc.Encode(s.manifest); err != nil {
+		return err
+	}
+	for _, c := range s.chunks {
+		if err := enc.Encode(c); err != nil {
+			return err
+		}
+	}
+
+	return util.WriteFile(path, buf.Bytes())
+}
+
+func LoadFromFile(path string) (*Snapshot, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	dec := cbor.NewDecoder(bufio.NewReader(f))
+	manifest := new(Manifest)
+	if err := dec.Decode(manifest); err != nil {
+		return nil, err
+	}
+	if err := manifest.SanityCheck(); err != nil {
+		return nil, err
+	}
+
+	chunks := make([]*Chunk, manifest.TotalChunks())
+	for i := range chunks {
+		c := new(Chunk)
+		if err := dec.Decode(c); err != nil {
+			return nil, errors.Errorf(errors.ErrGeneric, "unable to read chunk %v: %v", i, err)
+		}
+		chunks[i] = c
+	}
+
+	return NewSnapshot(manifest, chunks), nil
+}
+
+// CalcStateHash calculates the state hash of the accounts and validators in the same way that the state does.
+// Unlike the state, it doesn't trust the numbers of accounts and validators.
+func CalcStateHash(accs []*account.Account, vals []*validator.Validator) (crypto.Hash, error) {
+	accHashes := make([]crypto.Hash, len(accs))
+	for _, acc := range accs {
+		if acc.Number() < 0 || acc.Number() >= len(accs) {
+			return crypto.UndefHash, errors.Errorf(errors.ErrGeneric, "account number is out of range: %v", acc.Number())
+		}
+		if !accHashes[acc.Number()].IsUndef() {
+			return crypto.UndefHash, errors.Errorf(errors.ErrGeneric, "duplicated account number: %v", acc.Number())
+		}
+		accHashes[acc.Number()] = acc.Hash()
+	}
+
+	valHashes := make([]crypto.Hash, len(vals))
+	for _, val := range vals {
+		if val.Number() < 0 || val.Number() >= len(vals) {
+			return crypto.UndefHash, errors.Errorf(errors.ErrGeneric, "validator number is out of range: %v", val.Number())
+		}
+		if !valHashes[val.Number()].IsUndef() {
+			return crypto.UndefHash, errors.Errorf(errors.ErrGeneric, "duplicated validator number: %v", val.Number())
+		}
+		valHashes[val.Number()] = val.Hash()
+	}
+
+	accRootHash := simplemerkle.NewTreeFromHashes(accHashes).Root()
+	valRootHash := simplemerkle.NewTreeFromHashes(valHashes).Root()
+
+	return *simplemerkle.HashMerkleBranches(&accRootHash, &valRootHash), nil
+}
+
+func checkCertificate(cert *block.Certificate, blockHash crypto.Hash, vals []*validator.Validator) error {
+	if !cert.BlockHash().EqualsTo(blockHash) {
+		return errors.Errorf(errors.ErrInvalidBlock,
+			"certificate has invalid block hash. Expected %v, got %v", blockHash, cert.BlockHash())
+	}
+
+	valMap := make(map[int]*validator.Validator)
+	for _, val := range vals {
+		valMap[val.Number()] = val
+	}
+
+	pubs := make([]crypto.PublicKey, 0, len(cert.Committers()))
+	totalStake := int64(0)
+	signersStake := int64(0)
+	for _, num := range cert.Committers() {
+		val, ok := valMap[num]
+		if !ok {
+			return errors.Errorf(errors.ErrInvalidBlock,
+				"certificate has invalid committer: %x", num)
+		}
+		if !util.HasItem(cert.Absentees(), num) {
+			pubs = append(pubs, val.PublicKey())
+			signersStake += val.Power()
+		}
+		totalStake += val.Power()
+	}
+
+	// Check if signers have 2/3+ of total stake
+	if signersStake <= totalStake*2/3 {
+		return errors.Errorf(errors.ErrInvalidBlock, "No quorom. Has %v, should be more than %v", signersStake, totalStake*2/3)
+	}
+
+	if !crypto.VerifyAggregated(cert.Signature(), pubs, cert.SignBytes()) {
+		return errors.Errorf(errors.ErrInvalidBlock,
+			"certificate has invalid signature: %v", cert.Signature())
+	}
+
+	return nil
+}
+
+// ---------
+// For tests
+func GenerateTestSnapshot(height int) (*Snapshot, *block.Block, *block.Certificate) {
+	st := store.MockingStore()
+	signers := make([]crypto.Signer, 4)
+	vals := make([]*validator.Validator, 4)
+	accs := make([]*account.Account, 6)
+	for i := 0; i < 4; i++ {
+		vals[i], signers[i] = validator.GenerateTestValidator(i)
+		st.UpdateValidator(vals[i])
+	}
+	for i := 0; i < 6; i++ {
+		accs[i], _ = account.GenerateTestAccount(i)
+		st.UpdateAccount(accs[i])
+	}
+	params := param.DefaultParams()
+	params.CommitteeSize = 4
+	genDoc := genesis.MakeGenesis(util.Now(), accs, vals, params)
+
+	makeCertificate := func(blockHash crypto.Hash) *block.Certificate {
+		sigs := make([]crypto.Signature, len(signers))
+		sb := block.CertificateSignBytes(blockHash, 0)
+		for i, s := range signers {
+			sigs[i] = s.SignData(sb)
+		}
+		return block.NewCertificate(blockHash, 0, []int{0, 1, 2, 3}, []int{}, crypto.Aggregate(sigs))
+	}
+	makeBlock := func(lastHash, stateHash crypto.Hash, lastCert *block.Certificate) (*block.Block, *tx.Tx) {
+		trx, _ := tx.GenerateTestSendTx()
+		ids := block.NewTxIDs()
+		ids.Append(trx.ID())
+		b := block.MakeBlock(1, util.Now(), ids, lastHash, stateHash,
+			lastCert, sortition.GenerateRandomSeed(), signers[0].Address())
+		return b, trx
+	}
+
+	lastHash := crypto.UndefHash
+	var lastCert *block.Certificate
+	for h := 1; h <= height; h++ {
+		b, trx := makeBlock(lastHash, crypto.GenerateTestHash(), lastCert)
+		st.SaveBlock(h, b)
+		st.SaveTransaction(trx)
+
+		lastHash = b.Hash()
+		lastCert = makeCertificate(lastHash)
+	}
+
+	li := lastinfo.NewLastInfo(st)
+	li.SetBlockHeight(height)
+	li.SetCertificate(lastCert)
+	li.SaveLastInfo()
+
+	s, err := Export(st, genDoc, height)
+	if err != nil {
+		panic(err)
+	}
+	b, _ := makeBlock(lastHash, s.manifest.StateHash(), lastCert)
+
+	return s, b, makeCertificate(b.Hash())
+}
@@ -0,0 +1,262 @@
+package snapshot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zarbchain/zarb-go/account"
+	"github.com/zarbchain/zarb-go/block"
+	"github.com/zarbchain/zarb-go/crypto"
+	"github.com/zarbchain/zarb-go/genesis"
+	"github.com/zarbchain/zarb-go/logger"
+	"github.com/zarbchain/zarb-go/param"
+	"github.com/zarbchain/zarb-go/sortition"
+	"github.com/zarbchain/zarb-go/state/lastinfo"
+	"github.com/zarbchain/zarb-go/store"
+	"github.com/zarbchain/zarb-go/tx"
+	"github.com/zarbchain/zarb-go/util"
+	"github.com/zarbchain/zarb-go/validator"
+)
+
+var tStore *store.MockStore
+var tGenDoc *genesis.Genesis
+var tSigners []crypto.Signer
+var tLastHeight = 20
+
+func setup(t *testing.T) {
+	logger.InitLogger(logger.TestConfig())
+
+	tStore = store.MockingStore()
+	tSigners = make([]crypto.Signer, 4)
+	vals := make([]*validator.Validator, 4)
+	accs := make([]*account.Account, 6)
+	for i := 0; i < 4; i++ {
+		vals[i], tSigners[i] = validator.GenerateTestValidator(i)
+		tStore.UpdateValidator(vals[i])
+	}
+	for i := 0; i < 6; i++ {
+		accs[i], _ = account.GenerateTestAccount(i)
+		tStore.UpdateAccount(accs[i])
+	}
+	params := param.DefaultParams()
+	params.CommitteeSize = 4
+	tGenDoc = genesis.MakeGenesis(util.Now(), accs, vals, params)
+
+	lastHash := crypto.UndefHash
+	var lastCert *block.Certificate
+	for h := 1; h <= tLastHeight; h++ {
+		trx, _ := tx.GenerateTestSendTx()
+		ids := block.NewTxIDs()
+		ids.Append(trx.ID())
+		b := block.MakeBlock(1, util.Now(), ids, lastHash, crypto.GenerateTestHash(),
+			lastCert, sortition.GenerateRandomSeed(), tSigners[0].Address())
+
+		tStore.SaveBlock(h, b)
+		tStore.SaveTransaction(trx)
+
+		lastHash = b.Hash()
+		lastCert = makeCertificate(lastHash)
+	}
+
+	li := lastinfo.NewLastInfo(tStore)
+	li.SetBlockHeight(tLastHeight)
+	li.SetCertificate(lastCert)
+	li.SaveLastInfo()
+}
+
+func makeCertificate(blockHash crypto.Hash) *block.Certificate {
+	sigs := make([]crypto.Signature, len(tSigners))
+	sb := block.CertificateSignBytes(blockHash, 0)
+	for i, s := range tSigners {
+		sigs[i] = s.SignData(sb)
+	}
+	return block.NewCertificate(blockHash, 0, []int{0, 1, 2, 3}, []int{}, crypto.Aggregate(sigs))
+}
+
+func exportSnapshot(t *testing.T) *Snapshot {
+	ChunkSize = 5
+	s, err := Export(tStore, tGenDoc, 10)
+	require.NoError(t, err)
+	return s
+}
+
+func TestExport(t *testing.T) {
+	setup(t)
+
+	s := exportSnapshot(t)
+	m := s.Manifest()
+	assert.NoError(t, m.SanityCheck())
+	assert.Equal(t, m.BlockHeight(), tLastHeight)
+	assert.Equal(t, m.FirstBlockHeight(), tLastHeight-9)
+	assert.Equal(t, m.BlockHash(), tStore.Blocks[tLastHeight].Hash())
+	assert.Equal(t, m.GenesisHash(), tGenDoc.Hash())
+	// 6 accounts, 4 validators, 10 blocks and 10 transactions
+	assert.Equal(t, m.TotalChunks(), 6)
+	assert.NoError(t, s.Verify(tGenDoc.Hash(), m.BlockHash(), m.StateHash()))
+}
+
+func TestExportMinimumBlocks(t *testing.T) {
+	setup(t)
+
+	s, err := Export(tStore, tGenDoc, 1)
+	require.NoError(t, err)
+	assert.Equal(t, s.Manifest().FirstBlockHeight(), tLastHeight-MinimumRecentBlocks+1)
+}
+
+func TestExportChunkBytes(t *testing.T) {
+	setup(t)
+
+	ChunkSize = 1000
+	ChunkBytes = 1024
+	defer func() { ChunkBytes = 512 * 1024 }()
+
+	s, err := Export(tStore, tGenDoc, 10)
+	require.NoError(t, err)
+	assert.Greater(t, len(s.Chunks()), 1)
+	for _, c := range s.Chunks() {
+		bs, err := c.Encode()
+		assert.NoError(t, err)
+		// The chunk itself adds a few bytes to its encoded items
+		if c.size() > 1 {
+			assert.LessOrEqual(t, len(bs), ChunkBytes+16, "chunk %v", c.Index())
+		}
+	}
+	assert.NoError(t, s.Verify(tGenDoc.Hash(), s.Manifest().BlockHash(), s.Manifest().StateHash()))
+}
+
+func TestExportEmptyStore(t *testing.T) {
+	setup(t)
+
+	_, err := Export(store.MockingStore(), tGenDoc, 10)
+	assert.Error(t, err)
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	setup(t)
+
+	s1 := exportSnapshot(t)
+	path := util.TempFilePath()
+	assert.NoError(t, s1.SaveToFile(path))
+
+	s2, err := LoadFromFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, s1.Manifest().Hash(), s2.Manifest().Hash())
+	assert.Equal(t, len(s1.Chunks()), len(s2.Chunks()))
+	for i := range s1.Chunks() {
+		assert.Equal(t, s1.Chunks()[i].Hash(), s2.Chunks()[i].Hash())
+	}
+	assert.NoError(t, s2.Verify(tGenDoc.Hash(), s2.Manifest().BlockHash(), s2.Manifest().StateHash()))
+
+	_, err = LoadFromFile(util.TempFilePath())
+	assert.Error(t, err)
+}
+
+func TestVerify(t *testing.T) {
+	setup(t)
+
+	s := exportSnapshot(t)
+	m := s.Manifest()
+
+	t.Run("Invalid genesis hash", func(t *testing.T) {
+		assert.Error(t, s.Verify(crypto.GenerateTestHash(), m.BlockHash(), m.StateHash()))
+	})
+
+	t.Run("Invalid block hash", func(t *testing.T) {
+		assert.Error(t, s.Verify(tGenDoc.Hash(), crypto.GenerateTestHash(), m.StateHash()))
+	})
+
+	t.Run("Invalid state hash", func(t *testing.T) {
+		assert.Error(t, s.Verify(tGenDoc.Hash(), m.BlockHash(), crypto.GenerateTestHash()))
+	})
+
+	t.Run("Tampered chunk", func(t *testing.T) {
+		s.Chunks()[0].Accounts()[0].AddToBalance(1)
+		assert.Error(t, s.Verify(tGenDoc.Hash(), m.BlockHash(), m.StateHash()))
+		s.Chunks()[0].Accounts()[0].SubtractFromBalance(1)
+		assert.NoError(t, s.Verify(tGenDoc.Hash(), m.BlockHash(), m.StateHash()))
+	})
+
+	t.Run("Missing chunk", func(t *testing.T) {
+		s2 := NewSnapshot(m, s.Chunks()[1:])
+		assert.Error(t, s2.Verify(tGenDoc.Hash(), m.BlockHash(), m.StateHash()))
+	})
+
+	t.Run("Forged state", func(t *testing.T) {
+		// Chunk hashes and state hash are matched with the forged state,
+		// but the certificate doesn't match
+		acc, _ := account.GenerateTestAccount(0)
+		_, vals, blocks, txs := s.collect()
+		c := newChunk(0)
+		c.data.Accounts = []*account.Account{acc}
+		c.data.Validators = vals
+		c.data.Blocks = blocks
+		for _, trx := range txs {
+			c.data.Transactions = append(c.data.Transactions, trx)
+		}
+		stateHash, err := CalcStateHash(c.Accounts(), c.Validators())
+		assert.NoError(t, err)
+		cert := block.NewCertificate(m.BlockHash(), 0, []int{0, 1, 2, 3}, []int{},
+			crypto.GenerateTestSigner().SignData(block.CertificateSignBytes(m.BlockHash(), 0)))
+		m2 := NewManifest(m.GenesisHash(), m.BlockHeight(), m.BlockHash(), stateHash,
+			cert, m.FirstBlockHeight(), []crypto.Hash{c.Hash()})
+		s2 := NewSnapshot(m2, []*Chunk{c})
+		assert.Error(t, s2.Verify(tGenDoc.Hash(), m.BlockHash(), stateHash))
+	})
+}
+
+func TestRestore(t *testing.T) {
+	setup(t)
+
+	s := exportSnapshot(t)
+	m := s.Manifest()
+
+	st, err := store.NewStore(store.TestConfig())
+	require.NoError(t, err)
+
+	assert.Error(t, s.Restore(st, tGenDoc.Hash(), crypto.GenerateTestHash(), m.StateHash()))
+	assert.False(t, st.HasAnyBlock())
+
+	assert.NoError(t, s.Restore(st, tGenDoc.Hash(), m.BlockHash(), m.StateHash()))
+	assert.Equal(t, st.RestoredHeight(), m.BlockHeight())
+	assert.Equal(t, st.TotalAccounts(), 6)
+	assert.Equal(t, st.TotalValidators(), 4)
+	for h := m.FirstBlockHeight(); h <= m.BlockHeight(); h++ {
+		b, err := st.Block(h)
+		assert.NoError(t, err)
+		assert.Equal(t, b.Hash(), tStore.Blocks[h].Hash())
+	}
+	_, err = st.Block(m.FirstBlockHeight() - 1)
+	assert.Error(t, err)
+
+	li := lastinfo.NewLastInfo(st)
+	_, err = li.RestoreLastInfo(4, sortition.NewSortition())
+	assert.NoError(t, err)
+	assert.Equal(t, li.BlockHeight(), tLastHeight)
+	assert.Equal(t, li.BlockHash(), m.BlockHash())
+
+	// Store is not empty anymore
+	assert.Error(t, s.Restore(st, tGenDoc.Hash(), m.BlockHash(), m.StateHash()))
+}
+
+func TestVerifyCertifiedBlock(t *testing.T) {
+	s, b, c := GenerateTestSnapshot(10)
+
+	assert.NoError(t, s.VerifyCertifiedBlock(b, c))
+
+	t.Run("Invalid certificate", func(t *testing.T) {
+		c2 := block.GenerateTestCertificate(b.Hash())
+		assert.Error(t, s.VerifyCertifiedBlock(b, c2))
+	})
+
+	t.Run("Certificate for another block", func(t *testing.T) {
+		b2, _ := block.GenerateTestBlock(nil, nil)
+		assert.Error(t, s.VerifyCertifiedBlock(b2, c))
+	})
+
+	t.Run("Not linked to the snapshot", func(t *testing.T) {
+		s2, _, _ := GenerateTestSnapshot(10)
+		assert.Error(t, s2.Manifest().VerifyNextBlock(b))
+		assert.Error(t, s2.VerifyCertifiedBlock(b, c))
+	})
+}
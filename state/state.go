@@ -2,6 +2,7 @@ package state
 
 import (
 	"fmt"
+	"os"
 	"sync"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/zarbchain/zarb-go/sandbox"
 	"github.com/zarbchain/zarb-go/sortition"
 	"github.com/zarbchain/zarb-go/state/lastinfo"
+	"github.com/zarbchain/zarb-go/state/snapshot"
 	"github.com/zarbchain/zarb-go/store"
 	"github.com/zarbchain/zarb-go/tx"
 	"github.com/zarbchain/zarb-go/txpool"
@@ -37,6 +39,9 @@ type state struct {
 	committee    *committee.Committee
 	sortition    *sortition.Sortition
 	lastInfo     *lastinfo.LastInfo
+	lastSnapshot *snapshot.Snapshot
+	snapshotWg   sync.WaitGroup
+	verifyWg     sync.WaitGroup
 	logger       *logger.Logger
 }
 
@@ -86,6 +91,10 @@ func LoadOrNewState(
 
 	txPool.SetNewSandboxAndRecheck(st.concreteSandbox())
 
+	if conf.VerifyOnStartup && store.HasAnyBlock() {
+		st.startVerifying()
+	}
+
 	return st, nil
 }
 
@@ -98,13 +107,20 @@ func (st *state) tryLoadLastInfo() error {
 	//
 	// This check is not important because genesis state is committed.
 	// But it is good to have it to make sure genesis doc hasn't changed
-	genHash := st.calculateGenesisStateHashFromGenesisDoc()
-	blockOne, err := st.store.Block(1)
-	if err != nil {
-		return err
-	}
-	if !genHash.EqualsTo(blockOne.Header().StateHash()) {
-		return fmt.Errorf("invalid genesis doc")
+	//
+	// A store that is restored from a snapshot has no genesis block.
+	// The genesis hash is checked while importing the snapshot.
+	if h := st.store.RestoredHeight(); h > 0 {
+		logger.Info("No genesis block. The state is restored from a snapshot", "height", h)
+	} else {
+		genHash := st.calculateGenesisStateHashFromGenesisDoc()
+		blockOne, err := st.store.Block(1)
+		if err != nil {
+			return err
+		}
+		if !genHash.EqualsTo(blockOne.Header().StateHash()) {
+			return fmt.Errorf("invalid genesis doc")
+		}
 	}
 
 	logger.Info("Try to load the last state info")
@@ -147,6 +163,9 @@ func (st *state) makeGenesisState(genDoc *genesis.Genesis) error {
 }
 
 func (st *state) Close() error {
+	st.snapshotWg.Wait()
+	st.verifyWg.Wait()
+
 	st.lk.RLock()
 	defer st.lk.RUnlock()
 
@@ -309,6 +328,16 @@ func (st *state) ValidateBlock(block *block.Block) error {
 	return nil
 }
 
+// VerifyCertificate verifies the signature and the quorum of the certificate.
+// Unlike CommitBlock, it doesn't check the committee, so it can verify the blocks ahead of us,
+// as long as their committers are known validators.
+func (st *state) VerifyCertificate(cert *block.Certificate) error {
+	st.lk.RLock()
+	defer st.lk.RUnlock()
+
+	return st.checkCertificate(cert)
+}
+
 func (st *state) CommitBlock(height int, block *block.Block, cert *block.Certificate) error {
 	st.lk.Lock()
 	defer st.lk.Unlock()
@@ -349,6 +378,40 @@ func (st *state) CommitBlock(height int, block *block.Block, cert *block.Certifi
 		return errors.Errorf(errors.ErrInvalidBlock, "invalid sortition seed.")
 	}
 
+	if err := st.applyBlock(block, cert); err != nil {
+		return err
+	}
+
+	st.logger.Info("New block is committed", "block", block, "round", cert.Round())
+
+	// Evaluate sortition before updating the committee
+	if st.evaluateSortition() {
+		st.logger.Info("👏 This validator is chosen to be in the committee", "address", st.signer.Address())
+	}
+
+	// -----------------------------------
+	// At this point we can assign new sandbox to tx pool
+	st.txPool.SetNewSandboxAndRecheck(st.concreteSandbox())
+
+	if st.config.SnapshotInterval > 0 &&
+		st.lastInfo.BlockHeight()%st.config.SnapshotInterval == 0 {
+		// Exporting the whole state takes time.
+		// It is done in the background on a read-only view of the store, so committing blocks doesn't wait for it.
+		view, err := st.store.View()
+		if err != nil {
+			st.logger.Error("Unable to take a snapshot", "err", err)
+		} else {
+			st.snapshotWg.Add(1)
+			go st.takeSnapshot(view)
+		}
+	}
+
+	return nil
+}
+
+// applyBlock executes the block and commits it.
+// It updates the last info, the committee, the store and the sortition parameters.
+func (st *state) applyBlock(block *block.Block, cert *block.Certificate) error {
 	// -----------------------------------
 	// Execute block
 	sb := st.concreteSandbox()
@@ -381,19 +444,119 @@ func (st *state) CommitBlock(height int, block *block.Block, cert *block.Certifi
 		st.logger.Panic("Unable to update state", "err", err)
 	}
 
-	st.logger.Info("New block is committed", "block", block, "round", cert.Round())
-
 	// -----------------------------------
-	// Update sortition params and evaluate sortition
+	// Update sortition params
 	st.sortition.SetParams(block.Hash(), block.Header().SortitionSeed(), st.poolStake())
 
-	// Evaluate sortition before updating the committee
-	if st.evaluateSortition() {
-		st.logger.Info("👏 This validator is chosen to be in the committee", "address", st.signer.Address())
+	return nil
+}
+
+func (st *state) takeSnapshot(view store.Store) {
+	defer st.snapshotWg.Done()
+	defer view.Close()
+
+	s, err := snapshot.Export(view, st.genDoc, st.params.TransactionToLiveInterval)
+	if err != nil {
+		st.logger.Error("Unable to take a snapshot", "err", err)
+		return
 	}
 
-	// -----------------------------------
-	// At this point we can assign new sandbox to tx pool
+	st.lk.Lock()
+	defer st.lk.Unlock()
+
+	// An older snapshot might be finished later
+	if st.lastSnapshot != nil && st.lastSnapshot.Manifest().BlockHeight() >= s.Manifest().BlockHeight() {
+		return
+	}
+	st.lastSnapshot = s
+	st.logger.Info("New snapshot is taken", "height", s.Manifest().BlockHeight(), "chunks", s.Manifest().TotalChunks())
+}
+
+// startVerifying verifies the store in background, on a view of the store at this moment
+func (st *state) startVerifying() {
+	view, err := st.store.View()
+	if err != nil {
+		st.logger.Error("Unable to verify the store", "err", err)
+		return
+	}
+	st.verifyWg.Add(1)
+	go st.verify(view)
+}
+
+func (st *state) verify(view store.Store) {
+	defer st.verifyWg.Done()
+	defer view.Close()
+
+	// Blocks are replayed into a temporary store
+	replayConf := store.DefaultConfig()
+	replayConf.Path = util.TempDirPath()
+	defer os.RemoveAll(replayConf.Path)
+	replayStore, err := store.NewStore(replayConf)
+	if err != nil {
+		st.logger.Error("Unable to create the replay store", "err", err)
+		return
+	}
+	defer replayStore.Close()
+
+	st.logger.Info("Verifying the store in background")
+	err = Verify(st.genDoc, view, replayStore, verifyStateHashInterval)
+	if err != nil {
+		if cErr, ok := err.(*CorruptionError); ok {
+			st.logger.Error("The store is corrupted. Stop the node and roll back the state by `zarb rollback`",
+				"height", cErr.Height, "err", cErr.Err)
+			return
+		}
+		st.logger.Error("Unable to verify the store", "err", err)
+		return
+	}
+	st.logger.Info("The store is verified")
+}
+
+func (st *state) LastSnapshot() *snapshot.Snapshot {
+	st.lk.RLock()
+	defer st.lk.RUnlock()
+
+	return st.lastSnapshot
+}
+
+// RestoreSnapshot restores the state from a snapshot that is received from other peers.
+// The block `b` is the block after the snapshot and it is certified by `cert`.
+// The state hash of this block should be same as the state hash of the snapshot.
+func (st *state) RestoreSnapshot(s *snapshot.Snapshot, b *block.Block, cert *block.Certificate, trxs []*tx.Tx) error {
+	if err := st.restoreSnapshot(s, b, cert); err != nil {
+		return err
+	}
+
+	for _, trx := range trxs {
+		if err := st.txPool.AppendTx(trx); err != nil {
+			st.logger.Debug("Error on appending transaction", "tx", trx, "err", err)
+		}
+	}
+
+	return st.CommitBlock(s.Manifest().BlockHeight()+1, b, cert)
+}
+
+func (st *state) restoreSnapshot(s *snapshot.Snapshot, b *block.Block, cert *block.Certificate) error {
+	st.lk.Lock()
+	defer st.lk.Unlock()
+
+	if st.store.HasAnyBlock() {
+		return errors.Errorf(errors.ErrGeneric, "state is not empty")
+	}
+
+	if err := s.VerifyCertifiedBlock(b, cert); err != nil {
+		return err
+	}
+
+	if err := s.Restore(st.store, st.genDoc.Hash(), b.Header().LastBlockHash(), b.Header().StateHash()); err != nil {
+		return err
+	}
+
+	if err := st.tryLoadLastInfo(); err != nil {
+		return err
+	}
+
+	st.logger.Info("State is restored from the snapshot", "height", s.Manifest().BlockHeight())
 	st.txPool.SetNewSandboxAndRecheck(st.concreteSandbox())
 
 	return nil
@@ -450,12 +613,14 @@ func (st *state) commitSandbox(sb *sandbox.Concrete, round int) {
 		}
 	})
 
+	oldCommitters := st.committee.Committers()
 	if err := st.committee.Update(round, joined); err != nil {
 		//
 		// We should panic here before updating the state
 		//
 		logger.Panic("An error occurred", "err", err)
 	}
+	st.saveCommitteeChange(oldCommitters, st.committee.Committers())
 
 	sb.IterateAccounts(func(as *sandbox.AccountStatus) {
 		if as.Updated {
@@ -570,6 +735,25 @@ func (st *state) Transaction(id tx.ID) *tx.Tx {
 	return tx
 }
 
+// saveCommitteeChange keeps the history of the committee for the explorers.
+func (st *state) saveCommitteeChange(oldCommitters, newCommitters []int) {
+	change := &store.CommitteeChange{
+		Joined: util.Subtracts(newCommitters, oldCommitters),
+		Left:   util.Subtracts(oldCommitters, newCommitters),
+	}
+	if len(change.Joined) > 0 || len(change.Left) > 0 {
+		st.store.SaveCommitteeChange(st.lastInfo.BlockHeight(), change)
+	}
+}
+
+func (st *state) CommitteeChange(height int) *store.CommitteeChange {
+	c, err := st.store.CommitteeChange(height)
+	if err != nil {
+		st.logger.Trace("Error on retrieving committee change", "err", err)
+	}
+	return c
+}
+
 func (st *state) Block(height int) *block.Block {
 	b, err := st.store.Block(height)
 	if err != nil {
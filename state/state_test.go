@@ -418,6 +418,13 @@ func TestSortition(t *testing.T) {
 	assert.True(t, tState1.committee.Contains(tValSigner1.Address()))
 	assert.True(t, tState1.committee.Contains(addr))
 
+	// The committee change is kept in the store
+	change := tState1.CommitteeChange(height)
+	require.NotNil(t, change)
+	assert.Equal(t, change.Joined, []int{4})
+	assert.Empty(t, change.Left)
+	assert.Nil(t, tState1.CommitteeChange(height-1))
+
 	// ---------------------------------------------
 	// Let's save and load tState1
 	tState1.Close()
@@ -664,3 +671,59 @@ func TestSetBlockTime(t *testing.T) {
 		assert.Zero(t, b.Header().Time().Second()%10)
 	})
 }
+
+func TestRestoreSnapshot(t *testing.T) {
+	setup(t)
+
+	tState1.config.SnapshotInterval = 5
+	for i := 0; i < 6; i++ {
+		moveToNextHeightForAllStates(t)
+	}
+	tState1.snapshotWg.Wait()
+	s := tState1.LastSnapshot()
+	require.NotNil(t, s)
+	assert.Equal(t, s.Manifest().BlockHeight(), 5)
+
+	b6 := tState1.Block(6)
+	c6 := tState1.LastCertificate()
+	trxs := make([]*tx.Tx, 0)
+	for _, id := range b6.TxIDs().IDs() {
+		trx, err := tState1.store.Transaction(id)
+		require.NoError(t, err)
+		trxs = append(trxs, trx)
+	}
+
+	st, err := LoadOrNewState(TestConfig(), tState1.genDoc, tValSigner1, store.MockingStore(), tCommonTxPool)
+	require.NoError(t, err)
+
+	t.Run("Invalid certificate", func(t *testing.T) {
+		c := makeCertificateAndSign(t, b6.Hash(), 0, tValSigner1)
+		assert.Error(t, st.RestoreSnapshot(s, b6, c, trxs))
+	})
+
+	t.Run("Block is not linked to the snapshot", func(t *testing.T) {
+		b5 := tState1.Block(5)
+		c5 := b6.LastCertificate()
+		assert.Error(t, st.RestoreSnapshot(s, b5, c5, nil))
+	})
+
+	require.NoError(t, st.RestoreSnapshot(s, b6, c6, trxs))
+	assert.Equal(t, st.LastBlockHeight(), 6)
+	assert.Equal(t, st.LastBlockHash(), tState1.LastBlockHash())
+	assert.Equal(t, st.(*state).committee.Committers(), tState1.committee.Committers())
+
+	// Moving forward
+	b7, c7 := makeBlockAndCertificate(t, 0, tValSigner1, tValSigner2, tValSigner3, tValSigner4)
+	assert.NoError(t, st.CommitBlock(7, b7, c7))
+	assert.NoError(t, tState1.CommitBlock(7, b7, c7))
+
+	// Store is not empty anymore
+	assert.Error(t, st.RestoreSnapshot(s, b6, c6, trxs))
+
+	t.Run("Genesis block is missing and the store is not restored from a snapshot", func(t *testing.T) {
+		mockStore := store.MockingStore()
+		mockStore.Blocks[6] = b6
+		_, err := LoadOrNewState(TestConfig(), tState1.genDoc, tValSigner1, mockStore, tCommonTxPool)
+		assert.Error(t, err)
+	})
+}
@@ -185,6 +185,34 @@ func TestCertificateValidation(t *testing.T) {
 	})
 }
 
+func TestVerifyCertificate(t *testing.T) {
+	setup(t)
+
+	blockHash := crypto.GenerateTestHash()
+
+	t.Run("Certificate of a block ahead of us, should return no error", func(t *testing.T) {
+		cert := makeCertificateAndSign(t, blockHash, 0, tValSigner1, tValSigner2, tValSigner3)
+		assert.NoError(t, tState1.VerifyCertificate(cert))
+	})
+
+	t.Run("Invalid signature, should return error", func(t *testing.T) {
+		cert := makeCertificateAndSign(t, crypto.GenerateTestHash(), 0, tValSigner1, tValSigner2, tValSigner3)
+		cert = block.NewCertificate(blockHash, cert.Round(), cert.Committers(), cert.Absentees(), cert.Signature())
+		assert.Error(t, tState1.VerifyCertificate(cert))
+	})
+
+	t.Run("Unknown committer, should return error", func(t *testing.T) {
+		signBytes := block.CertificateSignBytes(blockHash, 0)
+		sig1 := tValSigner1.SignData(signBytes)
+		sig2 := tValSigner2.SignData(signBytes)
+		sig3 := tValSigner3.SignData(signBytes)
+		aggSig := crypto.Aggregate([]crypto.Signature{sig1, sig2, sig3})
+		cert := block.NewCertificate(blockHash, 0, []int{0, 1, 2, 3, 4}, []int{3, 4}, aggSig)
+
+		assert.Error(t, tState1.VerifyCertificate(cert))
+	})
+}
+
 func TestBlockValidation(t *testing.T) {
 	setup(t)
 
@@ -0,0 +1,158 @@
+package state
+
+import (
+	"fmt"
+
+	"github.com/zarbchain/zarb-go/block"
+	"github.com/zarbchain/zarb-go/errors"
+	"github.com/zarbchain/zarb-go/genesis"
+	"github.com/zarbchain/zarb-go/logger"
+	"github.com/zarbchain/zarb-go/sandbox"
+	"github.com/zarbchain/zarb-go/sortition"
+	"github.com/zarbchain/zarb-go/state/lastinfo"
+	"github.com/zarbchain/zarb-go/store"
+	"github.com/zarbchain/zarb-go/tx"
+)
+
+// verifyStateHashInterval is the number of blocks between two state hash checks, when the store is verified on startup
+const verifyStateHashInterval = 1000
+
+// CorruptionError is returned when the store is corrupted at a height
+type CorruptionError struct {
+	Height int
+	Err    error
+}
+
+func (e *CorruptionError) Error() string {
+	return fmt.Sprintf("store is corrupted at height %v: %v", e.Height, e.Err)
+}
+
+// Verify walks through all the blocks of the store and replays them from the genesis.
+// It checks the chaining of the blocks, their certificates against the replayed committee,
+// the hash of the transaction IDs and the existence of the transactions.
+// The state hash is recomputed every `stateHashInterval` blocks. Zero disables it.
+// At the end, the persisted accounts and validators are compared with the replayed ones.
+//
+// The replayed state is kept in `replayStore` that should be empty.
+// On corruption, a CorruptionError is returned that has the first corrupted height.
+func Verify(genDoc *genesis.Genesis, st store.Store, replayStore store.Store, stateHashInterval int) error {
+	if !st.HasAnyBlock() {
+		return errors.Errorf(errors.ErrGeneric, "store is empty")
+	}
+	if replayStore.HasAnyBlock() {
+		return errors.Errorf(errors.ErrGeneric, "replay store is not empty")
+	}
+	b, err := st.Block(1)
+	if err != nil {
+		return errors.Errorf(errors.ErrGeneric, "no genesis block. A store that is restored from a snapshot can't be replayed")
+	}
+
+	replay := &state{
+		genDoc:    genDoc,
+		params:    genDoc.Params(),
+		store:     replayStore,
+		txPool:    &replayTxPool{store: st},
+		sortition: sortition.NewSortition(),
+		lastInfo:  lastinfo.NewLastInfo(replayStore),
+	}
+	replay.logger = logger.NewLogger("_verify", replay)
+	if err := replay.makeGenesisState(genDoc); err != nil {
+		return err
+	}
+
+	for height := 1; ; height++ {
+		next, err := st.Block(height + 1)
+		last := err != nil
+
+		var cert *block.Certificate
+		if last {
+			li := lastinfo.NewLastInfo(st)
+			if _, err := li.RestoreLastInfo(genDoc.Params().CommitteeSize, sortition.NewSortition()); err != nil {
+				return &CorruptionError{Height: height, Err: err}
+			}
+			if li.BlockHeight() != height {
+				return &CorruptionError{Height: height,
+					Err: errors.Errorf(errors.ErrGeneric, "last info has invalid height. Expected %v, got %v", height, li.BlockHeight())}
+			}
+			cert = li.Certificate()
+		} else {
+			cert = next.LastCertificate()
+		}
+
+		checkStateHash := stateHashInterval > 0 && height%stateHashInterval == 0
+		if err := replay.replayBlock(b, cert, checkStateHash); err != nil {
+			return &CorruptionError{Height: height, Err: err}
+		}
+
+		if last {
+			break
+		}
+		b = next
+	}
+
+	// The persisted accounts and validators should be same as the replayed ones
+	stateHash := (&state{store: st}).stateHash()
+	if !stateHash.EqualsTo(replay.stateHash()) {
+		return &CorruptionError{Height: replay.lastInfo.BlockHeight(),
+			Err: errors.Errorf(errors.ErrGeneric, "state hash is not same as we expected. Expected %v, got %v", replay.stateHash(), stateHash)}
+	}
+
+	return nil
+}
+
+// replayBlock validates the block and applies it on the replayed state.
+// It is similar to `CommitBlock`, but the state hash is checked only on demand.
+func (st *state) replayBlock(b *block.Block, cert *block.Certificate, checkStateHash bool) error {
+	if !b.Header().LastBlockHash().EqualsTo(st.lastInfo.BlockHash()) {
+		return errors.Errorf(errors.ErrInvalidBlock, "block is not linked to the previous block")
+	}
+	if err := b.SanityCheck(); err != nil {
+		return err
+	}
+	if checkStateHash && !b.Header().StateHash().EqualsTo(st.stateHash()) {
+		return errors.Errorf(errors.ErrInvalidBlock,
+			"state hash is not same as we expected. Expected %v, got %v", st.stateHash(), b.Header().StateHash())
+	}
+	if err := st.validateCertificateForPreviousHeight(b.LastCertificate()); err != nil {
+		return err
+	}
+	if cert == nil {
+		return errors.Errorf(errors.ErrInvalidBlock, "no certificate")
+	}
+	if err := st.validateCertificate(cert, b.Hash()); err != nil {
+		return err
+	}
+
+	proposer := st.committee.Proposer(cert.Round())
+	if !proposer.Address().EqualsTo(b.Header().ProposerAddress()) {
+		return errors.Errorf(errors.ErrInvalidBlock, "invalid proposer. Expected %s, got %s", proposer.Address(), b.Header().ProposerAddress())
+	}
+	if !b.Header().SortitionSeed().Validate(proposer.PublicKey(), st.lastInfo.SortitionSeed()) {
+		return errors.Errorf(errors.ErrInvalidBlock, "invalid sortition seed.")
+	}
+
+	return st.applyBlock(b, cert)
+}
+
+// replayTxPool provides the transactions of the blocks from the store
+type replayTxPool struct {
+	store store.Store
+}
+
+func (p *replayTxPool) QueryTx(id tx.ID) *tx.Tx {
+	trx, err := p.store.Transaction(id)
+	if err != nil {
+		return nil
+	}
+	return trx
+}
+
+func (p *replayTxPool) AllTransactions() []*tx.Tx               { return nil }
+func (p *replayTxPool) PendingTx(id tx.ID) *tx.Tx               { return nil }
+func (p *replayTxPool) HasTx(id tx.ID) bool                     { return p.QueryTx(id) != nil }
+func (p *replayTxPool) Size() int                               { return 0 }
+func (p *replayTxPool) Fingerprint() string                     { return "" }
+func (p *replayTxPool) SetNewSandboxAndRecheck(sandbox.Sandbox) {}
+func (p *replayTxPool) AppendTx(*tx.Tx) error                   { return nil }
+func (p *replayTxPool) AppendTxAndBroadcast(*tx.Tx) error       { return nil }
+func (p *replayTxPool) RemoveTx(tx.ID)                          {}
@@ -0,0 +1,118 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zarbchain/zarb-go/block"
+	"github.com/zarbchain/zarb-go/crypto"
+	"github.com/zarbchain/zarb-go/store"
+	"github.com/zarbchain/zarb-go/tx"
+)
+
+func verifyStore(st store.Store, interval int) error {
+	return Verify(tState1.genDoc, st, store.MockingStore(), interval)
+}
+
+func corruptedHeight(t *testing.T, err error) int {
+	require.Error(t, err)
+	cErr, ok := err.(*CorruptionError)
+	require.True(t, ok, err.Error())
+	return cErr.Height
+}
+
+func TestVerify(t *testing.T) {
+	setup(t)
+
+	_, pub, _ := crypto.GenerateTestKeyPair()
+	trx := tx.NewBondTx(crypto.UndefHash, 1, tValSigner1.Address(), pub, 8888000, 8888, "")
+	tValSigner1.SignMsg(trx)
+	assert.NoError(t, tCommonTxPool.AppendTx(trx))
+
+	for i := 0; i < 8; i++ {
+		moveToNextHeightForAllStates(t)
+	}
+	st := tState1.store.(*store.MockStore)
+
+	assert.NoError(t, verifyStore(st, 0))
+	assert.NoError(t, verifyStore(st, 1))
+
+	t.Run("Empty store", func(t *testing.T) {
+		err := verifyStore(store.MockingStore(), 1)
+		assert.Error(t, err)
+		_, ok := err.(*CorruptionError)
+		assert.False(t, ok)
+	})
+
+	t.Run("Missing transaction", func(t *testing.T) {
+		id := st.Blocks[5].TxIDs().IDs()[0]
+		trx := st.Transactions[id]
+		delete(st.Transactions, id)
+		assert.Equal(t, corruptedHeight(t, verifyStore(st, 0)), 5)
+		st.Transactions[id] = trx
+	})
+
+	t.Run("Missing block", func(t *testing.T) {
+		b := st.Blocks[4]
+		delete(st.Blocks, 4)
+		// Last info points to a higher block
+		assert.Equal(t, corruptedHeight(t, verifyStore(st, 0)), 3)
+		st.Blocks[4] = b
+	})
+
+	t.Run("Not linked block", func(t *testing.T) {
+		b := st.Blocks[6]
+		st.Blocks[6], _ = block.GenerateTestBlock(nil, nil)
+		assert.Equal(t, corruptedHeight(t, verifyStore(st, 0)), 5)
+		st.Blocks[6] = b
+	})
+
+	t.Run("Invalid certificate", func(t *testing.T) {
+		b := st.Blocks[3]
+		cert := makeCertificateAndSign(t, b.Hash(), 0, tValSigner1)
+		c := tState1.LastCertificate()
+		tState1.lastInfo.SetCertificate(cert)
+		tState1.lastInfo.SaveLastInfo()
+		assert.Equal(t, corruptedHeight(t, verifyStore(st, 0)), 8)
+		tState1.lastInfo.SetCertificate(c)
+		tState1.lastInfo.SaveLastInfo()
+	})
+
+	t.Run("Modified account", func(t *testing.T) {
+		acc, _ := st.Account(crypto.TreasuryAddress)
+		acc.AddToBalance(1)
+		st.UpdateAccount(acc)
+		assert.Equal(t, corruptedHeight(t, verifyStore(st, 0)), 8)
+		acc.SubtractFromBalance(1)
+		st.UpdateAccount(acc)
+	})
+
+	assert.NoError(t, verifyStore(st, 3))
+}
+
+func TestVerifyOnStartup(t *testing.T) {
+	setup(t)
+
+	st1, err := store.NewStore(store.TestConfig())
+	require.NoError(t, err)
+	st, err := LoadOrNewState(TestConfig(), tState1.genDoc, tValSigner1, st1, tCommonTxPool)
+	require.NoError(t, err)
+	for i := 0; i < 4; i++ {
+		b, c := makeBlockAndCertificate(t, 0, tValSigner1, tValSigner2, tValSigner3, tValSigner4)
+		require.NoError(t, st.CommitBlock(i+1, b, c))
+		CommitBlockForAllStates(t, b, c)
+	}
+
+	conf := TestConfig()
+	conf.VerifyOnStartup = true
+	st2, err := LoadOrNewState(conf, tState1.genDoc, tValSigner1, st1, tCommonTxPool)
+	require.NoError(t, err)
+
+	// The node keeps committing blocks while the store is being verified
+	b, c := makeBlockAndCertificate(t, 0, tValSigner1, tValSigner2, tValSigner3, tValSigner4)
+	assert.NoError(t, st2.CommitBlock(5, b, c))
+
+	// Closing waits for the verification
+	assert.NoError(t, st2.Close())
+}
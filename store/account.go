@@ -1,20 +1,19 @@
 package store
 
 import (
-	"github.com/syndtr/goleveldb/leveldb"
-	"github.com/syndtr/goleveldb/leveldb/util"
 	"github.com/zarbchain/zarb-go/account"
 	"github.com/zarbchain/zarb-go/crypto"
+	"github.com/zarbchain/zarb-go/store/kv"
 )
 
 type accountStore struct {
-	db    *leveldb.DB
+	db    kv.DB
 	total int
 }
 
 func accountKey(addr crypto.Address) []byte { return append(accountPrefix, addr.RawBytes()...) }
 
-func newAccountStore(db *leveldb.DB) *accountStore {
+func newAccountStore(db kv.DB) *accountStore {
 	as := &accountStore{
 		db: db,
 	}
@@ -29,7 +28,7 @@ func newAccountStore(db *leveldb.DB) *accountStore {
 }
 
 func (as *accountStore) hasAccount(addr crypto.Address) bool {
-	has, err := as.db.Has(accountKey(addr), nil)
+	has, err := as.db.Has(accountKey(addr))
 	if err != nil {
 		return false
 	}
@@ -51,8 +50,9 @@ func (as *accountStore) account(addr crypto.Address) (*account.Account, error) {
 }
 
 func (as *accountStore) iterateAccounts(consumer func(*account.Account) (stop bool)) {
-	r := util.BytesPrefix(accountPrefix)
-	iter := as.db.NewIterator(r, nil)
+	iter := as.db.NewIterator(accountPrefix)
+	defer iter.Release()
+
 	for iter.Next() {
 		//key := iter.Key()
 		value := iter.Value()
@@ -66,12 +66,10 @@ func (as *accountStore) iterateAccounts(consumer func(*account.Account) (stop bo
 		if stopped {
 			return
 		}
-
 	}
-	iter.Release()
 }
 
-func (as *accountStore) updateAccount(batch *leveldb.Batch, acc *account.Account) error {
+func (as *accountStore) updateAccount(batch kv.Batch, acc *account.Account) error {
 	data, err := acc.Encode()
 	if err != nil {
 		return err
@@ -31,6 +31,7 @@ func TestAccountCounter(t *testing.T) {
 func TestAccountBatchSaving(t *testing.T) {
 
 	conf := TestConfig()
+	conf.Engine = EngineLevelDB // reopening needs a persistent database
 	store, _ := NewStore(conf)
 
 	t.Run("Add 100 accounts", func(t *testing.T) {
@@ -1,10 +1,9 @@
 package store
 
 import (
-	"github.com/syndtr/goleveldb/leveldb"
-	dbutil "github.com/syndtr/goleveldb/leveldb/util"
 	"github.com/zarbchain/zarb-go/block"
 	"github.com/zarbchain/zarb-go/crypto"
+	"github.com/zarbchain/zarb-go/store/kv"
 	"github.com/zarbchain/zarb-go/util"
 )
 
@@ -12,16 +11,16 @@ func blockKey(height int) []byte           { return append(blockPrefix, util.Int
 func blockHashKey(hash crypto.Hash) []byte { return append(blockHashPrefix, hash.RawBytes()...) }
 
 type blockStore struct {
-	db *leveldb.DB
+	db kv.DB
 }
 
-func newBlockStore(db *leveldb.DB) *blockStore {
+func newBlockStore(db kv.DB) *blockStore {
 	return &blockStore{
 		db: db,
 	}
 }
 
-func (bs *blockStore) saveBlock(batch *leveldb.Batch, height int, block *block.Block) error {
+func (bs *blockStore) saveBlock(batch kv.Batch, height int, block *block.Block) error {
 	blockData, err := block.Encode()
 	if err != nil {
 		return err
@@ -59,6 +58,8 @@ func (bs *blockStore) blockHeight(hash crypto.Hash) (int, error) {
 }
 
 func (bs *blockStore) hasAnyBlock() bool {
-	iter := bs.db.NewIterator(dbutil.BytesPrefix(blockHashPrefix), nil)
-	return iter.First()
+	iter := bs.db.NewIterator(blockHashPrefix)
+	defer iter.Release()
+
+	return iter.Next()
 }
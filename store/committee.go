@@ -0,0 +1,49 @@
+package store
+
+import (
+	"github.com/fxamacker/cbor/v2"
+	"github.com/zarbchain/zarb-go/store/kv"
+	"github.com/zarbchain/zarb-go/util"
+)
+
+func committeeChangeKey(height int) []byte {
+	return append(committeeChangePrefix, util.IntToSlice(height)...)
+}
+
+// CommitteeChange keeps the numbers of the validators who joined or left the committee after committing a block.
+// It is saved only for the heights that the committee has changed.
+type CommitteeChange struct {
+	Joined []int `cbor:"1,keyasint"`
+	Left   []int `cbor:"2,keyasint"`
+}
+
+type committeeStore struct {
+	db kv.DB
+}
+
+func newCommitteeStore(db kv.DB) *committeeStore {
+	return &committeeStore{
+		db: db,
+	}
+}
+
+func (cs *committeeStore) saveCommitteeChange(batch kv.Batch, height int, change *CommitteeChange) error {
+	data, err := cbor.Marshal(change)
+	if err != nil {
+		return err
+	}
+	batch.Put(committeeChangeKey(height), data)
+	return nil
+}
+
+func (cs *committeeStore) committeeChange(height int) (*CommitteeChange, error) {
+	data, err := tryGet(cs.db, committeeChangeKey(height))
+	if err != nil {
+		return nil, err
+	}
+	change := new(CommitteeChange)
+	if err := cbor.Unmarshal(data, change); err != nil {
+		return nil, err
+	}
+	return change, nil
+}
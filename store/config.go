@@ -5,19 +5,31 @@ import (
 	"github.com/zarbchain/zarb-go/util"
 )
 
+const (
+	EngineLevelDB = "leveldb"
+	EngineBadger  = "badger"
+	EngineMemory  = "memory"
+)
+
 type Config struct {
-	Path string `toml:"" comment:"Path contains database directory. Default is ./store.db"`
+	Path          string `toml:"" comment:"Path contains database directory. Default is ./store.db"`
+	Engine        string `toml:"" comment:"Engine is the database engine: leveldb, badger or memory. Memory engine doesn't persist data. Default is leveldb"`
+	RollbackDepth int    `toml:"" comment:"RollbackDepth is the number of the last blocks that can be rolled back. The undo data of the older blocks are removed. Default is 10000"`
 }
 
 func DefaultConfig() *Config {
 	return &Config{
-		Path: "data",
+		Path:          "data",
+		Engine:        EngineLevelDB,
+		RollbackDepth: 10000,
 	}
 }
 
 func TestConfig() *Config {
 	return &Config{
-		Path: util.TempDirPath(),
+		Path:          util.TempDirPath(),
+		Engine:        EngineMemory,
+		RollbackDepth: 10,
 	}
 }
 
@@ -30,5 +42,13 @@ func (conf *Config) SanityCheck() error {
 	if !util.IsValidDirPath(conf.Path) {
 		return errors.Errorf(errors.ErrInvalidConfig, "path is not valid")
 	}
+	switch conf.Engine {
+	case EngineLevelDB, EngineBadger, EngineMemory:
+	default:
+		return errors.Errorf(errors.ErrInvalidConfig, "unknown database engine: %v", conf.Engine)
+	}
+	if conf.RollbackDepth < 1 {
+		return errors.Errorf(errors.ErrInvalidConfig, "RollbackDepth should be positive")
+	}
 	return nil
 }
@@ -13,3 +13,31 @@ func TestDefaultConfigCheck(t *testing.T) {
 	c.Path = "/tmp/zarb"
 	assert.NoError(t, c.SanityCheck())
 }
+
+func TestInvalidEngine(t *testing.T) {
+	c := TestConfig()
+	c.Engine = "unknown"
+	assert.Error(t, c.SanityCheck())
+
+	_, err := NewStore(c)
+	assert.Error(t, err)
+}
+
+func TestInvalidRollbackDepth(t *testing.T) {
+	c := TestConfig()
+	c.RollbackDepth = 0
+	assert.Error(t, c.SanityCheck())
+}
+
+func TestEngines(t *testing.T) {
+	for _, engine := range []string{EngineLevelDB, EngineBadger, EngineMemory} {
+		c := TestConfig()
+		c.Engine = engine
+		assert.NoError(t, c.SanityCheck())
+
+		s, err := NewStore(c)
+		assert.NoError(t, err)
+		assert.False(t, s.HasAnyBlock())
+		assert.NoError(t, s.Close())
+	}
+}
@@ -22,7 +22,9 @@ type Reader interface {
 	IterateValidators(consumer func(*validator.Validator) (stop bool))
 	IterateAccounts(consumer func(*account.Account) (stop bool))
 	TotalValidators() int
+	CommitteeChange(height int) (*CommitteeChange, error)
 	RestoreLastInfo() []byte
+	RestoredHeight() int
 }
 
 type Store interface {
@@ -32,7 +34,11 @@ type Store interface {
 	UpdateValidator(acc *validator.Validator)
 	SaveBlock(height int, block *block.Block)
 	SaveTransaction(trx *tx.Tx)
+	SaveCommitteeChange(height int, change *CommitteeChange)
 	SaveLastInfo(info []byte)
+	SaveRestoredHeight(height int)
 	WriteBatch() error
+	Rollback(height int, lastInfo []byte, verifyFn func(accs []*account.Account, vals []*validator.Validator) error) error
+	View() (Store, error)
 	Close() error
 }
@@ -0,0 +1,215 @@
+package kv
+
+import (
+	"github.com/dgraph-io/badger/v2"
+	"github.com/zarbchain/zarb-go/errors"
+)
+
+type badgerDB struct {
+	db *badger.DB
+}
+
+type badgerOp struct {
+	key    []byte
+	value  []byte
+	delete bool
+}
+
+type badgerBatch struct {
+	ops []badgerOp
+}
+
+type badgerIterator struct {
+	// txn is discarded on release, if the iterator owns it
+	txn     *badger.Txn
+	ownTxn  bool
+	iter    *badger.Iterator
+	prefix  []byte
+	started bool
+	err     error
+}
+
+// NewBadgerDB opens or creates a Badger database in the given path
+func NewBadgerDB(path string) (DB, error) {
+	opts := badger.DefaultOptions(path).WithLogger(nil)
+	db, err := badger.Open(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &badgerDB{db: db}, nil
+}
+
+func (b *badgerDB) Get(key []byte) ([]byte, error) {
+	var data []byte
+	err := b.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(key)
+		if err != nil {
+			return err
+		}
+		data, err = item.ValueCopy(nil)
+		return err
+	})
+	if err == badger.ErrKeyNotFound {
+		return nil, ErrNotFound
+	}
+	return data, err
+}
+
+func (b *badgerDB) Has(key []byte) (bool, error) {
+	_, err := b.Get(key)
+	if err == ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (b *badgerDB) NewBatch() Batch {
+	return &badgerBatch{}
+}
+
+func (b *badgerDB) Write(batch Batch) error {
+	// All the changes are applied in one transaction, so the batch is atomic.
+	// A batch bigger than a single transaction is refused, not split.
+	err := b.db.Update(func(txn *badger.Txn) error {
+		for _, op := range batch.(*badgerBatch).ops {
+			var err error
+			if op.delete {
+				err = txn.Delete(op.key)
+			} else {
+				err = txn.Set(op.key, op.value)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err == badger.ErrTxnTooBig {
+		return errors.Errorf(errors.ErrGeneric, "batch is too big for one transaction: %d operations", batch.Len())
+	}
+	return err
+}
+
+func (b *badgerDB) NewIterator(prefix []byte) Iterator {
+	return newBadgerIterator(b.db.NewTransaction(false), true, prefix)
+}
+
+func (b *badgerDB) NewSnapshot() (DB, error) {
+	return &badgerSnapshot{txn: b.db.NewTransaction(false)}, nil
+}
+
+func (b *badgerDB) Close() error {
+	return b.db.Close()
+}
+
+func newBadgerIterator(txn *badger.Txn, ownTxn bool, prefix []byte) *badgerIterator {
+	opts := badger.DefaultIteratorOptions
+	opts.Prefix = prefix
+	return &badgerIterator{
+		txn:    txn,
+		ownTxn: ownTxn,
+		iter:   txn.NewIterator(opts),
+		prefix: prefix,
+	}
+}
+
+// badgerSnapshot keeps a read-only transaction open
+type badgerSnapshot struct {
+	txn *badger.Txn
+}
+
+func (s *badgerSnapshot) Get(key []byte) ([]byte, error) {
+	item, err := s.txn.Get(key)
+	if err == badger.ErrKeyNotFound {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return item.ValueCopy(nil)
+}
+
+func (s *badgerSnapshot) Has(key []byte) (bool, error) {
+	_, err := s.Get(key)
+	if err == ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *badgerSnapshot) NewBatch() Batch {
+	return &badgerBatch{}
+}
+
+func (s *badgerSnapshot) Write(batch Batch) error {
+	return ErrReadOnly
+}
+
+func (s *badgerSnapshot) NewIterator(prefix []byte) Iterator {
+	return newBadgerIterator(s.txn, false, prefix)
+}
+
+func (s *badgerSnapshot) NewSnapshot() (DB, error) {
+	return nil, ErrReadOnly
+}
+
+func (s *badgerSnapshot) Close() error {
+	s.txn.Discard()
+	return nil
+}
+
+func (b *badgerBatch) Put(key, value []byte) {
+	b.ops = append(b.ops, badgerOp{
+		key:   append([]byte{}, key...),
+		value: append([]byte{}, value...),
+	})
+}
+
+func (b *badgerBatch) Delete(key []byte) {
+	b.ops = append(b.ops, badgerOp{
+		key:    append([]byte{}, key...),
+		delete: true,
+	})
+}
+
+func (b *badgerBatch) Len() int { return len(b.ops) }
+func (b *badgerBatch) Reset()   { b.ops = b.ops[:0] }
+
+func (i *badgerIterator) Next() bool {
+	if !i.started {
+		i.iter.Seek(i.prefix)
+		i.started = true
+	} else {
+		i.iter.Next()
+	}
+	return i.iter.ValidForPrefix(i.prefix)
+}
+
+func (i *badgerIterator) Key() []byte {
+	return i.iter.Item().KeyCopy(nil)
+}
+
+func (i *badgerIterator) Value() []byte {
+	data, err := i.iter.Item().ValueCopy(nil)
+	if err != nil {
+		i.err = err
+	}
+	return data
+}
+
+func (i *badgerIterator) Error() error {
+	return i.err
+}
+
+func (i *badgerIterator) Release() {
+	i.iter.Close()
+	if i.ownTxn {
+		i.txn.Discard()
+	}
+}
@@ -0,0 +1,43 @@
+package kv
+
+import (
+	"github.com/zarbchain/zarb-go/errors"
+)
+
+// ErrNotFound is returned when the key doesn't exist in database
+var ErrNotFound = errors.Errorf(errors.ErrGeneric, "key not found")
+
+// ErrReadOnly is returned when writing into a snapshot of the database
+var ErrReadOnly = errors.Errorf(errors.ErrGeneric, "database is read-only")
+
+// DB is a key-value database that the store is built on top of it
+type DB interface {
+	Get(key []byte) ([]byte, error)
+	Has(key []byte) (bool, error)
+	NewBatch() Batch
+	Write(batch Batch) error
+	// NewIterator returns an iterator over the keys with the given prefix, in ascending order
+	NewIterator(prefix []byte) Iterator
+	// NewSnapshot returns a read-only view of the database at this moment.
+	// Later writes are not visible in the snapshot. It should be closed after use.
+	NewSnapshot() (DB, error)
+	Close() error
+}
+
+// Batch keeps the changes in memory, until it is written to the database
+type Batch interface {
+	Put(key, value []byte)
+	Delete(key []byte)
+	Len() int
+	Reset()
+}
+
+// Iterator should be released after use.
+// Calling Next for the first time moves the iterator to the first key.
+type Iterator interface {
+	Next() bool
+	Key() []byte
+	Value() []byte
+	Error() error
+	Release()
+}
@@ -0,0 +1,146 @@
+package kv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zarbchain/zarb-go/util"
+)
+
+func testBackends(t *testing.T) map[string]DB {
+	level, err := NewLevelDB(util.TempDirPath())
+	require.NoError(t, err)
+	badger, err := NewBadgerDB(util.TempDirPath())
+	require.NoError(t, err)
+
+	return map[string]DB{
+		"leveldb": level,
+		"badger":  badger,
+		"memory":  NewMemoryDB(),
+	}
+}
+
+func TestGetAndPut(t *testing.T) {
+	for name, db := range testBackends(t) {
+		t.Run(name, func(t *testing.T) {
+			_, err := db.Get([]byte("key"))
+			assert.Equal(t, err, ErrNotFound)
+			has, err := db.Has([]byte("key"))
+			assert.NoError(t, err)
+			assert.False(t, has)
+
+			batch := db.NewBatch()
+			batch.Put([]byte("key"), []byte("value"))
+			assert.Equal(t, batch.Len(), 1)
+
+			// Not written yet
+			_, err = db.Get([]byte("key"))
+			assert.Error(t, err)
+
+			assert.NoError(t, db.Write(batch))
+			data, err := db.Get([]byte("key"))
+			assert.NoError(t, err)
+			assert.Equal(t, data, []byte("value"))
+			has, err = db.Has([]byte("key"))
+			assert.NoError(t, err)
+			assert.True(t, has)
+
+			batch.Reset()
+			assert.Zero(t, batch.Len())
+			batch.Delete([]byte("key"))
+			assert.NoError(t, db.Write(batch))
+			_, err = db.Get([]byte("key"))
+			assert.Equal(t, err, ErrNotFound)
+
+			assert.NoError(t, db.Close())
+		})
+	}
+}
+
+func TestIterator(t *testing.T) {
+	for name, db := range testBackends(t) {
+		t.Run(name, func(t *testing.T) {
+			batch := db.NewBatch()
+			batch.Put([]byte{1, 3}, []byte("c"))
+			batch.Put([]byte{1, 1}, []byte("a"))
+			batch.Put([]byte{2, 1}, []byte("x"))
+			batch.Put([]byte{1, 2}, []byte("b"))
+			batch.Put([]byte{0, 1}, []byte("y"))
+			assert.NoError(t, db.Write(batch))
+
+			values := []string{}
+			iter := db.NewIterator([]byte{1})
+			for iter.Next() {
+				assert.Equal(t, iter.Key()[0], byte(1))
+				values = append(values, string(iter.Value()))
+			}
+			assert.NoError(t, iter.Error())
+			iter.Release()
+			assert.Equal(t, values, []string{"a", "b", "c"})
+
+			iter = db.NewIterator([]byte{5})
+			assert.False(t, iter.Next())
+			iter.Release()
+
+			assert.NoError(t, db.Close())
+		})
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	for name, db := range testBackends(t) {
+		t.Run(name, func(t *testing.T) {
+			batch := db.NewBatch()
+			batch.Put([]byte{1, 1}, []byte("a"))
+			assert.NoError(t, db.Write(batch))
+
+			snap, err := db.NewSnapshot()
+			assert.NoError(t, err)
+
+			batch.Reset()
+			batch.Put([]byte{1, 1}, []byte("b"))
+			batch.Put([]byte{1, 2}, []byte("c"))
+			assert.NoError(t, db.Write(batch))
+
+			// Later writes are not visible in the snapshot
+			data, err := snap.Get([]byte{1, 1})
+			assert.NoError(t, err)
+			assert.Equal(t, data, []byte("a"))
+			has, err := snap.Has([]byte{1, 2})
+			assert.NoError(t, err)
+			assert.False(t, has)
+
+			values := []string{}
+			iter := snap.NewIterator([]byte{1})
+			for iter.Next() {
+				values = append(values, string(iter.Value()))
+			}
+			iter.Release()
+			assert.Equal(t, values, []string{"a"})
+
+			assert.Equal(t, snap.Write(snap.NewBatch()), ErrReadOnly)
+			assert.NoError(t, snap.Close())
+			assert.NoError(t, db.Close())
+		})
+	}
+}
+
+func TestBadgerBatchTooBig(t *testing.T) {
+	db, err := NewBadgerDB(util.TempDirPath())
+	require.NoError(t, err)
+
+	batch := db.NewBatch()
+	value := make([]byte, 512)
+	for i := 0; i < 32*1024; i++ {
+		batch.Put(util.IntToSlice(i), value)
+	}
+	assert.Error(t, db.Write(batch))
+
+	// Nothing should be written
+	has, err := db.Has(util.IntToSlice(0))
+	assert.NoError(t, err)
+	assert.False(t, has)
+
+	assert.NoError(t, db.Close())
+}
@@ -0,0 +1,113 @@
+package kv
+
+import (
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/storage"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+type levelDB struct {
+	db *leveldb.DB
+}
+
+type levelDBBatch struct {
+	batch *leveldb.Batch
+}
+
+// NewLevelDB opens or creates a LevelDB database in the given path
+func NewLevelDB(path string) (DB, error) {
+	db, err := leveldb.OpenFile(path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &levelDB{db: db}, nil
+}
+
+// NewMemoryDB creates an in-memory database. Data will be lost after closing the database.
+// It is useful for testing and ephemeral nodes.
+func NewMemoryDB() DB {
+	db, err := leveldb.Open(storage.NewMemStorage(), nil)
+	if err != nil {
+		// Opening an empty memory storage should never fail
+		panic(err)
+	}
+	return &levelDB{db: db}
+}
+
+func (l *levelDB) Get(key []byte) ([]byte, error) {
+	data, err := l.db.Get(key, nil)
+	if err == leveldb.ErrNotFound {
+		return nil, ErrNotFound
+	}
+	return data, err
+}
+
+func (l *levelDB) Has(key []byte) (bool, error) {
+	return l.db.Has(key, nil)
+}
+
+func (l *levelDB) NewBatch() Batch {
+	return &levelDBBatch{batch: new(leveldb.Batch)}
+}
+
+func (l *levelDB) Write(batch Batch) error {
+	return l.db.Write(batch.(*levelDBBatch).batch, nil)
+}
+
+func (l *levelDB) NewIterator(prefix []byte) Iterator {
+	return l.db.NewIterator(util.BytesPrefix(prefix), nil)
+}
+
+func (l *levelDB) NewSnapshot() (DB, error) {
+	snap, err := l.db.GetSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	return &levelDBSnapshot{snap: snap}, nil
+}
+
+func (l *levelDB) Close() error {
+	return l.db.Close()
+}
+
+type levelDBSnapshot struct {
+	snap *leveldb.Snapshot
+}
+
+func (s *levelDBSnapshot) Get(key []byte) ([]byte, error) {
+	data, err := s.snap.Get(key, nil)
+	if err == leveldb.ErrNotFound {
+		return nil, ErrNotFound
+	}
+	return data, err
+}
+
+func (s *levelDBSnapshot) Has(key []byte) (bool, error) {
+	return s.snap.Has(key, nil)
+}
+
+func (s *levelDBSnapshot) NewBatch() Batch {
+	return &levelDBBatch{batch: new(leveldb.Batch)}
+}
+
+func (s *levelDBSnapshot) Write(batch Batch) error {
+	return ErrReadOnly
+}
+
+func (s *levelDBSnapshot) NewIterator(prefix []byte) Iterator {
+	return s.snap.NewIterator(util.BytesPrefix(prefix), nil)
+}
+
+func (s *levelDBSnapshot) NewSnapshot() (DB, error) {
+	return nil, ErrReadOnly
+}
+
+func (s *levelDBSnapshot) Close() error {
+	s.snap.Release()
+	return nil
+}
+
+func (b *levelDBBatch) Put(key, value []byte) { b.batch.Put(key, value) }
+func (b *levelDBBatch) Delete(key []byte)     { b.batch.Delete(key) }
+func (b *levelDBBatch) Len() int              { return b.batch.Len() }
+func (b *levelDBBatch) Reset()                { b.batch.Reset() }
@@ -0,0 +1,145 @@
+package store
+
+import (
+	"github.com/zarbchain/zarb-go/errors"
+	"github.com/zarbchain/zarb-go/store/kv"
+	"github.com/zarbchain/zarb-go/util"
+)
+
+// SchemaVersion is the current version of the database schema.
+// Any change in the stored data should increase the version and add a migration for it.
+const SchemaVersion = 2
+
+// legacySchemaVersion is the version of the databases that were created
+// before keeping the schema version
+const legacySchemaVersion = 1
+
+// migration upgrades the database from version `from` to the next version
+type migration struct {
+	from        int
+	description string
+	// automatic migrations don't change the data and they run when the store is opened
+	automatic bool
+	migrate   func(db kv.DB) error
+}
+
+// migrations should be sorted by their version, one for each version
+var migrations = []migration{
+	{
+		from:        1,
+		description: "Keeping the schema version",
+		automatic:   true,
+		// Nothing to change, the version is saved after running the migration
+		migrate: func(db kv.DB) error { return nil },
+	},
+}
+
+// schemaVersion returns the version of the database schema.
+// An empty database has no version and zero is returned.
+func schemaVersion(db kv.DB) (int, error) {
+	data, err := db.Get(versionKey)
+	if err == nil {
+		return util.SliceToInt(data), nil
+	}
+	if err != kv.ErrNotFound {
+		return 0, err
+	}
+
+	iter := db.NewIterator(nil)
+	defer iter.Release()
+	if iter.Next() {
+		return legacySchemaVersion, nil
+	}
+	return 0, iter.Error()
+}
+
+func saveSchemaVersion(db kv.DB, version int) error {
+	batch := db.NewBatch()
+	batch.Put(versionKey, util.IntToSlice(version))
+	return db.Write(batch)
+}
+
+// isAutomatic returns true if all the migrations from this version are automatic
+func isAutomatic(version int) bool {
+	for _, m := range migrations {
+		if m.from >= version && !m.automatic {
+			return false
+		}
+	}
+	return true
+}
+
+// checkSchemaVersion makes sure the database schema is up to date.
+// The current schema version is saved in an empty database,
+// and outdated databases are upgraded if their migrations are automatic.
+func checkSchemaVersion(db kv.DB) error {
+	version, err := schemaVersion(db)
+	if err != nil {
+		return err
+	}
+	switch {
+	case version == 0:
+		return saveSchemaVersion(db, SchemaVersion)
+	case version > SchemaVersion:
+		return errors.Errorf(errors.ErrGeneric,
+			"database schema version %v is newer than the supported version %v. Please upgrade the node", version, SchemaVersion)
+	case version < SchemaVersion && isAutomatic(version):
+		return migrate(db, version, nil)
+	case version < SchemaVersion:
+		return errors.Errorf(errors.ErrGeneric,
+			"database schema version %v is outdated. Please run `zarb store migrate` to upgrade it to version %v", version, SchemaVersion)
+	}
+	return nil
+}
+
+// Migrate upgrades the database schema to the current version, step by step.
+// The schema version is saved after each step, so an interrupted migration can be resumed.
+// The `progress` function is called before running each step.
+func Migrate(conf *Config, progress func(from, to int, description string)) error {
+	db, err := openDB(conf)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	version, err := schemaVersion(db)
+	if err != nil {
+		return err
+	}
+	if version == 0 {
+		return errors.Errorf(errors.ErrGeneric, "database is empty")
+	}
+	if version > SchemaVersion {
+		return errors.Errorf(errors.ErrGeneric,
+			"database schema version %v is newer than the supported version %v", version, SchemaVersion)
+	}
+
+	return migrate(db, version, progress)
+}
+
+// migrate runs the migrations from this version, step by step
+func migrate(db kv.DB, version int, progress func(from, to int, description string)) error {
+	for _, m := range migrations {
+		if m.from < version {
+			continue
+		}
+		if m.from != version {
+			return errors.Errorf(errors.ErrGeneric, "no migration for schema version %v", version)
+		}
+		if progress != nil {
+			progress(m.from, m.from+1, m.description)
+		}
+		if err := m.migrate(db); err != nil {
+			return errors.Errorf(errors.ErrGeneric, "migration from version %v failed: %v", m.from, err)
+		}
+		version = m.from + 1
+		if err := saveSchemaVersion(db, version); err != nil {
+			return err
+		}
+	}
+
+	if version != SchemaVersion {
+		return errors.Errorf(errors.ErrGeneric, "no migration for schema version %v", version)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zarbchain/zarb-go/account"
+	"github.com/zarbchain/zarb-go/store/kv"
+)
+
+func persistentConfig() *Config {
+	conf := TestConfig()
+	conf.Engine = EngineLevelDB
+	return conf
+}
+
+func openRawDB(t *testing.T, conf *Config) kv.DB {
+	db, err := openDB(conf)
+	require.NoError(t, err)
+	return db
+}
+
+func TestSchemaVersion(t *testing.T) {
+	conf := persistentConfig()
+
+	s, err := NewStore(conf)
+	require.NoError(t, err)
+	version, err := schemaVersion(s.(*store).db)
+	assert.NoError(t, err)
+	assert.Equal(t, version, SchemaVersion)
+	assert.NoError(t, s.Close())
+
+	// Reopening the store
+	s, err = NewStore(conf)
+	require.NoError(t, err)
+	assert.NoError(t, s.Close())
+
+	t.Run("Database is too new", func(t *testing.T) {
+		db := openRawDB(t, conf)
+		assert.NoError(t, saveSchemaVersion(db, SchemaVersion+1))
+		assert.NoError(t, db.Close())
+
+		_, err := NewStore(conf)
+		assert.Error(t, err)
+		assert.Error(t, Migrate(conf, nil))
+	})
+}
+
+// makeLegacyDB makes a legacy database without schema version
+func makeLegacyDB(t *testing.T, conf *Config) *account.Account {
+	db := openRawDB(t, conf)
+	acc, _ := account.GenerateTestAccount(0)
+	accStore := newAccountStore(db)
+	batch := db.NewBatch()
+	accStore.updateAccount(batch, acc)
+	require.NoError(t, db.Write(batch))
+	version, err := schemaVersion(db)
+	assert.NoError(t, err)
+	assert.Equal(t, version, legacySchemaVersion)
+	assert.NoError(t, db.Close())
+
+	return acc
+}
+
+func TestMigrate(t *testing.T) {
+	conf := persistentConfig()
+
+	t.Run("Empty database", func(t *testing.T) {
+		assert.Error(t, Migrate(conf, nil))
+	})
+
+	acc := makeLegacyDB(t, conf)
+
+	steps := 0
+	assert.NoError(t, Migrate(conf, func(from, to int, description string) {
+		assert.Equal(t, from+1, to)
+		steps++
+	}))
+	assert.Equal(t, steps, SchemaVersion-legacySchemaVersion)
+
+	s, err := NewStore(conf)
+	require.NoError(t, err)
+	assert.True(t, s.HasAccount(acc.Address()))
+
+	// Nothing to migrate
+	assert.NoError(t, s.Close())
+	steps = 0
+	assert.NoError(t, Migrate(conf, func(from, to int, description string) { steps++ }))
+	assert.Zero(t, steps)
+}
+
+func TestAutomaticMigration(t *testing.T) {
+	conf := persistentConfig()
+	acc := makeLegacyDB(t, conf)
+
+	// Keeping the schema version doesn't change the data, so the legacy database is upgraded automatically
+	assert.True(t, isAutomatic(legacySchemaVersion))
+	s, err := NewStore(conf)
+	require.NoError(t, err)
+	assert.True(t, s.HasAccount(acc.Address()))
+	version, err := schemaVersion(s.(*store).db)
+	assert.NoError(t, err)
+	assert.Equal(t, version, SchemaVersion)
+	assert.NoError(t, s.Close())
+}
@@ -16,6 +16,8 @@ type MockStore struct {
 	Validators   map[crypto.Address]validator.Validator
 	Transactions map[crypto.Hash]tx.Tx
 	LastInfo     []byte
+	Changes      map[int]CommitteeChange
+	Restored     int
 }
 
 func MockingStore() *MockStore {
@@ -24,6 +26,7 @@ func MockingStore() *MockStore {
 		Accounts:     make(map[crypto.Address]account.Account),
 		Validators:   make(map[crypto.Address]validator.Validator),
 		Transactions: make(map[crypto.Hash]tx.Tx),
+		Changes:      make(map[int]CommitteeChange),
 	}
 }
 func (m *MockStore) Block(height int) (*block.Block, error) {
@@ -91,7 +94,13 @@ func (m *MockStore) TotalValidators() int {
 	return len(m.Validators)
 }
 func (m *MockStore) LastBlockHeight() int {
-	return len(m.Blocks)
+	height := 0
+	for h := range m.Blocks {
+		if h > height {
+			height = h
+		}
+	}
+	return height
 }
 
 func (m *MockStore) Close() error {
@@ -130,12 +139,79 @@ func (m *MockStore) SaveTransaction(trx *tx.Tx) {
 	m.Transactions[trx.ID()] = *trx
 }
 
+func (m *MockStore) SaveCommitteeChange(height int, change *CommitteeChange) {
+	m.Changes[height] = *change
+}
+func (m *MockStore) CommitteeChange(height int) (*CommitteeChange, error) {
+	c, ok := m.Changes[height]
+	if ok {
+		return &c, nil
+	}
+	return nil, fmt.Errorf("not found")
+}
+
 func (m *MockStore) SaveLastInfo(info []byte) {
 	m.LastInfo = info
 }
 func (m *MockStore) RestoreLastInfo() []byte {
 	return m.LastInfo
 }
+func (m *MockStore) SaveRestoredHeight(height int) {
+	m.Restored = height
+}
+func (m *MockStore) RestoredHeight() int {
+	return m.Restored
+}
+func (m *MockStore) View() (Store, error) {
+	view := MockingStore()
+	for k, v := range m.Blocks {
+		view.Blocks[k] = v
+	}
+	for k, v := range m.Accounts {
+		view.Accounts[k] = v
+	}
+	for k, v := range m.Validators {
+		view.Validators[k] = v
+	}
+	for k, v := range m.Transactions {
+		view.Transactions[k] = v
+	}
+	for k, v := range m.Changes {
+		view.Changes[k] = v
+	}
+	view.LastInfo = m.LastInfo
+	view.Restored = m.Restored
+	return view, nil
+}
 func (m *MockStore) WriteBatch() error {
 	return nil
 }
+func (m *MockStore) Rollback(height int, lastInfo []byte, verifyFn func(accs []*account.Account, vals []*validator.Validator) error) error {
+	if height >= m.LastBlockHeight() {
+		return fmt.Errorf("no block above height %v", height)
+	}
+	accs := make([]*account.Account, 0, len(m.Accounts))
+	for _, acc := range m.Accounts {
+		acc := acc
+		accs = append(accs, &acc)
+	}
+	vals := make([]*validator.Validator, 0, len(m.Validators))
+	for _, val := range m.Validators {
+		val := val
+		vals = append(vals, &val)
+	}
+	if err := verifyFn(accs, vals); err != nil {
+		return err
+	}
+	for h, b := range m.Blocks {
+		if h > height {
+			for _, id := range b.TxIDs().IDs() {
+				delete(m.Transactions, id)
+			}
+			delete(m.Blocks, h)
+			delete(m.Changes, h)
+		}
+	}
+	m.LastInfo = lastInfo
+	return nil
+}
@@ -1,14 +1,18 @@
 package store
 
 import (
+	"sort"
+	"strings"
 	"sync"
 
-	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/zarbchain/zarb-go/account"
 	"github.com/zarbchain/zarb-go/block"
 	"github.com/zarbchain/zarb-go/crypto"
+	"github.com/zarbchain/zarb-go/errors"
 	"github.com/zarbchain/zarb-go/logger"
+	"github.com/zarbchain/zarb-go/store/kv"
 	"github.com/zarbchain/zarb-go/tx"
+	"github.com/zarbchain/zarb-go/util"
 	"github.com/zarbchain/zarb-go/validator"
 )
 
@@ -19,36 +23,92 @@ var (
 	accountPrefix   = []byte{0x05}
 	validatorPrefix = []byte{0x07}
 	txPrefix        = []byte{0x09}
+	undoPrefix      = []byte{0x0b}
+	versionKey      = []byte{0x0d}
+	restoredKey     = []byte{0x11}
+
+	committeeChangePrefix = []byte{0x0f}
+)
+
+// undoHeightNone means no block is saved in the current batch.
+// undoHeightInvalid means more than one block is saved in the current batch,
+// like restoring a snapshot, so the batch can't be rolled back.
+const (
+	undoHeightNone    = 0
+	undoHeightInvalid = -1
 )
 
 type store struct {
 	lk sync.RWMutex
 
 	config         *Config
-	db             *leveldb.DB
-	batch          *leveldb.Batch
+	db             kv.DB
+	batch          kv.Batch
 	blockStore     *blockStore
 	txStore        *txStore
 	accountStore   *accountStore
 	validatorStore *validatorStore
+	undoStore      *undoStore
+	committeeStore *committeeStore
+
+	// Keeping the previous value of accounts and validators that are updated in the current batch.
+	// It will be saved as undo data for the block in this batch.
+	undoValues map[string][]byte
+	undoHeight int
 }
 
 func NewStore(conf *Config) (Store, error) {
-
-	db, err := leveldb.OpenFile(conf.StorePath(), nil)
+	db, err := openDB(conf)
 	if err != nil {
 		return nil, err
 	}
+	if err := checkSchemaVersion(db); err != nil {
+		db.Close()
+		return nil, err
+	}
 
+	return newStore(conf, db), nil
+}
+
+func newStore(conf *Config, db kv.DB) *store {
 	return &store{
 		config:         conf,
 		db:             db,
-		batch:          new(leveldb.Batch),
+		batch:          db.NewBatch(),
 		blockStore:     newBlockStore(db),
 		txStore:        newTxStore(db),
 		accountStore:   newAccountStore(db),
 		validatorStore: newValidatorStore(db),
-	}, nil
+		undoStore:      newUndoStore(db),
+		committeeStore: newCommitteeStore(db),
+		undoValues:     make(map[string][]byte),
+	}
+}
+
+// View returns a read-only view of the store at this moment.
+// The changes after this moment are not visible in the view. It should be closed after use.
+func (s *store) View() (Store, error) {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	snap, err := s.db.NewSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	return newStore(s.config, snap), nil
+}
+
+func openDB(conf *Config) (kv.DB, error) {
+	switch conf.Engine {
+	case EngineLevelDB:
+		return kv.NewLevelDB(conf.StorePath())
+	case EngineBadger:
+		return kv.NewBadgerDB(conf.StorePath())
+	case EngineMemory:
+		return kv.NewMemoryDB(), nil
+	default:
+		return nil, errors.Errorf(errors.ErrInvalidConfig, "unknown database engine: %v", conf.Engine)
+	}
 }
 
 func (s *store) Close() error {
@@ -64,6 +124,11 @@ func (s *store) SaveBlock(height int, block *block.Block) {
 	if err := s.blockStore.saveBlock(s.batch, height, block); err != nil {
 		logger.Panic("Error on saving block: %v", err)
 	}
+	if s.undoHeight == undoHeightNone {
+		s.undoHeight = height
+	} else {
+		s.undoHeight = undoHeightInvalid
+	}
 }
 
 func (s *store) Block(height int) (*block.Block, error) {
@@ -128,6 +193,7 @@ func (s *store) UpdateAccount(acc *account.Account) {
 	s.lk.Lock()
 	defer s.lk.Unlock()
 
+	s.keepUndoValue(accountKey(acc.Address()))
 	if err := s.accountStore.updateAccount(s.batch, acc); err != nil {
 		logger.Panic("Error on updating an account: %v", err)
 	}
@@ -172,11 +238,28 @@ func (s *store) UpdateValidator(acc *validator.Validator) {
 	s.lk.Lock()
 	defer s.lk.Unlock()
 
+	s.keepUndoValue(validatorKey(acc.Address()))
 	if err := s.validatorStore.updateValidator(s.batch, acc); err != nil {
 		logger.Panic("Error on updating a validator: %v", err)
 	}
 }
 
+func (s *store) SaveCommitteeChange(height int, change *CommitteeChange) {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	if err := s.committeeStore.saveCommitteeChange(s.batch, height, change); err != nil {
+		logger.Panic("Error on saving committee change: %v", err)
+	}
+}
+
+func (s *store) CommitteeChange(height int) (*CommitteeChange, error) {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	return s.committeeStore.committeeChange(height)
+}
+
 func (s *store) HasAnyBlock() bool {
 	s.lk.Lock()
 	defer s.lk.Unlock()
@@ -192,10 +275,176 @@ func (s *store) RestoreLastInfo() []byte {
 	return info
 }
 
+// SaveRestoredHeight marks the store as restored from a snapshot at the given height
+func (s *store) SaveRestoredHeight(height int) {
+	s.batch.Put(restoredKey, util.IntToSlice(height))
+}
+
+// RestoredHeight returns the height of the snapshot that the store is restored from.
+// It returns zero if the store is not restored from a snapshot.
+func (s *store) RestoredHeight() int {
+	data, err := tryGet(s.db, restoredKey)
+	if err != nil || data == nil {
+		return 0
+	}
+	return util.SliceToInt(data)
+}
+
 func (s *store) WriteBatch() error {
-	if err := s.db.Write(s.batch, nil); err != nil {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	if s.undoHeight > 0 {
+		if err := s.undoStore.saveUndo(s.batch, s.undoHeight, s.undoEntries()); err != nil {
+			return err
+		}
+		// Blocks deeper than the rollback depth can't be rolled back
+		if pruned := s.undoHeight - s.config.RollbackDepth; pruned > 0 {
+			s.undoStore.removeUndo(s.batch, pruned)
+		}
+	}
+	if err := s.db.Write(s.batch); err != nil {
 		return err
 	}
 	s.batch.Reset()
+	s.undoValues = make(map[string][]byte)
+	s.undoHeight = undoHeightNone
 	return nil
 }
+
+// keepUndoValue keeps the value of the key before the current batch.
+func (s *store) keepUndoValue(key []byte) {
+	if _, ok := s.undoValues[string(key)]; ok {
+		return
+	}
+	value, _ := tryGet(s.db, key)
+	s.undoValues[string(key)] = value
+}
+
+func (s *store) undoEntries() []undoEntry {
+	entries := make([]undoEntry, 0, len(s.undoValues))
+	for key, value := range s.undoValues {
+		entries = append(entries, undoEntry{Key: []byte(key), Value: value})
+	}
+	sort.Slice(entries, func(i, j int) bool { return string(entries[i].Key) < string(entries[j].Key) })
+	return entries
+}
+
+// Rollback removes the blocks and transactions above the given height,
+// and reverts the accounts and validators to their state at this height.
+// verifyFn is called with the reverted accounts and validators before changing anything,
+// so the caller can verify them.
+// All the changes and the new last info are written in one batch, so a crash can't leave the store half rolled back.
+func (s *store) Rollback(height int, lastInfo []byte, verifyFn func(accs []*account.Account, vals []*validator.Validator) error) error {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	if height < 1 {
+		return errors.Errorf(errors.ErrGeneric, "invalid height: %v", height)
+	}
+	if s.batch.Len() > 0 {
+		return errors.Errorf(errors.ErrGeneric, "there are unsaved changes")
+	}
+
+	// Collecting undo data before changing anything
+	blocks := make([]*block.Block, 0)
+	undos := make([][]undoEntry, 0)
+	for h := height + 1; ; h++ {
+		b, err := s.blockStore.block(h)
+		if err != nil {
+			break
+		}
+		entries, err := s.undoStore.undo(h)
+		if err != nil {
+			return errors.Errorf(errors.ErrGeneric, "no undo data for height %v", h)
+		}
+		blocks = append(blocks, b)
+		undos = append(undos, entries)
+	}
+	if len(blocks) == 0 {
+		return errors.Errorf(errors.ErrGeneric, "no block above height %v", height)
+	}
+
+	// The earliest undo data of each key has its value at this height
+	reverted := make(map[string][]byte)
+	for i := len(undos) - 1; i >= 0; i-- {
+		for _, e := range undos[i] {
+			reverted[string(e.Key)] = e.Value
+		}
+	}
+	accs := make([]*account.Account, 0)
+	for _, data := range revertedValues(s.db, accountPrefix, reverted) {
+		acc := new(account.Account)
+		if err := acc.Decode(data); err != nil {
+			return err
+		}
+		accs = append(accs, acc)
+	}
+	vals := make([]*validator.Validator, 0)
+	for _, data := range revertedValues(s.db, validatorPrefix, reverted) {
+		val := new(validator.Validator)
+		if err := val.Decode(data); err != nil {
+			return err
+		}
+		vals = append(vals, val)
+	}
+	if err := verifyFn(accs, vals); err != nil {
+		return err
+	}
+
+	batch := s.db.NewBatch()
+	for i := len(blocks) - 1; i >= 0; i-- {
+		h := height + 1 + i
+		b := blocks[i]
+		for _, e := range undos[i] {
+			if len(e.Value) == 0 {
+				batch.Delete(e.Key)
+			} else {
+				batch.Put(e.Key, e.Value)
+			}
+		}
+		for _, id := range b.TxIDs().IDs() {
+			batch.Delete(txKey(id))
+		}
+		batch.Delete(blockKey(h))
+		batch.Delete(blockHashKey(b.Hash()))
+		batch.Delete(undoKey(h))
+		batch.Delete(committeeChangeKey(h))
+	}
+	batch.Put(infoKey, lastInfo)
+	if err := s.db.Write(batch); err != nil {
+		return err
+	}
+
+	// Reloading the counters and the map of validators
+	s.accountStore = newAccountStore(s.db)
+	s.validatorStore = newValidatorStore(s.db)
+
+	return nil
+}
+
+// revertedValues returns the values of the keys with this prefix, after reverting them.
+// An empty reverted value means the key didn't exist.
+func revertedValues(db kv.DB, prefix []byte, reverted map[string][]byte) [][]byte {
+	values := make([][]byte, 0)
+	seen := make(map[string]bool)
+	iter := db.NewIterator(prefix)
+	defer iter.Release()
+	for iter.Next() {
+		key := string(iter.Key())
+		seen[key] = true
+		value, ok := reverted[key]
+		if !ok {
+			value = append([]byte{}, iter.Value()...)
+		}
+		if len(value) > 0 {
+			values = append(values, value)
+		}
+	}
+	for key, value := range reverted {
+		if !seen[key] && len(value) > 0 && strings.HasPrefix(key, string(prefix)) {
+			values = append(values, value)
+		}
+	}
+	return values
+}
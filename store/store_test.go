@@ -222,3 +222,53 @@ func TestReestoreLastInfo(t *testing.T) {
 	assert.NoError(t, tStore.WriteBatch())
 	assert.NotNil(t, tStore.RestoreLastInfo())
 }
+
+func TestRestoredHeight(t *testing.T) {
+	setup(t)
+
+	assert.Zero(t, tStore.RestoredHeight())
+	tStore.SaveRestoredHeight(12)
+	assert.NoError(t, tStore.WriteBatch())
+	assert.Equal(t, tStore.RestoredHeight(), 12)
+}
+
+func TestView(t *testing.T) {
+	setup(t)
+
+	acc, _ := account.GenerateTestAccount(util.RandInt(10000))
+	tStore.UpdateAccount(acc)
+	assert.NoError(t, tStore.WriteBatch())
+
+	view, err := tStore.View()
+	assert.NoError(t, err)
+	defer view.Close()
+
+	acc2, _ := account.GenerateTestAccount(util.RandInt(10000))
+	tStore.UpdateAccount(acc2)
+	assert.NoError(t, tStore.WriteBatch())
+
+	assert.True(t, view.HasAccount(acc.Address()))
+	assert.False(t, view.HasAccount(acc2.Address()))
+	assert.Equal(t, view.TotalAccounts(), 1)
+	assert.Equal(t, tStore.TotalAccounts(), 2)
+
+	view.UpdateAccount(acc2)
+	assert.Error(t, view.WriteBatch())
+}
+
+func TestRetrieveCommitteeChange(t *testing.T) {
+	setup(t)
+
+	change := &CommitteeChange{Joined: []int{4, 5}, Left: []int{0, 1}}
+	tStore.SaveCommitteeChange(10, change)
+	_, err := tStore.CommitteeChange(10)
+	assert.Error(t, err, "not written yet")
+
+	assert.NoError(t, tStore.WriteBatch())
+	c, err := tStore.CommitteeChange(10)
+	assert.NoError(t, err)
+	assert.Equal(t, c, change)
+
+	_, err = tStore.CommitteeChange(11)
+	assert.Error(t, err)
+}
@@ -2,23 +2,23 @@ package store
 
 import (
 	"github.com/fxamacker/cbor/v2"
-	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/zarbchain/zarb-go/store/kv"
 	"github.com/zarbchain/zarb-go/tx"
 )
 
 func txKey(id tx.ID) []byte { return append(txPrefix, id.RawBytes()...) }
 
 type txStore struct {
-	db *leveldb.DB
+	db kv.DB
 }
 
-func newTxStore(db *leveldb.DB) *txStore {
+func newTxStore(db kv.DB) *txStore {
 	return &txStore{
 		db: db,
 	}
 }
 
-func (ts *txStore) saveTx(batch *leveldb.Batch, trx *tx.Tx) error {
+func (ts *txStore) saveTx(batch kv.Batch, trx *tx.Tx) error {
 	data, err := cbor.Marshal(trx)
 	if err != nil {
 		return err
@@ -0,0 +1,55 @@
+package store
+
+import (
+	"github.com/fxamacker/cbor/v2"
+	"github.com/zarbchain/zarb-go/store/kv"
+	"github.com/zarbchain/zarb-go/util"
+)
+
+func undoKey(height int) []byte { return append(undoPrefix, util.IntToSlice(height)...) }
+
+// undoEntry keeps the value of a key before committing a block.
+// Empty value means the key didn't exist before.
+type undoEntry struct {
+	Key   []byte `cbor:"1,keyasint"`
+	Value []byte `cbor:"2,keyasint"`
+}
+
+type undoData struct {
+	Entries []undoEntry `cbor:"1,keyasint"`
+}
+
+type undoStore struct {
+	db kv.DB
+}
+
+func newUndoStore(db kv.DB) *undoStore {
+	return &undoStore{
+		db: db,
+	}
+}
+
+func (us *undoStore) saveUndo(batch kv.Batch, height int, entries []undoEntry) error {
+	data, err := cbor.Marshal(undoData{Entries: entries})
+	if err != nil {
+		return err
+	}
+	batch.Put(undoKey(height), data)
+	return nil
+}
+
+func (us *undoStore) removeUndo(batch kv.Batch, height int) {
+	batch.Delete(undoKey(height))
+}
+
+func (us *undoStore) undo(height int) ([]undoEntry, error) {
+	data, err := tryGet(us.db, undoKey(height))
+	if err != nil {
+		return nil, err
+	}
+	ud := new(undoData)
+	if err := cbor.Unmarshal(data, ud); err != nil {
+		return nil, err
+	}
+	return ud.Entries, nil
+}
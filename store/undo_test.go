@@ -0,0 +1,156 @@
+package store
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zarbchain/zarb-go/account"
+	"github.com/zarbchain/zarb-go/block"
+	"github.com/zarbchain/zarb-go/tx"
+	"github.com/zarbchain/zarb-go/validator"
+)
+
+func saveTestBlock(t *testing.T, height int) (*block.Block, []*tx.Tx) {
+	b, trxs := block.GenerateTestBlock(nil, nil)
+	tStore.SaveBlock(height, b)
+	for _, trx := range trxs {
+		tStore.SaveTransaction(trx)
+	}
+	return b, trxs
+}
+
+func acceptAll(accs []*account.Account, vals []*validator.Validator) error {
+	return nil
+}
+
+func TestRollback(t *testing.T) {
+	setup(t)
+
+	// Genesis state
+	acc1, _ := account.GenerateTestAccount(0)
+	val1, _ := validator.GenerateTestValidator(0)
+	tStore.UpdateAccount(acc1)
+	tStore.UpdateValidator(val1)
+	require.NoError(t, tStore.WriteBatch())
+
+	// Block 1
+	saveTestBlock(t, 1)
+	acc1.AddToBalance(1)
+	acc2, _ := account.GenerateTestAccount(1)
+	tStore.UpdateAccount(acc1)
+	tStore.UpdateAccount(acc2)
+	require.NoError(t, tStore.WriteBatch())
+	acc1AtHeight1, _ := tStore.Account(acc1.Address())
+
+	// Block 2
+	b2, trxs2 := saveTestBlock(t, 2)
+	acc1.AddToBalance(1)
+	tStore.UpdateAccount(acc1)
+	acc1.AddToBalance(1)
+	tStore.UpdateAccount(acc1)
+	val1.AddToStake(1)
+	val2, _ := validator.GenerateTestValidator(1)
+	tStore.UpdateValidator(val1)
+	tStore.UpdateValidator(val2)
+	tStore.SaveCommitteeChange(2, &CommitteeChange{Joined: []int{1}, Left: []int{0}})
+	require.NoError(t, tStore.WriteBatch())
+
+	t.Run("Invalid height", func(t *testing.T) {
+		assert.Error(t, tStore.Rollback(0, nil, acceptAll))
+		assert.Error(t, tStore.Rollback(2, []byte{2}, acceptAll))
+	})
+
+	t.Run("Unsaved changes", func(t *testing.T) {
+		acc3, _ := account.GenerateTestAccount(3)
+		tStore.UpdateAccount(acc3)
+		assert.Error(t, tStore.Rollback(1, []byte{1}, acceptAll))
+		tStore.batch.Reset()
+		tStore.undoValues = make(map[string][]byte)
+	})
+
+	t.Run("Verification fails, nothing should change", func(t *testing.T) {
+		assert.Error(t, tStore.Rollback(1, []byte{1}, func(accs []*account.Account, vals []*validator.Validator) error {
+			return fmt.Errorf("invalid state")
+		}))
+		_, err := tStore.Block(2)
+		assert.NoError(t, err)
+		assert.True(t, tStore.HasValidator(val2.Address()))
+		assert.Nil(t, tStore.RestoreLastInfo())
+	})
+
+	require.NoError(t, tStore.Rollback(1, []byte{1}, func(accs []*account.Account, vals []*validator.Validator) error {
+		// The accounts and validators at height 1
+		assert.Equal(t, len(accs), 2)
+		assert.Equal(t, len(vals), 1)
+		for _, acc := range accs {
+			if acc.Address().EqualsTo(acc1.Address()) {
+				assert.Equal(t, acc.Hash(), acc1AtHeight1.Hash())
+			}
+		}
+		assert.Equal(t, vals[0].Stake(), val1.Stake()-1)
+		return nil
+	}))
+
+	_, err := tStore.Block(2)
+	assert.Error(t, err)
+	assert.Equal(t, tStore.RestoreLastInfo(), []byte{1})
+	_, err = tStore.BlockHeight(b2.Hash())
+	assert.Error(t, err)
+	_, err = tStore.Transaction(trxs2[0].ID())
+	assert.Error(t, err)
+	_, err = tStore.CommitteeChange(2)
+	assert.Error(t, err)
+	_, err = tStore.Block(1)
+	assert.NoError(t, err)
+
+	acc, err := tStore.Account(acc1.Address())
+	assert.NoError(t, err)
+	assert.Equal(t, acc.Hash(), acc1AtHeight1.Hash())
+	assert.True(t, tStore.HasAccount(acc2.Address()))
+	assert.Equal(t, tStore.TotalAccounts(), 2)
+
+	val, err := tStore.Validator(val1.Address())
+	assert.NoError(t, err)
+	assert.Equal(t, val.Stake(), val1.Stake()-1)
+	assert.False(t, tStore.HasValidator(val2.Address()))
+	_, err = tStore.ValidatorByNumber(1)
+	assert.Error(t, err)
+	assert.Equal(t, tStore.TotalValidators(), 1)
+}
+
+func TestRollbackWithoutUndoData(t *testing.T) {
+	setup(t)
+
+	// Saving more than one block in a batch, like restoring a snapshot
+	saveTestBlock(t, 1)
+	saveTestBlock(t, 2)
+	require.NoError(t, tStore.WriteBatch())
+
+	saveTestBlock(t, 3)
+	require.NoError(t, tStore.WriteBatch())
+
+	assert.Error(t, tStore.Rollback(1, []byte{1}, acceptAll))
+	assert.NoError(t, tStore.Rollback(2, []byte{2}, acceptAll))
+}
+
+func TestPruneUndoData(t *testing.T) {
+	setup(t)
+
+	depth := tStore.config.RollbackDepth
+	for h := 1; h <= depth+2; h++ {
+		saveTestBlock(t, h)
+		require.NoError(t, tStore.WriteBatch())
+	}
+
+	_, err := tStore.undoStore.undo(1)
+	assert.Error(t, err)
+	_, err = tStore.undoStore.undo(2)
+	assert.Error(t, err)
+	_, err = tStore.undoStore.undo(3)
+	assert.NoError(t, err)
+
+	assert.Error(t, tStore.Rollback(1, []byte{1}, acceptAll))
+	assert.NoError(t, tStore.Rollback(2, []byte{2}, acceptAll))
+}
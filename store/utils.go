@@ -1,12 +1,12 @@
 package store
 
 import (
-	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/zarbchain/zarb-go/logger"
+	"github.com/zarbchain/zarb-go/store/kv"
 )
 
-func tryGet(db *leveldb.DB, key []byte) ([]byte, error) {
-	data, err := db.Get(key, nil)
+func tryGet(db kv.DB, key []byte) ([]byte, error) {
+	data, err := db.Get(key)
 	if err != nil {
 		// Probably key doesn't exist in database
 		logger.Trace("DB error on get", "err", err, "key", key)
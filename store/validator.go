@@ -3,21 +3,20 @@ package store
 import (
 	"fmt"
 
-	"github.com/syndtr/goleveldb/leveldb"
-	"github.com/syndtr/goleveldb/leveldb/util"
 	"github.com/zarbchain/zarb-go/crypto"
+	"github.com/zarbchain/zarb-go/store/kv"
 	"github.com/zarbchain/zarb-go/validator"
 )
 
 type validatorStore struct {
-	db     *leveldb.DB
+	db     kv.DB
 	valMap map[int]*validator.Validator
 	total  int
 }
 
 func validatorKey(addr crypto.Address) []byte { return append(validatorPrefix, addr.RawBytes()...) }
 
-func newValidatorStore(db *leveldb.DB) *validatorStore {
+func newValidatorStore(db kv.DB) *validatorStore {
 	vs := &validatorStore{
 		db: db,
 	}
@@ -37,7 +36,7 @@ func newValidatorStore(db *leveldb.DB) *validatorStore {
 }
 
 func (vs *validatorStore) hasValidator(addr crypto.Address) bool {
-	has, err := vs.db.Has(validatorKey(addr), nil)
+	has, err := vs.db.Has(validatorKey(addr))
 	if err != nil {
 		return false
 	}
@@ -68,8 +67,9 @@ func (vs *validatorStore) validatorByNumber(num int) (*validator.Validator, erro
 }
 
 func (vs *validatorStore) iterateValidators(consumer func(*validator.Validator) (stop bool)) {
-	r := util.BytesPrefix(validatorPrefix)
-	iter := vs.db.NewIterator(r, nil)
+	iter := vs.db.NewIterator(validatorPrefix)
+	defer iter.Release()
+
 	for iter.Next() {
 		// key := iter.Key()
 		value := iter.Value()
@@ -83,12 +83,10 @@ func (vs *validatorStore) iterateValidators(consumer func(*validator.Validator)
 		if stopped {
 			return
 		}
-
 	}
-	iter.Release()
 }
 
-func (vs *validatorStore) updateValidator(batch *leveldb.Batch, val *validator.Validator) error {
+func (vs *validatorStore) updateValidator(batch kv.Batch, val *validator.Validator) error {
 	data, err := val.Encode()
 	if err != nil {
 		return err
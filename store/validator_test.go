@@ -31,6 +31,7 @@ func TestValidatorCounter(t *testing.T) {
 
 func TestValidatorBatchSaving(t *testing.T) {
 	conf := TestConfig()
+	conf.Engine = EngineLevelDB // reopening needs a persistent database
 	store, _ := NewStore(conf)
 
 	t.Run("Add 100 validators", func(t *testing.T) {
@@ -53,6 +54,7 @@ func TestValidatorBatchSaving(t *testing.T) {
 
 func TestValidatorByNumber(t *testing.T) {
 	conf := TestConfig()
+	conf.Engine = EngineLevelDB // reopening needs a persistent database
 	store, _ := NewStore(conf)
 
 	t.Run("Add some validators", func(t *testing.T) {
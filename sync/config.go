@@ -3,6 +3,8 @@ package sync
 import (
 	"time"
 
+	"github.com/zarbchain/zarb-go/crypto"
+	"github.com/zarbchain/zarb-go/errors"
 	"github.com/zarbchain/zarb-go/sync/firewall"
 )
 
@@ -13,9 +15,15 @@ type Config struct {
 	StartingTimeout      time.Duration    `toml:"" comment:"StartingTimeout is time taken for syncing the node."`
 	HeartBeatTimeout     time.Duration    `toml:"" comment:"HeartBeatTimeout timeout for broadcasting heartbeat message to network."`
 	SessionTimeout       time.Duration    `toml:"" comment:"SessionTimeout timeout for session of node."`
+	RelayTimeout         time.Duration    `toml:"" comment:"RelayTimeout timeout for receiving the missing transactions of a proposal, before querying the full proposal."`
 	InitialBlockDownload bool             `toml:"" comment:"InitialBlockDownload enable or disable for initial block downloading."`
+	StateSync            bool             `toml:"" comment:"StateSync enable or disable bootstrapping an empty node from the snapshots of other peers. It needs a trusted block."`
+	TrustedHeight        int              `toml:"" comment:"TrustedHeight is the height of a block that we trust, for state syncing. It should be the block right after a snapshot, like 1001 for the snapshot at height 1000."`
+	TrustedHash          string           `toml:"" comment:"TrustedHash is the hash of the trusted block. Snapshots that don't match it are rejected."`
 	BlockPerMessage      int              `toml:"" comment:"BlockPerMessage the number of blocks per message.Default is 120."`
 	MaximumOpenSessions  int              `toml:"" comment:"Maximum number of open session.Default is 8"`
+	DownloadChunkSize    int              `toml:"" comment:"DownloadChunkSize the number of blocks in each download request. Default is 360"`
+	DownloadPipeline     int              `toml:"" comment:"DownloadPipeline the number of download requests in flight for each peer. Default is 2"`
 	CacheSize            int              `toml:"" comment:"CacheSize is the total capacity of the cache"`
 	Firewall             *firewall.Config `toml:"" comment:"Setting for firewall"`
 }
@@ -25,9 +33,13 @@ func DefaultConfig() *Config {
 		StartingTimeout:      time.Second * 3,
 		HeartBeatTimeout:     time.Second * 5,
 		SessionTimeout:       time.Second * 30,
+		RelayTimeout:         time.Second * 2,
 		InitialBlockDownload: true,
+		StateSync:            false,
 		BlockPerMessage:      120,
 		MaximumOpenSessions:  8,
+		DownloadChunkSize:    360,
+		DownloadPipeline:     2,
 		CacheSize:            500000,
 		Firewall:             firewall.DefaultConfig(),
 	}
@@ -39,9 +51,13 @@ func TestConfig() *Config {
 		StartingTimeout:      0,
 		HeartBeatTimeout:     time.Second * 1,
 		SessionTimeout:       time.Second * 1,
+		RelayTimeout:         time.Millisecond * 200,
 		InitialBlockDownload: true,
+		StateSync:            false,
 		BlockPerMessage:      10,
 		MaximumOpenSessions:  4,
+		DownloadChunkSize:    20,
+		DownloadPipeline:     2,
 		CacheSize:            1000,
 		Firewall:             firewall.TestConfig(),
 	}
@@ -49,5 +65,19 @@ func TestConfig() *Config {
 
 // SanityCheck is a basic checks for config
 func (conf *Config) SanityCheck() error {
-	return nil
+	if conf.DownloadChunkSize <= 0 {
+		return errors.Errorf(errors.ErrInvalidConfig, "download chunk size should be positive")
+	}
+	if conf.DownloadPipeline <= 0 {
+		return errors.Errorf(errors.ErrInvalidConfig, "download pipeline should be positive")
+	}
+	if conf.StateSync {
+		if conf.TrustedHeight <= 1 {
+			return errors.Errorf(errors.ErrInvalidConfig, "state sync needs a trusted height")
+		}
+		if _, err := crypto.HashFromString(conf.TrustedHash); err != nil {
+			return errors.Errorf(errors.ErrInvalidConfig, "state sync needs a valid trusted hash: %v", err)
+		}
+	}
+	return conf.Firewall.SanityCheck()
 }
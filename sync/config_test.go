@@ -4,9 +4,37 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/zarbchain/zarb-go/crypto"
 )
 
 func TestDefaultConfigCheck(t *testing.T) {
 	c := DefaultConfig()
 	assert.NoError(t, c.SanityCheck())
 }
+
+func TestDownloadConfigCheck(t *testing.T) {
+	c := TestConfig()
+	assert.NoError(t, c.SanityCheck())
+
+	c.DownloadChunkSize = 0
+	assert.Error(t, c.SanityCheck())
+
+	c = TestConfig()
+	c.DownloadPipeline = 0
+	assert.Error(t, c.SanityCheck())
+}
+
+func TestStateSyncConfigCheck(t *testing.T) {
+	c := TestConfig()
+	c.StateSync = true
+	assert.Error(t, c.SanityCheck())
+
+	c.TrustedHeight = 1001
+	assert.Error(t, c.SanityCheck())
+
+	c.TrustedHash = crypto.GenerateTestHash().String()
+	assert.NoError(t, c.SanityCheck())
+
+	c.TrustedHeight = 1
+	assert.Error(t, c.SanityCheck())
+}
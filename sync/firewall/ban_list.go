@@ -0,0 +1,131 @@
+package firewall
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/zarbchain/zarb-go/errors"
+	"github.com/zarbchain/zarb-go/util"
+)
+
+// BanList keeps the banned peers. A temporary ban expires after a while,
+// but the number of bans is kept to ban the peer permanently at the end.
+// The list is saved in a file after each ban. An empty path keeps it in memory.
+type BanList struct {
+	lk sync.RWMutex
+
+	path string
+	bans map[peer.ID]*ban
+}
+
+type ban struct {
+	Count     int       `json:"count"`
+	Permanent bool      `json:"permanent"`
+	Until     time.Time `json:"until"`
+}
+
+// LoadBanList loads the ban list from the file, if it exists
+func LoadBanList(path string) (*BanList, error) {
+	bl := &BanList{
+		path: path,
+		bans: make(map[peer.ID]*ban),
+	}
+	if path == "" || !util.PathExists(path) {
+		return bl, nil
+	}
+
+	bs, err := util.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(bs, &bl.bans); err != nil {
+		return nil, errors.Errorf(errors.ErrGeneric, "invalid ban list: %v", err)
+	}
+	return bl, nil
+}
+
+// Ban bans the peer until the given time.
+// If the peer is banned more than maxBans times, it is banned permanently.
+// Zero maxBans disables permanent bans.
+func (bl *BanList) Ban(pid peer.ID, until time.Time, maxBans int) error {
+	bl.lk.Lock()
+	defer bl.lk.Unlock()
+
+	b, ok := bl.bans[pid]
+	if !ok {
+		b = &ban{}
+		bl.bans[pid] = b
+	}
+	b.Count++
+	b.Until = until
+	if maxBans > 0 && b.Count > maxBans {
+		b.Permanent = true
+	}
+
+	return bl.save()
+}
+
+// IsBanned returns true if the peer is banned permanently or its ban is not expired yet
+func (bl *BanList) IsBanned(pid peer.ID) bool {
+	bl.lk.RLock()
+	defer bl.lk.RUnlock()
+
+	b, ok := bl.bans[pid]
+	if !ok {
+		return false
+	}
+	return b.Permanent || util.Now().Before(b.Until)
+}
+
+// Banned returns the banned peers and the end of their bans. Zero time means a permanent ban.
+func (bl *BanList) Banned() map[peer.ID]time.Time {
+	bl.lk.RLock()
+	defer bl.lk.RUnlock()
+
+	banned := make(map[peer.ID]time.Time)
+	for pid, b := range bl.bans {
+		if b.Permanent {
+			banned[pid] = time.Time{}
+		} else if util.Now().Before(b.Until) {
+			banned[pid] = b.Until
+		}
+	}
+	return banned
+}
+
+// IsPermanentlyBanned returns true if the peer is banned permanently
+func (bl *BanList) IsPermanentlyBanned(pid peer.ID) bool {
+	bl.lk.RLock()
+	defer bl.lk.RUnlock()
+
+	b, ok := bl.bans[pid]
+	return ok && b.Permanent
+}
+
+// Unban removes the peer from the ban list
+func (bl *BanList) Unban(pid peer.ID) error {
+	bl.lk.Lock()
+	defer bl.lk.Unlock()
+
+	delete(bl.bans, pid)
+	return bl.save()
+}
+
+// save writes the ban list into a temporary file and then renames it
+func (bl *BanList) save() error {
+	if bl.path == "" {
+		return nil
+	}
+	bs, err := json.MarshalIndent(bl.bans, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := bl.path + ".tmp"
+	if err := util.WriteFile(tmp, bs); err != nil {
+		return err
+	}
+	return os.Rename(tmp, bl.path)
+}
@@ -0,0 +1,75 @@
+package firewall
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zarbchain/zarb-go/util"
+)
+
+func TestBanList(t *testing.T) {
+	bl, err := LoadBanList("")
+	assert.NoError(t, err)
+	pid := util.RandomPeerID()
+
+	assert.False(t, bl.IsBanned(pid))
+	assert.NoError(t, bl.Ban(pid, util.Now().Add(time.Minute), 2))
+	assert.True(t, bl.IsBanned(pid))
+	assert.False(t, bl.IsPermanentlyBanned(pid))
+
+	t.Run("Temporary ban expires", func(t *testing.T) {
+		assert.NoError(t, bl.Ban(pid, util.Now().Add(-time.Second), 2))
+		assert.False(t, bl.IsBanned(pid))
+	})
+
+	t.Run("Banning more than the maximum bans the peer permanently", func(t *testing.T) {
+		assert.NoError(t, bl.Ban(pid, util.Now().Add(-time.Second), 2))
+		assert.True(t, bl.IsBanned(pid))
+		assert.True(t, bl.IsPermanentlyBanned(pid))
+	})
+
+	t.Run("Unban", func(t *testing.T) {
+		assert.NoError(t, bl.Unban(pid))
+		assert.False(t, bl.IsBanned(pid))
+	})
+}
+
+func TestBanListWithoutPermanentBan(t *testing.T) {
+	bl, _ := LoadBanList("")
+	pid := util.RandomPeerID()
+
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, bl.Ban(pid, util.Now().Add(-time.Second), 0))
+	}
+	assert.False(t, bl.IsBanned(pid))
+}
+
+func TestSaveBanList(t *testing.T) {
+	path := util.TempDirPath() + "/ban_list.json"
+	bl, err := LoadBanList(path)
+	assert.NoError(t, err)
+
+	pid1 := util.RandomPeerID()
+	pid2 := util.RandomPeerID()
+	assert.NoError(t, bl.Ban(pid1, util.Now().Add(time.Hour), 0))
+	assert.NoError(t, bl.Ban(pid2, util.Now().Add(time.Hour), 0))
+	assert.NoError(t, bl.Ban(pid2, util.Now().Add(time.Hour), 1))
+
+	bl2, err := LoadBanList(path)
+	assert.NoError(t, err)
+	assert.True(t, bl2.IsBanned(pid1))
+	assert.False(t, bl2.IsPermanentlyBanned(pid1))
+	assert.True(t, bl2.IsPermanentlyBanned(pid2))
+
+	banned := bl2.Banned()
+	assert.Equal(t, len(banned), 2)
+	assert.False(t, banned[pid1].IsZero())
+	assert.True(t, banned[pid2].IsZero())
+
+	t.Run("Invalid file", func(t *testing.T) {
+		assert.NoError(t, util.WriteFile(path, []byte("invalid")))
+		_, err := LoadBanList(path)
+		assert.Error(t, err)
+	})
+}
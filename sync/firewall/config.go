@@ -1,22 +1,101 @@
 package firewall
 
+import (
+	"time"
+
+	"github.com/zarbchain/zarb-go/errors"
+	"github.com/zarbchain/zarb-go/sync/message/payload"
+)
+
 type Config struct {
-	Enabled bool
+	Enabled          bool
+	BanScore         float64       `toml:"" comment:"BanScore is the score that bans a misbehaving peer temporarily. Default is 100"`
+	ScoreHalfLife    time.Duration `toml:"" comment:"ScoreHalfLife is the time that the score of a peer decays to half."`
+	BanDuration      time.Duration `toml:"" comment:"BanDuration is the duration of a temporary ban."`
+	MaxTemporaryBans int           `toml:"" comment:"MaxTemporaryBans is the number of temporary bans after that the peer is banned permanently. Zero disables permanent bans."`
+	BanListPath      string        `toml:"" comment:"BanListPath is the path of the file that keeps the banned peers. Empty path keeps them in memory. Default is ./data/ban_list.json"`
+
+	RequireSignedMessages bool `toml:"" comment:"RequireSignedMessages drops unsigned salam, aleyk and heartbeat messages, if the firewall is enabled. Keep it disabled until all the nodes of the network sign their messages."`
+
+	QueryTransactionsLimit   RateLimit `toml:"" comment:"QueryTransactionsLimit limits the query transactions messages of each peer."`
+	QueryVotesLimit          RateLimit `toml:"" comment:"QueryVotesLimit limits the query votes messages of each peer."`
+	DownloadRequestLimit     RateLimit `toml:"" comment:"DownloadRequestLimit limits the download requests of each peer."`
+	LatestBlocksRequestLimit RateLimit `toml:"" comment:"LatestBlocksRequestLimit limits the latest blocks requests of each peer."`
 }
 
 func DefaultConfig() *Config {
 	return &Config{
-		Enabled: false,
+		Enabled:          false,
+		BanScore:         100,
+		ScoreHalfLife:    10 * time.Minute,
+		BanDuration:      1 * time.Hour,
+		MaxTemporaryBans: 3,
+		BanListPath:      "data/ban_list.json",
+
+		RequireSignedMessages: false,
+
+		QueryTransactionsLimit:   RateLimit{Rate: 5, Burst: 20},
+		QueryVotesLimit:          RateLimit{Rate: 1, Burst: 10},
+		DownloadRequestLimit:     RateLimit{Rate: 0.1, Burst: 4},
+		LatestBlocksRequestLimit: RateLimit{Rate: 0.1, Burst: 4},
 	}
 }
 
 func TestConfig() *Config {
 	return &Config{
-		Enabled: false,
+		Enabled:          false,
+		BanScore:         100,
+		ScoreHalfLife:    10 * time.Minute,
+		BanDuration:      1 * time.Minute,
+		MaxTemporaryBans: 3,
+		BanListPath:      "",
+
+		RequireSignedMessages: false,
+
+		QueryTransactionsLimit:   RateLimit{Rate: 5, Burst: 20},
+		QueryVotesLimit:          RateLimit{Rate: 1, Burst: 10},
+		DownloadRequestLimit:     RateLimit{Rate: 0.1, Burst: 4},
+		LatestBlocksRequestLimit: RateLimit{Rate: 0.1, Burst: 4},
 	}
 }
 
 // SanityCheck is a basic checks for config
 func (conf *Config) SanityCheck() error {
+	if conf.BanScore <= 0 {
+		return errors.Errorf(errors.ErrInvalidConfig, "BanScore should be positive")
+	}
+	if conf.ScoreHalfLife <= 0 {
+		return errors.Errorf(errors.ErrInvalidConfig, "ScoreHalfLife should be positive")
+	}
+	if conf.BanDuration <= 0 {
+		return errors.Errorf(errors.ErrInvalidConfig, "BanDuration should be positive")
+	}
+	if conf.MaxTemporaryBans < 0 {
+		return errors.Errorf(errors.ErrInvalidConfig, "MaxTemporaryBans can't be negative")
+	}
+	for _, l := range conf.rateLimits() {
+		if err := l.sanityCheck(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (conf *Config) rateLimits() map[payload.Type]RateLimit {
+	return map[payload.Type]RateLimit{
+		payload.PayloadTypeQueryTransactions:   conf.QueryTransactionsLimit,
+		payload.PayloadTypeQueryVotes:          conf.QueryVotesLimit,
+		payload.PayloadTypeDownloadRequest:     conf.DownloadRequestLimit,
+		payload.PayloadTypeLatestBlocksRequest: conf.LatestBlocksRequestLimit,
+	}
+}
+
+func (l RateLimit) sanityCheck() error {
+	if l.Rate < 0 {
+		return errors.Errorf(errors.ErrInvalidConfig, "rate limit can't be negative")
+	}
+	if l.Rate > 0 && l.Burst < 1 {
+		return errors.Errorf(errors.ErrInvalidConfig, "burst of a rate limit should be at least one")
+	}
 	return nil
 }
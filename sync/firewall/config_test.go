@@ -0,0 +1,43 @@
+package firewall
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigCheck(t *testing.T) {
+	c1 := DefaultConfig()
+	assert.NoError(t, c1.SanityCheck())
+
+	c2 := TestConfig()
+	assert.NoError(t, c2.SanityCheck())
+
+	c3 := DefaultConfig()
+	c3.BanScore = 0
+	assert.Error(t, c3.SanityCheck())
+
+	c4 := DefaultConfig()
+	c4.ScoreHalfLife = 0
+	assert.Error(t, c4.SanityCheck())
+
+	c5 := DefaultConfig()
+	c5.BanDuration = 0
+	assert.Error(t, c5.SanityCheck())
+
+	c6 := DefaultConfig()
+	c6.MaxTemporaryBans = -1
+	assert.Error(t, c6.SanityCheck())
+
+	c7 := DefaultConfig()
+	c7.QueryVotesLimit.Rate = -1
+	assert.Error(t, c7.SanityCheck())
+
+	c8 := DefaultConfig()
+	c8.DownloadRequestLimit.Burst = 0
+	assert.Error(t, c8.SanityCheck())
+
+	c9 := DefaultConfig()
+	c9.DownloadRequestLimit = RateLimit{}
+	assert.NoError(t, c9.SanityCheck())
+}
@@ -2,34 +2,64 @@ package firewall
 
 import (
 	"encoding/hex"
+	"sync"
+	"time"
 
+	"github.com/zarbchain/zarb-go/errors"
 	"github.com/zarbchain/zarb-go/network"
 	"github.com/zarbchain/zarb-go/state"
 
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/zarbchain/zarb-go/logger"
 	"github.com/zarbchain/zarb-go/sync/message"
+	"github.com/zarbchain/zarb-go/sync/message/payload"
 	"github.com/zarbchain/zarb-go/sync/peerset"
 	"github.com/zarbchain/zarb-go/util"
 )
 
-// Firewall check packets before passing them to sync module
+// Firewall check packets before passing them to sync module.
+// It also keeps a score for misbehaving peers. The score decays over time
+// and the peer is banned if its score reaches the ban score.
 type Firewall struct {
+	lk sync.Mutex
+
 	config  *Config
 	network network.Network
 	peerSet *peerset.PeerSet
 	state   state.Facade
+	banList *BanList
+	scores  map[peer.ID]*score
+	limiter *rateLimiter
 	logger  *logger.Logger
 }
 
-func NewFirewall(conf *Config, net network.Network, peerSet *peerset.PeerSet, state state.Facade, logger *logger.Logger) *Firewall {
+func NewFirewall(conf *Config, net network.Network, peerSet *peerset.PeerSet, state state.Facade, logger *logger.Logger) (*Firewall, error) {
+	banListPath := ""
+	if conf.BanListPath != "" {
+		banListPath = util.MakeAbs(conf.BanListPath)
+	}
+	banList, err := LoadBanList(banListPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if conf.Enabled {
+		// The network should reject the peers that are banned before
+		for pid, until := range banList.Banned() {
+			net.BanPeer(pid, until, time.Time{})
+		}
+	}
+
 	return &Firewall{
 		config:  conf,
 		network: net,
 		peerSet: peerSet,
 		state:   state,
+		banList: banList,
+		scores:  make(map[peer.ID]*score),
+		limiter: newRateLimiter(conf.rateLimits()),
 		logger:  logger,
-	}
+	}, nil
 }
 
 func (f *Firewall) OpenMessage(data []byte, from peer.ID) *message.Message {
@@ -46,6 +76,7 @@ func (f *Firewall) OpenMessage(data []byte, from peer.ID) *message.Message {
 	msg := new(message.Message)
 	if err := msg.Decode(data); err != nil {
 		peer.IncreaseInvalidMessage()
+		f.Penalize(from, MisbehaviorInvalidMessage)
 		f.logger.Debug("Error decoding message", "from", util.FingerprintPeerID(from), "data", hex.EncodeToString(data), "err", err)
 
 		return nil
@@ -53,10 +84,18 @@ func (f *Firewall) OpenMessage(data []byte, from peer.ID) *message.Message {
 
 	if err := msg.SanityCheck(); err != nil {
 		peer.IncreaseInvalidMessage()
+		f.Penalize(from, MisbehaviorInvalidMessage)
 		f.logger.Debug("Peer sent us invalid msg", "from", util.FingerprintPeerID(from), "msg", msg, "err", err)
 		return nil
 	}
 
+	// The message might be relayed by an honest peer, so we don't penalize the sender here.
+	if err := f.checkSignature(msg); err != nil {
+		peer.IncreaseInvalidMessage()
+		f.logger.Warn("Firewall: Message with invalid signature", "msg", msg, "from", util.FingerprintPeerID(from), "err", err)
+		return nil
+	}
+
 	if f.shouldDropMessage(msg) {
 		// TODO: A better way for handshaking
 		peer.IncreaseInvalidMessage()
@@ -73,11 +112,29 @@ func (f *Firewall) shouldDropMessage(msg *message.Message) bool {
 	}
 
 	initiatorPeer := f.peerSet.MustGetPeer(msg.Initiator)
-	switch initiatorPeer.Status() {
-	case peerset.StatusCodeBanned:
-		return true
+	return f.isBanned(initiatorPeer)
+}
+
+// checkSignature verifies the signature of the signed messages.
+// Unsigned messages are accepted, unless the firewall is enabled, the message type is sensitive and signature is required.
+func (f *Firewall) checkSignature(msg *message.Message) error {
+	if msg.IsSigned() {
+		return msg.Verify()
+	}
+	if f.config.Enabled && f.config.RequireSignedMessages && isSensitive(msg.Payload.Type()) {
+		return errors.Errorf(errors.ErrInvalidSignature, "message is not signed")
 	}
+	return nil
+}
 
+// isSensitive returns true for messages that update the information of the initiator in peer set.
+func isSensitive(t payload.Type) bool {
+	switch t {
+	case payload.PayloadTypeSalam,
+		payload.PayloadTypeAleyk,
+		payload.PayloadTypeHeartBeat:
+		return true
+	}
 	return false
 }
 
@@ -86,9 +143,81 @@ func (f *Firewall) shouldBanPeer(peer *peerset.Peer) bool {
 		return false
 	}
 
-	switch peer.Status() {
-	case peerset.StatusCodeBanned:
+	return f.isBanned(peer)
+}
+
+func (f *Firewall) isBanned(peer *peerset.Peer) bool {
+	if peer.Status() == peerset.StatusCodeBanned {
 		return true
 	}
-	return false
+	return f.banList.IsBanned(peer.PeerID())
+}
+
+// Penalize increases the score of the peer for the misbehavior.
+// If the score reaches the ban score, the peer is banned and disconnected.
+func (f *Firewall) Penalize(pid peer.ID, m Misbehavior) {
+	if !f.config.Enabled {
+		return
+	}
+
+	f.lk.Lock()
+	s, ok := f.scores[pid]
+	if !ok {
+		s = &score{updatedAt: util.Now()}
+		f.scores[pid] = s
+	}
+	value := s.decay(util.Now(), f.config.ScoreHalfLife) + m.Penalty()
+	s.value = value
+	shouldBan := value >= f.config.BanScore
+	if shouldBan {
+		delete(f.scores, pid)
+	}
+	f.lk.Unlock()
+
+	f.logger.Debug("Firewall: Peer penalized", "pid", util.FingerprintPeerID(pid), "misbehavior", m, "score", value)
+	if shouldBan {
+		f.banPeer(pid)
+	}
+}
+
+// Score returns the current score of the peer
+func (f *Firewall) Score(pid peer.ID) float64 {
+	f.lk.Lock()
+	defer f.lk.Unlock()
+
+	s, ok := f.scores[pid]
+	if !ok {
+		return 0
+	}
+	return s.decay(util.Now(), f.config.ScoreHalfLife)
+}
+
+// Allow checks the rate limit of the peer for this type of messages.
+// It returns false if the peer sends this type of messages too often.
+func (f *Firewall) Allow(pid peer.ID, t payload.Type) bool {
+	if !f.config.Enabled {
+		return true
+	}
+	return f.limiter.allow(pid, t)
+}
+
+// IsBanned returns true if the peer is banned
+func (f *Firewall) IsBanned(pid peer.ID) bool {
+	return f.banList.IsBanned(pid)
+}
+
+func (f *Firewall) banPeer(pid peer.ID) {
+	until := util.Now().Add(f.config.BanDuration)
+	if err := f.banList.Ban(pid, until, f.config.MaxTemporaryBans); err != nil {
+		f.logger.Error("Firewall: Unable to save the ban list", "err", err)
+	}
+	if f.banList.IsPermanentlyBanned(pid) {
+		f.logger.Warn("Firewall: Peer banned permanently", "pid", util.FingerprintPeerID(pid))
+		until = time.Time{}
+	} else {
+		f.logger.Warn("Firewall: Peer banned temporarily", "pid", util.FingerprintPeerID(pid), "until", until)
+	}
+	// The network blocks the addresses of the peer too, so it can't reconnect with a new identity.
+	// The addresses are banned temporarily, they can be shared by other peers.
+	f.network.BanPeer(pid, until, util.Now().Add(f.config.BanDuration))
 }
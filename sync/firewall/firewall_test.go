@@ -4,9 +4,11 @@ import (
 	"testing"
 	"time"
 
+	lp2pcrypto "github.com/libp2p/go-libp2p-core/crypto"
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/stretchr/testify/assert"
 	"github.com/zarbchain/zarb-go/committee"
+	"github.com/zarbchain/zarb-go/crypto"
 	"github.com/zarbchain/zarb-go/logger"
 	"github.com/zarbchain/zarb-go/network"
 	"github.com/zarbchain/zarb-go/state"
@@ -31,8 +33,9 @@ func setup(t *testing.T) {
 	tNetwork = network.MockingNetwork(util.RandomPeerID())
 	conf := TestConfig()
 	conf.Enabled = true
-	tFirewall = NewFirewall(conf, tNetwork, peerSet, state, logger)
-	assert.NotNil(t, tFirewall)
+	firewall, err := NewFirewall(conf, tNetwork, peerSet, state, logger)
+	assert.NoError(t, err)
+	tFirewall = firewall
 	tBadPeerID = util.RandomPeerID()
 	tGoodPeerID = util.RandomPeerID()
 	tUnknownPeerID = util.RandomPeerID()
@@ -126,3 +129,145 @@ func TestDisabledFirewal(t *testing.T) {
 	tFirewall.config.Enabled = false
 	assert.NotNil(t, tFirewall.OpenMessage(d, tBadPeerID))
 }
+
+func TestPenalize(t *testing.T) {
+	setup(t)
+
+	t.Run("Score decays over time", func(t *testing.T) {
+		tFirewall.Penalize(tUnknownPeerID, MisbehaviorInvalidMessage)
+		score := tFirewall.Score(tUnknownPeerID)
+		assert.Greater(t, score, 9.99)
+		assert.LessOrEqual(t, score, 10.0)
+
+		s := tFirewall.scores[tUnknownPeerID]
+		s.updatedAt = s.updatedAt.Add(-tFirewall.config.ScoreHalfLife)
+		assert.InDelta(t, tFirewall.Score(tUnknownPeerID), 5, 0.01)
+	})
+
+	t.Run("Reaching the ban score bans the peer", func(t *testing.T) {
+		// The score decays a bit between the penalties
+		tFirewall.Penalize(tGoodPeerID, MisbehaviorInvalidBlock)
+		tFirewall.Penalize(tGoodPeerID, MisbehaviorInvalidBlock)
+		assert.False(t, tFirewall.IsBanned(tGoodPeerID))
+		assert.False(t, tNetwork.Closed)
+
+		tFirewall.Penalize(tGoodPeerID, MisbehaviorInvalidBlock)
+		assert.True(t, tFirewall.IsBanned(tGoodPeerID))
+		assert.True(t, tNetwork.Closed)
+		assert.Contains(t, tNetwork.BannedUntil, tGoodPeerID)
+		assert.False(t, tNetwork.BannedUntil[tGoodPeerID].IsZero())
+		assert.Zero(t, tFirewall.Score(tGoodPeerID))
+
+		msg := message.NewMessage(tGoodPeerID, payload.NewQueryProposalPayload(1, 0))
+		d, _ := msg.Encode()
+		assert.Nil(t, tFirewall.OpenMessage(d, tGoodPeerID))
+	})
+
+	t.Run("Disabled firewall doesn't penalize", func(t *testing.T) {
+		tFirewall.config.Enabled = false
+		tFirewall.Penalize(tBadPeerID, MisbehaviorInvalidMessage)
+		assert.Zero(t, tFirewall.Score(tBadPeerID))
+	})
+}
+
+func TestBanExpiry(t *testing.T) {
+	setup(t)
+
+	pid := util.RandomPeerID()
+	for i := 0; i < 3; i++ {
+		tFirewall.Penalize(pid, MisbehaviorInvalidBlock)
+	}
+	assert.True(t, tFirewall.IsBanned(pid))
+
+	// Expiring the ban
+	tFirewall.banList.bans[pid].Until = util.Now()
+	assert.False(t, tFirewall.IsBanned(pid))
+}
+
+func TestLoadBannedPeers(t *testing.T) {
+	logger.InitLogger(logger.TestConfig())
+	committee, _ := committee.GenerateTestCommittee()
+	conf := TestConfig()
+	conf.Enabled = true
+	conf.BanListPath = util.TempDirPath() + "/ban_list.json"
+
+	banList, err := LoadBanList(conf.BanListPath)
+	assert.NoError(t, err)
+	banned := util.RandomPeerID()
+	expired := util.RandomPeerID()
+	assert.NoError(t, banList.Ban(banned, util.Now().Add(time.Hour), 0))
+	assert.NoError(t, banList.Ban(expired, util.Now().Add(-time.Second), 0))
+
+	net := network.MockingNetwork(util.RandomPeerID())
+	_, err = NewFirewall(conf, net, peerset.NewPeerSet(3*time.Second), state.MockingState(committee), logger.NewLogger("firewal", nil))
+	assert.NoError(t, err)
+	assert.Contains(t, net.BannedUntil, banned)
+	assert.NotContains(t, net.BannedUntil, expired)
+}
+
+func TestSignedMessages(t *testing.T) {
+	setup(t)
+
+	key, _, _ := lp2pcrypto.GenerateEd25519Key(nil)
+	pid, _ := peer.IDFromPrivateKey(key)
+	otherKey, _, _ := lp2pcrypto.GenerateEd25519Key(nil)
+	pld := payload.NewHeartBeatPayload(100, 1, crypto.GenerateTestHash())
+
+	signed := message.NewMessage(pid, pld)
+	assert.NoError(t, signed.Sign(key))
+	d1, _ := signed.Encode()
+
+	spoofed := message.NewMessage(pid, pld)
+	assert.NoError(t, spoofed.Sign(otherKey))
+	d2, _ := spoofed.Encode()
+
+	unsigned := message.NewMessage(pid, pld)
+	d3, _ := unsigned.Encode()
+
+	nonSensitive := message.NewMessage(pid, payload.NewQueryProposalPayload(100, 1))
+	d4, _ := nonSensitive.Encode()
+
+	t.Run("Signature is not required", func(t *testing.T) {
+		tFirewall.config.RequireSignedMessages = false
+
+		assert.NotNil(t, tFirewall.OpenMessage(d1, tGoodPeerID))
+		assert.Nil(t, tFirewall.OpenMessage(d2, tGoodPeerID))
+		assert.NotNil(t, tFirewall.OpenMessage(d3, tGoodPeerID))
+		assert.NotNil(t, tFirewall.OpenMessage(d4, tGoodPeerID))
+	})
+
+	t.Run("Signature is required", func(t *testing.T) {
+		tFirewall.config.RequireSignedMessages = true
+
+		assert.NotNil(t, tFirewall.OpenMessage(d1, tGoodPeerID))
+		assert.Nil(t, tFirewall.OpenMessage(d2, tGoodPeerID))
+		assert.Nil(t, tFirewall.OpenMessage(d3, tGoodPeerID))
+		assert.NotNil(t, tFirewall.OpenMessage(d4, tGoodPeerID))
+	})
+
+	t.Run("Relaying peer should not be penalized", func(t *testing.T) {
+		assert.Zero(t, tFirewall.Score(tGoodPeerID))
+	})
+}
+
+func TestLegacyUnsignedSalam(t *testing.T) {
+	logger.InitLogger(logger.TestConfig())
+	committee, _ := committee.GenerateTestCommittee()
+	conf := DefaultConfig()
+	conf.BanListPath = ""
+	firewall, err := NewFirewall(conf, network.MockingNetwork(util.RandomPeerID()),
+		peerset.NewPeerSet(3*time.Second), state.MockingState(committee), logger.NewLogger("firewal", nil))
+	assert.NoError(t, err)
+
+	_, pub, _ := crypto.GenerateTestKeyPair()
+	pld := payload.NewSalamPayload("legacy", pub, crypto.GenerateTestHash(), 0, 0, payload.Capabilities{})
+	d, _ := message.NewMessage(util.RandomPeerID(), pld).Encode()
+
+	assert.NotNil(t, firewall.OpenMessage(d, util.RandomPeerID()))
+
+	t.Run("Signature is required, but the firewall is disabled", func(t *testing.T) {
+		firewall.config.RequireSignedMessages = true
+
+		assert.NotNil(t, firewall.OpenMessage(d, util.RandomPeerID()))
+	})
+}
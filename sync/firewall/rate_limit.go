@@ -0,0 +1,103 @@
+package firewall
+
+import (
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/zarbchain/zarb-go/sync/message/payload"
+	"github.com/zarbchain/zarb-go/util"
+)
+
+// RateLimit is a token bucket limit. The bucket is refilled with the rate and holds at most burst tokens.
+type RateLimit struct {
+	Rate  float64 `toml:"" comment:"Rate is the number of allowed messages per second. Zero disables the limit."`
+	Burst int     `toml:"" comment:"Burst is the number of allowed messages at once."`
+}
+
+// evictInterval is the interval of removing the idle buckets
+const evictInterval = time.Minute
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+// refill adds the tokens since the last update, but not more than the burst.
+func (b *bucket) refill(limit RateLimit, now time.Time) {
+	b.tokens += now.Sub(b.updatedAt).Seconds() * limit.Rate
+	if b.tokens > float64(limit.Burst) {
+		b.tokens = float64(limit.Burst)
+	}
+	b.updatedAt = now
+}
+
+// rateLimiter keeps a token bucket for each peer and each limited payload type.
+// A full bucket is same as a new one, so the idle full buckets are removed from time to time.
+type rateLimiter struct {
+	lk sync.Mutex
+
+	limits    map[payload.Type]RateLimit
+	buckets   map[peer.ID]map[payload.Type]*bucket
+	evictedAt time.Time
+}
+
+func newRateLimiter(limits map[payload.Type]RateLimit) *rateLimiter {
+	return &rateLimiter{
+		limits:    limits,
+		buckets:   make(map[peer.ID]map[payload.Type]*bucket),
+		evictedAt: util.Now(),
+	}
+}
+
+// allow takes a token from the bucket of the peer. It returns false if the bucket is empty.
+func (rl *rateLimiter) allow(pid peer.ID, t payload.Type) bool {
+	limit, ok := rl.limits[t]
+	if !ok || limit.Rate == 0 {
+		return true
+	}
+
+	rl.lk.Lock()
+	defer rl.lk.Unlock()
+
+	now := util.Now()
+	if now.Sub(rl.evictedAt) > evictInterval {
+		rl.evictFullBuckets(now)
+	}
+
+	buckets, ok := rl.buckets[pid]
+	if !ok {
+		buckets = make(map[payload.Type]*bucket)
+		rl.buckets[pid] = buckets
+	}
+	b, ok := buckets[t]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), updatedAt: now}
+		buckets[t] = b
+	}
+
+	b.refill(limit, now)
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// evictFullBuckets removes the buckets that are refilled completely, and the peers without any bucket.
+func (rl *rateLimiter) evictFullBuckets(now time.Time) {
+	for pid, buckets := range rl.buckets {
+		for t, b := range buckets {
+			limit := rl.limits[t]
+			b.refill(limit, now)
+			if b.tokens >= float64(limit.Burst) {
+				delete(buckets, t)
+			}
+		}
+		if len(buckets) == 0 {
+			delete(rl.buckets, pid)
+		}
+	}
+	rl.evictedAt = now
+}
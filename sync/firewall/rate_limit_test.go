@@ -0,0 +1,76 @@
+package firewall
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zarbchain/zarb-go/sync/message/payload"
+	"github.com/zarbchain/zarb-go/util"
+)
+
+func TestRateLimiter(t *testing.T) {
+	rl := newRateLimiter(map[payload.Type]RateLimit{
+		payload.PayloadTypeQueryVotes:      {Rate: 1, Burst: 3},
+		payload.PayloadTypeDownloadRequest: {Rate: 0, Burst: 0},
+	})
+	pid1 := util.RandomPeerID()
+	pid2 := util.RandomPeerID()
+
+	t.Run("Burst", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			assert.True(t, rl.allow(pid1, payload.PayloadTypeQueryVotes))
+		}
+		assert.False(t, rl.allow(pid1, payload.PayloadTypeQueryVotes))
+	})
+
+	t.Run("Each peer has its own bucket", func(t *testing.T) {
+		assert.True(t, rl.allow(pid2, payload.PayloadTypeQueryVotes))
+	})
+
+	t.Run("Bucket is refilled over time", func(t *testing.T) {
+		b := rl.buckets[pid1][payload.PayloadTypeQueryVotes]
+		b.updatedAt = b.updatedAt.Add(-2 * time.Second)
+		assert.True(t, rl.allow(pid1, payload.PayloadTypeQueryVotes))
+		assert.True(t, rl.allow(pid1, payload.PayloadTypeQueryVotes))
+		assert.False(t, rl.allow(pid1, payload.PayloadTypeQueryVotes))
+	})
+
+	t.Run("Bucket holds at most burst tokens", func(t *testing.T) {
+		b := rl.buckets[pid1][payload.PayloadTypeQueryVotes]
+		b.updatedAt = b.updatedAt.Add(-time.Hour)
+		for i := 0; i < 3; i++ {
+			assert.True(t, rl.allow(pid1, payload.PayloadTypeQueryVotes))
+		}
+		assert.False(t, rl.allow(pid1, payload.PayloadTypeQueryVotes))
+	})
+
+	t.Run("Not limited", func(t *testing.T) {
+		for i := 0; i < 100; i++ {
+			assert.True(t, rl.allow(pid1, payload.PayloadTypeDownloadRequest))
+			assert.True(t, rl.allow(pid1, payload.PayloadTypeQueryProposal))
+		}
+	})
+	t.Run("Idle full buckets are evicted", func(t *testing.T) {
+		// Bucket of pid2 is refilled, but pid1 has no token
+		b := rl.buckets[pid2][payload.PayloadTypeQueryVotes]
+		b.updatedAt = b.updatedAt.Add(-time.Hour)
+		rl.evictedAt = rl.evictedAt.Add(-2 * evictInterval)
+
+		assert.False(t, rl.allow(pid1, payload.PayloadTypeQueryVotes))
+		assert.NotContains(t, rl.buckets, pid2)
+		assert.Contains(t, rl.buckets, pid1)
+	})
+}
+
+func TestAllow(t *testing.T) {
+	setup(t)
+
+	for i := 0; i < tFirewall.config.QueryVotesLimit.Burst; i++ {
+		assert.True(t, tFirewall.Allow(tGoodPeerID, payload.PayloadTypeQueryVotes))
+	}
+	assert.False(t, tFirewall.Allow(tGoodPeerID, payload.PayloadTypeQueryVotes))
+
+	tFirewall.config.Enabled = false
+	assert.True(t, tFirewall.Allow(tGoodPeerID, payload.PayloadTypeQueryVotes))
+}
@@ -0,0 +1,65 @@
+package firewall
+
+import (
+	"math"
+	"time"
+)
+
+// Misbehavior is a kind of bad behavior of a peer that increases its score
+type Misbehavior int
+
+const (
+	MisbehaviorInvalidMessage    = Misbehavior(1)
+	MisbehaviorUselessBlocks     = Misbehavior(2)
+	MisbehaviorUnansweredRequest = Misbehavior(3)
+	MisbehaviorInvalidBlock      = Misbehavior(4)
+	MisbehaviorExceedRateLimit   = Misbehavior(5)
+)
+
+func (m Misbehavior) String() string {
+	switch m {
+	case MisbehaviorInvalidMessage:
+		return "invalid-message"
+	case MisbehaviorUselessBlocks:
+		return "useless-blocks"
+	case MisbehaviorUnansweredRequest:
+		return "unanswered-request"
+	case MisbehaviorInvalidBlock:
+		return "invalid-block"
+	case MisbehaviorExceedRateLimit:
+		return "exceed-rate-limit"
+	}
+	return "invalid"
+}
+
+// Penalty returns the score that the misbehavior adds to the peer score
+func (m Misbehavior) Penalty() float64 {
+	switch m {
+	case MisbehaviorInvalidMessage:
+		return 10
+	case MisbehaviorUselessBlocks:
+		return 5
+	case MisbehaviorUnansweredRequest:
+		return 5
+	case MisbehaviorInvalidBlock:
+		return 50
+	case MisbehaviorExceedRateLimit:
+		return 2
+	}
+	return 0
+}
+
+// score decays exponentially, it halves every half-life
+type score struct {
+	value     float64
+	updatedAt time.Time
+}
+
+func (s *score) decay(now time.Time, halfLife time.Duration) float64 {
+	elapsed := now.Sub(s.updatedAt)
+	if elapsed > 0 {
+		s.value *= math.Pow(0.5, float64(elapsed)/float64(halfLife))
+		s.updatedAt = now
+	}
+	return s.value
+}
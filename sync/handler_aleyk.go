@@ -25,11 +25,14 @@ func (handler *aleykHandler) ParsPayload(p payload.Payload, initiator peer.ID) e
 	peer := handler.peerSet.MustGetPeer(initiator)
 
 	if pld.ResponseTarget == handler.SelfID() {
-		if pld.ResponseCode == payload.ResponseCodeOK {
-			peer.UpdateStatus(peerset.StatusCodeOK)
-		} else {
+		if pld.ResponseCode != payload.ResponseCodeOK {
 			handler.logger.Warn("Our Salam is not welcomed!", "message", pld.ResponseMessage, "peer", util.FingerprintPeerID(initiator))
 			peer.UpdateStatus(peerset.StatusCodeBanned)
+		} else if err := pld.Capabilities.CheckCompatibility(handler.network.Name()); err != nil {
+			handler.logger.Info("Received a message from incompatible peer", "err", err, "peer", util.FingerprintPeerID(initiator))
+			peer.UpdateStatus(peerset.StatusCodeBanned)
+		} else {
+			peer.UpdateStatus(peerset.StatusCodeOK)
 		}
 	}
 
@@ -38,6 +41,12 @@ func (handler *aleykHandler) ParsPayload(p payload.Payload, initiator peer.ID) e
 	peer.UpdateNodeVersion(pld.NodeVersion)
 	peer.UpdatePublicKey(pld.PublicKey)
 	peer.UpdateInitialBlockDownload(util.IsFlagSet(pld.Flags, FlagInitialBlockDownload))
+	peer.UpdateCodecs(pld.Codecs)
+	caps := pld.Capabilities
+	if caps.IsLegacy() {
+		caps = handler.legacyCapabilities()
+	}
+	peer.UpdateCapabilities(caps)
 
 	handler.peerSet.UpdateMaxClaimedHeight(pld.Height)
 	handler.updateBlokchain()
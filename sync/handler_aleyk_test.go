@@ -16,7 +16,7 @@ func TestParsingAleykMessages(t *testing.T) {
 	t.Run("Alice receives Aleyk message from a Peer. Peer has less blocks than Alice", func(t *testing.T) {
 		_, pub, _ := crypto.GenerateTestKeyPair()
 		pid := util.RandomPeerID()
-		pld := payload.NewAleykPayload(tAlicePeerID, payload.ResponseCodeOK, "Welcome", "kitty", pub, 1, 0)
+		pld := payload.NewAleykPayload(tAlicePeerID, payload.ResponseCodeOK, "Welcome", "kitty", pub, 1, 0, tAliceSync.capabilities())
 		tAliceNet.ReceivingMessageFromOtherPeer(pid, pld)
 
 		peer := tAliceSync.peerSet.GetPeer(pid)
@@ -29,7 +29,7 @@ func TestParsingAleykMessages(t *testing.T) {
 		_, pub, _ := crypto.GenerateTestKeyPair()
 		pid := util.RandomPeerID()
 		claimedHeight := tAliceState.LastBlockHeight() + 5
-		pld := payload.NewAleykPayload(tAlicePeerID, payload.ResponseCodeOK, "Welcome", "kitty", pub, claimedHeight, 0)
+		pld := payload.NewAleykPayload(tAlicePeerID, payload.ResponseCodeOK, "Welcome", "kitty", pub, claimedHeight, 0, tAliceSync.capabilities())
 		tAliceNet.ReceivingMessageFromOtherPeer(pid, pld)
 
 		peer := tAliceSync.peerSet.GetPeer(pid)
@@ -40,17 +40,40 @@ func TestParsingAleykMessages(t *testing.T) {
 	t.Run("Alice receives not welcoming Aleyk message from a peer", func(t *testing.T) {
 		_, pub, _ := crypto.GenerateTestKeyPair()
 		pid := util.RandomPeerID()
-		pld := payload.NewAleykPayload(tAlicePeerID, payload.ResponseCodeRejected, "Not Welcome!", "kitty", pub, 1, 0)
+		pld := payload.NewAleykPayload(tAlicePeerID, payload.ResponseCodeRejected, "Not Welcome!", "kitty", pub, 1, 0, tAliceSync.capabilities())
 		tAliceNet.ReceivingMessageFromOtherPeer(pid, pld)
 
 		peer := tAliceSync.peerSet.GetPeer(pid)
 		assert.Equal(t, peer.Status(), peerset.StatusCodeBanned)
 	})
 
+	t.Run("Alice receives welcoming Aleyk message from a peer in other network", func(t *testing.T) {
+		_, pub, _ := crypto.GenerateTestKeyPair()
+		pid := util.RandomPeerID()
+		caps := tAliceSync.capabilities()
+		caps.Network = "zarb-other"
+		pld := payload.NewAleykPayload(tAlicePeerID, payload.ResponseCodeOK, "Welcome", "kitty", pub, 1, 0, caps)
+		tAliceNet.ReceivingMessageFromOtherPeer(pid, pld)
+
+		peer := tAliceSync.peerSet.GetPeer(pid)
+		assert.Equal(t, peer.Status(), peerset.StatusCodeBanned)
+	})
+
+	t.Run("Alice receives welcoming Aleyk message from a legacy peer without capabilities", func(t *testing.T) {
+		_, pub, _ := crypto.GenerateTestKeyPair()
+		pid := util.RandomPeerID()
+		pld := payload.NewAleykPayload(tAlicePeerID, payload.ResponseCodeOK, "Welcome", "kitty", pub, 1, 0, payload.Capabilities{})
+		tAliceNet.ReceivingMessageFromOtherPeer(pid, pld)
+
+		peer := tAliceSync.peerSet.GetPeer(pid)
+		assert.Equal(t, peer.Status(), peerset.StatusCodeOK)
+		assert.Equal(t, peer.Capabilities(), tAliceSync.legacyCapabilities())
+	})
+
 	t.Run("Alice receives Aleyk message from a peer but not targeted Alice", func(t *testing.T) {
 		_, pub, _ := crypto.GenerateTestKeyPair()
 		pid := util.RandomPeerID()
-		pld := payload.NewAleykPayload(util.RandomPeerID(), payload.ResponseCodeOK, "Welcome", "kitty", pub, 1, 0)
+		pld := payload.NewAleykPayload(util.RandomPeerID(), payload.ResponseCodeOK, "Welcome", "kitty", pub, 1, 0, tAliceSync.capabilities())
 		tAliceNet.ReceivingMessageFromOtherPeer(pid, pld)
 
 		peer := tAliceSync.peerSet.GetPeer(pid)
@@ -60,7 +83,7 @@ func TestParsingAleykMessages(t *testing.T) {
 	t.Run("Alice eavesdrops Aleyk messages", func(t *testing.T) {
 		_, pub, _ := crypto.GenerateTestKeyPair()
 		pid := util.RandomPeerID()
-		pld := payload.NewAleykPayload(util.RandomPeerID(), payload.ResponseCodeRejected, "Not Welcome!", "kitty", pub, 1, 0)
+		pld := payload.NewAleykPayload(util.RandomPeerID(), payload.ResponseCodeRejected, "Not Welcome!", "kitty", pub, 1, 0, tAliceSync.capabilities())
 		tAliceNet.ReceivingMessageFromOtherPeer(pid, pld)
 
 		peer := tAliceSync.peerSet.GetPeer(pid)
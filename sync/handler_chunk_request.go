@@ -0,0 +1,69 @@
+package sync
+
+import (
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/zarbchain/zarb-go/errors"
+	"github.com/zarbchain/zarb-go/sync/message"
+	"github.com/zarbchain/zarb-go/sync/message/payload"
+	"github.com/zarbchain/zarb-go/sync/peerset"
+)
+
+type chunkRequestHandler struct {
+	*synchronizer
+}
+
+func newChunkRequestHandler(sync *synchronizer) payloadHandler {
+	return &chunkRequestHandler{
+		sync,
+	}
+}
+
+func (handler *chunkRequestHandler) ParsPayload(p payload.Payload, initiator peer.ID) error {
+	pld := p.(*payload.ChunkRequestPayload)
+	handler.logger.Trace("Parsing chunk request payload", "pld", pld)
+
+	if pld.Target != handler.SelfID() {
+		return nil
+	}
+
+	if handler.peerSet.NumberOfOpenSessions() > handler.config.MaximumOpenSessions {
+		handler.logger.Warn("We are busy", "pld", pld, "pid", initiator)
+		response := payload.NewChunkResponsePayload(payload.ResponseCodeBusy, pld.SessionID, initiator, nil)
+		handler.sendTo(response, initiator)
+
+		return nil
+	}
+
+	peer := handler.peerSet.MustGetPeer(initiator)
+	if peer.Status() != peerset.StatusCodeOK {
+		response := payload.NewChunkResponsePayload(payload.ResponseCodeRejected, pld.SessionID, initiator, nil)
+		handler.sendTo(response, initiator)
+
+		return errors.Errorf(errors.ErrInvalidMessage, "Peer status is not ok: %v", peer.Status())
+	}
+
+	s := handler.state.LastSnapshot()
+	if s == nil || !s.Manifest().Hash().EqualsTo(pld.ManifestHash) {
+		handler.logger.Debug("We don't have this snapshot anymore", "pid", initiator, "manifest", pld.ManifestHash)
+		response := payload.NewChunkResponsePayload(payload.ResponseCodeRejected, pld.SessionID, initiator, nil)
+		handler.sendTo(response, initiator)
+
+		return nil
+	}
+
+	if pld.Index >= len(s.Chunks()) {
+		response := payload.NewChunkResponsePayload(payload.ResponseCodeRejected, pld.SessionID, initiator, nil)
+		handler.sendTo(response, initiator)
+
+		return errors.Errorf(errors.ErrInvalidMessage, "invalid chunk index: %v", pld.Index)
+	}
+
+	response := payload.NewChunkResponsePayload(payload.ResponseCodeOK, pld.SessionID, initiator, s.Chunks()[pld.Index])
+	handler.sendTo(response, initiator)
+
+	return nil
+}
+
+func (handler *chunkRequestHandler) PrepareMessage(p payload.Payload) *message.Message {
+	return message.NewMessage(handler.SelfID(), p)
+}
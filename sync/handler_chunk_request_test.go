@@ -0,0 +1,54 @@
+package sync
+
+import (
+	"testing"
+
+	"github.com/zarbchain/zarb-go/crypto"
+	"github.com/zarbchain/zarb-go/state/snapshot"
+	"github.com/zarbchain/zarb-go/sync/message/payload"
+	"github.com/zarbchain/zarb-go/util"
+)
+
+func TestChunkRequestMessages(t *testing.T) {
+	setup(t)
+	disableHeartbeat(t)
+
+	s, _, _ := snapshot.GenerateTestSnapshot(20)
+	tAliceState.Snapshot = s
+	manifestHash := s.Manifest().Hash()
+
+	t.Run("Alice received request from unknown peer. Request should be rejected", func(t *testing.T) {
+		pld := payload.NewChunkRequestPayload(1, tAlicePeerID, manifestHash, 0)
+		tAliceNet.ReceivingMessageFromOtherPeer(util.RandomPeerID(), pld)
+
+		shouldPublishPayloadWithThisTypeAndResponseCode(t, tAliceNet, payload.PayloadTypeChunkResponse, payload.ResponseCodeRejected)
+	})
+
+	t.Run("Alice should not pay attention to requests for other peers", func(t *testing.T) {
+		pld := payload.NewChunkRequestPayload(1, util.RandomPeerID(), manifestHash, 0)
+		tAliceNet.ReceivingMessageFromOtherPeer(tBobPeerID, pld)
+
+		shouldNotPublishPayloadWithThisType(t, tAliceNet, payload.PayloadTypeChunkResponse)
+	})
+
+	t.Run("Alice doesn't have the requested snapshot", func(t *testing.T) {
+		pld := payload.NewChunkRequestPayload(1, tAlicePeerID, crypto.GenerateTestHash(), 0)
+		tAliceNet.ReceivingMessageFromOtherPeer(tBobPeerID, pld)
+
+		shouldPublishPayloadWithThisTypeAndResponseCode(t, tAliceNet, payload.PayloadTypeChunkResponse, payload.ResponseCodeRejected)
+	})
+
+	t.Run("Invalid chunk index", func(t *testing.T) {
+		pld := payload.NewChunkRequestPayload(1, tAlicePeerID, manifestHash, 100)
+		tAliceNet.ReceivingMessageFromOtherPeer(tBobPeerID, pld)
+
+		shouldPublishPayloadWithThisTypeAndResponseCode(t, tAliceNet, payload.PayloadTypeChunkResponse, payload.ResponseCodeRejected)
+	})
+
+	t.Run("Alice sends the chunk", func(t *testing.T) {
+		pld := payload.NewChunkRequestPayload(1, tAlicePeerID, manifestHash, 0)
+		tAliceNet.ReceivingMessageFromOtherPeer(tBobPeerID, pld)
+
+		shouldPublishPayloadWithThisTypeAndResponseCode(t, tAliceNet, payload.PayloadTypeChunkResponse, payload.ResponseCodeOK)
+	})
+}
@@ -0,0 +1,55 @@
+package sync
+
+import (
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/zarbchain/zarb-go/errors"
+	"github.com/zarbchain/zarb-go/sync/message"
+	"github.com/zarbchain/zarb-go/sync/message/payload"
+	"github.com/zarbchain/zarb-go/util"
+)
+
+type chunkResponseHandler struct {
+	*synchronizer
+}
+
+func newChunkResponseHandler(sync *synchronizer) payloadHandler {
+	return &chunkResponseHandler{
+		sync,
+	}
+}
+
+func (handler *chunkResponseHandler) ParsPayload(p payload.Payload, initiator peer.ID) error {
+	pld := p.(*payload.ChunkResponsePayload)
+	handler.logger.Trace("Parsing chunk response payload", "pld", pld)
+
+	if pld.Target != handler.SelfID() {
+		return nil
+	}
+	handler.peerSet.CloseSession(pld.SessionID)
+
+	if pld.ResponseCode != payload.ResponseCodeOK {
+		handler.logger.Warn("Chunk request is rejected", "pid", util.FingerprintPeerID(initiator), "response", pld.ResponseCode)
+		handler.stateSync.RemoveProvider(initiator)
+		handler.updateBlokchain()
+
+		return nil
+	}
+
+	if err := handler.stateSync.AddChunk(pld.Chunk); err != nil {
+		handler.stateSync.RemoveProvider(initiator)
+		handler.updateBlokchain()
+
+		return errors.Errorf(errors.ErrInvalidMessage, "invalid chunk: %v", err)
+	}
+	handler.tryRestoreSnapshot()
+	handler.updateBlokchain()
+
+	return nil
+}
+
+func (handler *chunkResponseHandler) PrepareMessage(p payload.Payload) *message.Message {
+	msg := message.NewMessage(handler.SelfID(), p)
+	msg.Codec = handler.codecFor(p.(*payload.ChunkResponsePayload).Target)
+
+	return msg
+}
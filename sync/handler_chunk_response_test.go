@@ -0,0 +1,95 @@
+package sync
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zarbchain/zarb-go/block"
+	"github.com/zarbchain/zarb-go/crypto"
+	"github.com/zarbchain/zarb-go/state/snapshot"
+	"github.com/zarbchain/zarb-go/sync/message/payload"
+	"github.com/zarbchain/zarb-go/sync/statesync"
+)
+
+func TestStateSync(t *testing.T) {
+	setup(t)
+	disableHeartbeat(t)
+
+	snapshot.ChunkSize = 10
+	defer func() { snapshot.ChunkSize = 1000 }()
+
+	// Bob has a snapshot at height 20 and the block 21 certifies it
+	s, b, c := snapshot.GenerateTestSnapshot(20)
+	tBobState.Snapshot = s
+	tBobState.AddBlock(21, b, nil)
+	tBobState.LastBlockCertificate = c
+	tBobState.GenHash = s.Manifest().GenesisHash()
+	tAliceState.GenHash = s.Manifest().GenesisHash()
+	tAliceSync.peerSet.GetPeer(tBobPeerID).UpdateCapabilities(tBobSync.capabilities())
+
+	// Alice is an empty node
+	tAliceSync.cache.Clear()
+	tAliceState.Store.Blocks = make(map[int]*block.Block)
+	tAliceSync.stateSync = statesync.NewStateSync(true, 21, b.Hash())
+	tAliceConsensus.Scheduled = false
+
+	joinBobToCommittee(t)
+	tBobBroadcastCh <- payload.NewBlockAnnouncePayload(21, b, c)
+	shouldPublishPayloadWithThisType(t, tBobNet, payload.PayloadTypeBlockAnnounce)
+
+	shouldPublishPayloadWithThisType(t, tAliceNet, payload.PayloadTypeQuerySnapshot)
+	shouldPublishPayloadWithThisTypeAndResponseCode(t, tBobNet, payload.PayloadTypeSnapshot, payload.ResponseCodeOK)
+
+	// 6 accounts, 4 validators, 20 blocks and 20 transactions in 5 chunks
+	assert.Equal(t, s.Manifest().TotalChunks(), 5)
+	for i := 0; i < s.Manifest().TotalChunks(); i++ {
+		shouldPublishPayloadWithThisType(t, tAliceNet, payload.PayloadTypeChunkRequest)
+		shouldPublishPayloadWithThisTypeAndResponseCode(t, tBobNet, payload.PayloadTypeChunkResponse, payload.ResponseCodeOK)
+	}
+
+	assert.False(t, tAliceSync.stateSync.IsActive())
+	assert.Equal(t, tAliceState.LastBlockHeight(), 21)
+	assert.Equal(t, tAliceState.LastBlockHash(), b.Hash())
+	assert.False(t, tAliceSync.peerSet.HasAnyOpenSession())
+}
+
+func TestStateSyncFallback(t *testing.T) {
+	setup(t)
+	disableHeartbeat(t)
+
+	// Bob offered snapshots in the handshake, but he has no snapshot anymore
+	tAliceSync.cache.Clear()
+	tAliceState.Store.Blocks = make(map[int]*block.Block)
+	tAliceSync.stateSync = statesync.NewStateSync(true, 2, crypto.GenerateTestHash())
+	caps := tBobSync.capabilities()
+	caps.Services |= payload.ServiceSnapshots
+	tAliceSync.peerSet.GetPeer(tBobPeerID).UpdateCapabilities(caps)
+
+	joinBobToCommittee(t)
+	addMoreBlocksForBobAndAnnounceLastBlock(t, 1)
+	shouldPublishPayloadWithThisType(t, tBobNet, payload.PayloadTypeBlockAnnounce)
+
+	shouldPublishPayloadWithThisType(t, tAliceNet, payload.PayloadTypeQuerySnapshot)
+	shouldPublishPayloadWithThisTypeAndResponseCode(t, tBobNet, payload.PayloadTypeSnapshot, payload.ResponseCodeRejected)
+
+	shouldPublishPayloadWithThisType(t, tAliceNet, payload.PayloadTypeDownloadRequest)
+	assert.False(t, tAliceSync.stateSync.IsActive())
+}
+
+func TestStateSyncNoProvider(t *testing.T) {
+	setup(t)
+	disableHeartbeat(t)
+
+	// Bob doesn't offer snapshots, Alice should download blocks without querying him
+	tAliceSync.cache.Clear()
+	tAliceState.Store.Blocks = make(map[int]*block.Block)
+	tAliceSync.stateSync = statesync.NewStateSync(true, 2, crypto.GenerateTestHash())
+
+	joinBobToCommittee(t)
+	addMoreBlocksForBobAndAnnounceLastBlock(t, 1)
+	shouldPublishPayloadWithThisType(t, tBobNet, payload.PayloadTypeBlockAnnounce)
+
+	shouldPublishPayloadWithThisType(t, tAliceNet, payload.PayloadTypeDownloadRequest)
+	assert.False(t, tAliceSync.stateSync.Queried())
+	assert.False(t, tAliceSync.stateSync.IsActive())
+}
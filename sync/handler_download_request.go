@@ -6,6 +6,7 @@ import (
 	"github.com/zarbchain/zarb-go/sync/message"
 	"github.com/zarbchain/zarb-go/sync/message/payload"
 	"github.com/zarbchain/zarb-go/sync/peerset"
+	"github.com/zarbchain/zarb-go/util"
 )
 
 type downloadRequestHandler struct {
@@ -26,10 +27,17 @@ func (handler *downloadRequestHandler) ParsPayload(p payload.Payload, initiator
 		return nil
 	}
 
+	if !handler.firewall.Allow(initiator, pld.Type()) {
+		handler.logger.Warn("Peer exceeds the rate limit", "pld", pld, "pid", initiator)
+		response := payload.NewDownloadResponsePayload(payload.ResponseCodeBusy, pld.SessionID, initiator, 0, nil, nil)
+		handler.sendTo(response, initiator)
+		return nil
+	}
+
 	if handler.peerSet.NumberOfOpenSessions() > handler.config.MaximumOpenSessions {
 		handler.logger.Warn("We are busy", "pld", pld, "pid", initiator)
 		response := payload.NewDownloadResponsePayload(payload.ResponseCodeBusy, pld.SessionID, initiator, 0, nil, nil)
-		handler.broadcast(response)
+		handler.sendTo(response, initiator)
 
 		return nil
 	}
@@ -37,21 +45,28 @@ func (handler *downloadRequestHandler) ParsPayload(p payload.Payload, initiator
 	peer := handler.peerSet.MustGetPeer(initiator)
 	if peer.Status() != peerset.StatusCodeOK {
 		response := payload.NewDownloadResponsePayload(payload.ResponseCodeRejected, pld.SessionID, initiator, 0, nil, nil)
-		handler.broadcast(response)
+		handler.sendTo(response, initiator)
 
 		return errors.Errorf(errors.ErrInvalidMessage, "Peer status is not ok: %v", peer.Status())
 	}
 
 	if peer.Height() > pld.From {
 		response := payload.NewDownloadResponsePayload(payload.ResponseCodeRejected, pld.SessionID, initiator, 0, nil, nil)
-		handler.broadcast(response)
+		handler.sendTo(response, initiator)
 
 		return errors.Errorf(errors.ErrInvalidMessage, "Peer request for blocks that already has: %v", pld.From)
 	}
 
+	if peer.HasServed(pld.From, pld.To) {
+		response := payload.NewDownloadResponsePayload(payload.ResponseCodeRejected, pld.SessionID, initiator, 0, nil, nil)
+		handler.sendTo(response, initiator)
+
+		return errors.Errorf(errors.ErrInvalidMessage, "Peer request for blocks that we have sent before: %v-%v", pld.From, pld.To)
+	}
+
 	if pld.To-pld.From > LatestBlockInterval {
 		response := payload.NewDownloadResponsePayload(payload.ResponseCodeRejected, pld.SessionID, initiator, 0, nil, nil)
-		handler.broadcast(response)
+		handler.sendTo(response, initiator)
 
 		return errors.Errorf(errors.ErrInvalidMessage, "peer request interval is not acceptable: %v", pld.To-pld.From)
 	}
@@ -70,18 +85,21 @@ func (handler *downloadRequestHandler) ParsPayload(p payload.Payload, initiator
 		}
 
 		response := payload.NewDownloadResponsePayload(payload.ResponseCodeMoreBlocks, pld.SessionID, initiator, from, blocks, trxs)
-		handler.broadcast(response)
+		handler.sendTo(response, initiator)
 
 		from += len(blocks)
 		if from >= pld.To {
 			break
 		}
 	}
-	// To avoid sending blocks again, we update height for this peer
-	peer.UpdateHeight(from - 1)
+	// To avoid sending blocks again, we keep the blocks that we have sent to this peer.
+	// The extra block of the last packet is not counted, it is the first block of the next chunk.
+	if from > pld.From {
+		peer.AddServed(pld.From, util.Min(from-1, pld.To))
+	}
 
 	response := payload.NewDownloadResponsePayload(payload.ResponseCodeNoMoreBlocks, pld.SessionID, initiator, 0, nil, nil)
-	handler.broadcast(response)
+	handler.sendTo(response, initiator)
 
 	return nil
 }
@@ -23,7 +23,7 @@ func TestDownloadBlocksRequestMessages(t *testing.T) {
 
 		t.Run("Alice handshakes with the new peer", func(t *testing.T) {
 			_, pub, _ := crypto.GenerateTestKeyPair()
-			pld := payload.NewSalamPayload("new-peer", pub, tAliceState.GenHash, 0, 0)
+			pld := payload.NewSalamPayload("new-peer", pub, tAliceState.GenHash, 0, 0, tAliceSync.capabilities())
 			tAliceNet.ReceivingMessageFromOtherPeer(pid, pld)
 
 			shouldPublishPayloadWithThisType(t, tAliceNet, payload.PayloadTypeAleyk)
@@ -56,6 +56,14 @@ func TestDownloadBlocksRequestMessages(t *testing.T) {
 
 			shouldPublishPayloadWithThisTypeAndResponseCode(t, tAliceNet, payload.PayloadTypeDownloadResponse, payload.ResponseCodeRejected)
 		})
+
+		t.Run("Peer requests the next chunk from Alice, Alice should send it.", func(t *testing.T) {
+			pld := payload.NewDownloadRequestPayload(7, tAlicePeerID, 11, 20)
+			tAliceNet.ReceivingMessageFromOtherPeer(pid, pld)
+
+			shouldPublishPayloadWithThisTypeAndResponseCode(t, tAliceNet, payload.PayloadTypeDownloadResponse, payload.ResponseCodeMoreBlocks)
+			shouldPublishPayloadWithThisTypeAndResponseCode(t, tAliceNet, payload.PayloadTypeDownloadResponse, payload.ResponseCodeNoMoreBlocks)
+		})
 	})
 
 	t.Run("Alice doesn't have request blocks", func(t *testing.T) {
@@ -2,14 +2,17 @@ package sync
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/zarbchain/zarb-go/block"
 	"github.com/zarbchain/zarb-go/sync/message/payload"
 	"github.com/zarbchain/zarb-go/sync/peerset"
 )
 
 func TestDownloadBlocks(t *testing.T) {
+	defer func(interval int) { LatestBlockInterval = interval }(LatestBlockInterval)
 	LatestBlockInterval = 30
 	setup(t)
 	disableHeartbeat(t)
@@ -26,28 +29,27 @@ func TestDownloadBlocks(t *testing.T) {
 	addMoreBlocksForBobAndAnnounceLastBlock(t, 79) // total blocks: 21+79 = 100
 	shouldPublishPayloadWithThisType(t, tBobNet, payload.PayloadTypeBlockAnnounce)
 
+	// Alice downloads blocks 22-70 in three chunks from Bob, two of them at the same time.
+	// The rest of blocks are asked by the latest blocks request.
 	shouldPublishPayloadWithThisType(t, tAliceNet, payload.PayloadTypeDownloadRequest)
-	shouldPublishPayloadWithThisType(t, tBobNet, payload.PayloadTypeDownloadResponse) // 1-10
-	shouldPublishPayloadWithThisType(t, tBobNet, payload.PayloadTypeDownloadResponse) // 11-20
-	shouldPublishPayloadWithThisType(t, tBobNet, payload.PayloadTypeDownloadResponse) // 21-31 (one extra block)
-	shouldPublishPayloadWithThisType(t, tBobNet, payload.PayloadTypeDownloadResponse) // NoMoreBlock
-
-	shouldPublishPayloadWithThisType(t, tAliceNet, payload.PayloadTypeDownloadRequest)
-	shouldPublishPayloadWithThisType(t, tBobNet, payload.PayloadTypeDownloadResponse) // 40-49
-	shouldPublishPayloadWithThisType(t, tBobNet, payload.PayloadTypeDownloadResponse) // 50-59
-	shouldPublishPayloadWithThisType(t, tBobNet, payload.PayloadTypeDownloadResponse) // 60-70 (one extra block)
-	shouldPublishPayloadWithThisType(t, tBobNet, payload.PayloadTypeDownloadResponse) // NoMoreBlock
-
 	shouldPublishPayloadWithThisType(t, tAliceNet, payload.PayloadTypeDownloadRequest)
-	shouldPublishPayloadWithThisType(t, tBobNet, payload.PayloadTypeDownloadResponse) // 61-70
-	shouldPublishPayloadWithThisType(t, tBobNet, payload.PayloadTypeDownloadResponse) // 71-80
-	shouldPublishPayloadWithThisType(t, tBobNet, payload.PayloadTypeDownloadResponse) // 81-91 (one extra block)
-	shouldPublishPayloadWithThisType(t, tBobNet, payload.PayloadTypeDownloadResponse) // NoMoreBlock
-
-	// Latest block requests
-	shouldPublishPayloadWithThisType(t, tAliceNet, payload.PayloadTypeLatestBlocksRequest)
-	shouldPublishPayloadWithThisType(t, tBobNet, payload.PayloadTypeLatestBlocksResponse) // 91-100
-	shouldPublishPayloadWithThisType(t, tBobNet, payload.PayloadTypeLatestBlocksResponse) // Synced
+	assert.Equal(t, tAliceSync.peerSet.NumberOfOpenSessions(), 2)
+	progress := tAliceSync.DownloadProgress()
+	assert.True(t, progress.Downloading)
+	assert.Equal(t, progress.TargetHeight, 70)
+
+	for tAliceState.LastBlockHeight() < tBobState.LastBlockHeight() {
+		select {
+		case msg := <-tAliceNet.BroadcastCh:
+			tAliceNet.SendMessageToOthePeer(msg)
+		case msg := <-tBobNet.BroadcastCh:
+			tBobNet.SendMessageToOthePeer(msg)
+		case <-time.After(2 * time.Second):
+			require.FailNow(t, "Timeout", "height: %v", tAliceState.LastBlockHeight())
+		}
+	}
+	shouldPublishPayloadWithThisTypeAndResponseCode(t, tBobNet, payload.PayloadTypeLatestBlocksResponse, payload.ResponseCodeSynced)
+	assert.False(t, tAliceSync.DownloadProgress().Downloading)
 
 	assert.Equal(t, tAliceState.LastBlockHash(), tBobState.LastBlockHash())
 	assert.Equal(t, tAliceState.LastBlockHeight(), tBobState.LastBlockHeight())
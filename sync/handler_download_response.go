@@ -21,8 +21,6 @@ func (handler *downloadResponseHandler) ParsPayload(p payload.Payload, initiator
 	pld := p.(*payload.DownloadResponsePayload)
 	handler.logger.Trace("Parsing download response payload", "pld", pld)
 
-	// TODO: to increase performance we can watch block stream
-	// And add them into cache if they are close to our height
 	if pld.Target != handler.SelfID() {
 		return nil
 	}
@@ -30,9 +28,8 @@ func (handler *downloadResponseHandler) ParsPayload(p payload.Payload, initiator
 	if pld.IsRequestNotProcessed() {
 		handler.logger.Warn("Download blocks request is rejected", "pid", util.FingerprintPeerID(initiator), "response", pld.ResponseCode)
 	} else {
-		handler.cache.AddBlocks(pld.From, pld.Blocks)
 		handler.cache.AddTransactions(pld.Transactions)
-		handler.tryCommitBlocks()
+		handler.addDownloadedBlocks(initiator, pld.SessionID, pld.From, pld.Blocks)
 	}
 	handler.updateSession(pld.ResponseCode, pld.SessionID, pld.Target)
 
@@ -41,7 +38,7 @@ func (handler *downloadResponseHandler) ParsPayload(p payload.Payload, initiator
 
 func (handler *downloadResponseHandler) PrepareMessage(p payload.Payload) *message.Message {
 	msg := message.NewMessage(handler.SelfID(), p)
-	msg.CompressIt()
+	msg.Codec = handler.codecFor(p.(*payload.DownloadResponsePayload).Target)
 
 	return msg
 }
@@ -26,10 +26,17 @@ func (handler *latestBlocksRequestHandler) ParsPayload(p payload.Payload, initia
 		return nil
 	}
 
+	if !handler.firewall.Allow(initiator, pld.Type()) {
+		handler.logger.Warn("Peer exceeds the rate limit", "pld", pld, "pid", initiator)
+		response := payload.NewLatestBlocksResponsePayload(payload.ResponseCodeBusy, pld.SessionID, initiator, 0, nil, nil, nil)
+		handler.sendTo(response, initiator)
+		return nil
+	}
+
 	if handler.peerSet.NumberOfOpenSessions() > handler.config.MaximumOpenSessions {
 		handler.logger.Warn("We are busy", "pld", pld, "pid", initiator)
 		response := payload.NewLatestBlocksResponsePayload(payload.ResponseCodeBusy, pld.SessionID, initiator, 0, nil, nil, nil)
-		handler.broadcast(response)
+		handler.sendTo(response, initiator)
 
 		return nil
 	}
@@ -37,14 +44,14 @@ func (handler *latestBlocksRequestHandler) ParsPayload(p payload.Payload, initia
 	peer := handler.peerSet.MustGetPeer(initiator)
 	if peer.Status() != peerset.StatusCodeOK {
 		response := payload.NewLatestBlocksResponsePayload(payload.ResponseCodeRejected, pld.SessionID, initiator, 0, nil, nil, nil)
-		handler.broadcast(response)
+		handler.sendTo(response, initiator)
 
 		return errors.Errorf(errors.ErrInvalidMessage, "Peer status is not ok: %v", peer.Status())
 	}
 
 	if peer.Height() > pld.From {
 		response := payload.NewLatestBlocksResponsePayload(payload.ResponseCodeRejected, pld.SessionID, initiator, 0, nil, nil, nil)
-		handler.broadcast(response)
+		handler.sendTo(response, initiator)
 
 		return errors.Errorf(errors.ErrInvalidMessage, "Peer request for blocks that already has: %v", pld.From)
 	}
@@ -52,7 +59,7 @@ func (handler *latestBlocksRequestHandler) ParsPayload(p payload.Payload, initia
 	ourHeight := handler.state.LastBlockHeight()
 	if pld.From < ourHeight-LatestBlockInterval {
 		response := payload.NewLatestBlocksResponsePayload(payload.ResponseCodeRejected, pld.SessionID, initiator, 0, nil, nil, nil)
-		handler.broadcast(response)
+		handler.sendTo(response, initiator)
 
 		return errors.Errorf(errors.ErrInvalidMessage, "the request height is not acceptable: %v", pld.From)
 	}
@@ -67,7 +74,7 @@ func (handler *latestBlocksRequestHandler) ParsPayload(p payload.Payload, initia
 		}
 
 		response := payload.NewLatestBlocksResponsePayload(payload.ResponseCodeMoreBlocks, pld.SessionID, initiator, from, blocks, trxs, nil)
-		handler.broadcast(response)
+		handler.sendTo(response, initiator)
 
 		from += len(blocks)
 	}
@@ -76,7 +83,7 @@ func (handler *latestBlocksRequestHandler) ParsPayload(p payload.Payload, initia
 
 	lastCertificate := handler.state.LastCertificate()
 	response := payload.NewLatestBlocksResponsePayload(payload.ResponseCodeSynced, pld.SessionID, initiator, from, nil, nil, lastCertificate)
-	handler.broadcast(response)
+	handler.sendTo(response, initiator)
 
 	return nil
 }
@@ -13,17 +13,27 @@ import (
 func TestSessionTimeout(t *testing.T) {
 	tBobConfig.SessionTimeout = 200 * time.Millisecond
 	setup(t)
+	disableHeartbeat(t)
 
 	t.Run("An unknown peers claims has more blocks. Alice requests for more blocks. Alice doesn't get any response. Session should be closed", func(t *testing.T) {
 		_, pub, _ := crypto.GenerateTestKeyPair()
-		pld := payload.NewAleykPayload(tAlicePeerID, payload.ResponseCodeOK, "ok", "devil", pub, 6666, 0x1) // InitialBlockDownload:  true
-		tAliceNet.ReceivingMessageFromOtherPeer(util.RandomPeerID(), pld)
+		pld := payload.NewAleykPayload(tAlicePeerID, payload.ResponseCodeOK, "ok", "devil", pub, 6666, 0x1, tAliceSync.capabilities()) // InitialBlockDownload:  true
+		pid := util.RandomPeerID()
+		tAliceNet.ReceivingMessageFromOtherPeer(pid, pld)
 
 		shouldPublishPayloadWithThisType(t, tAliceNet, payload.PayloadTypeDownloadRequest)
+		assert.Equal(t, tAliceNet.SentTo, pid)
 
 		assert.True(t, tAliceSync.peerSet.HasAnyOpenSession())
 		time.Sleep(2 * tAliceConfig.SessionTimeout)
 		assert.False(t, tAliceSync.peerSet.HasAnyOpenSession())
+
+		t.Run("Alice requests the blocks again", func(t *testing.T) {
+			tAliceSync.penalizeUnansweredSessions()
+
+			shouldPublishPayloadWithThisType(t, tAliceNet, payload.PayloadTypeDownloadRequest)
+			assert.True(t, tAliceSync.peerSet.HasAnyOpenSession())
+		})
 	})
 }
 
@@ -42,7 +52,7 @@ func TestLatestBlocksRequestMessages(t *testing.T) {
 
 		t.Run("Bob handshakes with the new peer", func(t *testing.T) {
 			_, pub, _ := crypto.GenerateTestKeyPair()
-			pld := payload.NewSalamPayload("new-peer", pub, tBobState.GenHash, 0, 0)
+			pld := payload.NewSalamPayload("new-peer", pub, tBobState.GenHash, 0, 0, tAliceSync.capabilities())
 			tBobNet.ReceivingMessageFromOtherPeer(pid, pld)
 
 			shouldPublishPayloadWithThisType(t, tBobNet, payload.PayloadTypeAleyk)
@@ -71,6 +81,8 @@ func TestLatestBlocksRequestMessages(t *testing.T) {
 
 		shouldPublishPayloadWithThisTypeAndResponseCode(t, tBobNet, payload.PayloadTypeLatestBlocksResponse, payload.ResponseCodeMoreBlocks)
 		shouldPublishPayloadWithThisTypeAndResponseCode(t, tBobNet, payload.PayloadTypeLatestBlocksResponse, payload.ResponseCodeSynced)
+		// Response should be sent directly to the peer
+		assert.Equal(t, tBobNet.SentTo, pid)
 
 		t.Run("Peer requests from Bob to send the blocks again, Bob should reject it.", func(t *testing.T) {
 			tBobNet.ReceivingMessageFromOtherPeer(pid, pld)
@@ -103,3 +115,23 @@ func TestLatestBlocksRequestMessages(t *testing.T) {
 		assert.Equal(t, tAliceSync.peerSet.NumberOfOpenSessions(), 1, "Alice should close the session")
 	})
 }
+
+func TestLatestBlocksRequestRateLimit(t *testing.T) {
+	tBobConfig.Firewall.Enabled = true
+	defer func() { tBobConfig.Firewall.Enabled = false }()
+	setup(t)
+	disableHeartbeat(t)
+
+	pid := util.RandomPeerID()
+	pld := payload.NewLatestBlocksRequestPayload(6, tBobPeerID, 100, 105)
+
+	t.Run("Bob responds the requests until the peer exceeds the rate limit", func(t *testing.T) {
+		for i := 0; i < tBobConfig.Firewall.LatestBlocksRequestLimit.Burst; i++ {
+			tBobNet.ReceivingMessageFromOtherPeer(pid, pld)
+			shouldPublishPayloadWithThisTypeAndResponseCode(t, tBobNet, payload.PayloadTypeLatestBlocksResponse, payload.ResponseCodeRejected)
+		}
+
+		tBobNet.ReceivingMessageFromOtherPeer(pid, pld)
+		shouldPublishPayloadWithThisTypeAndResponseCode(t, tBobNet, payload.PayloadTypeLatestBlocksResponse, payload.ResponseCodeBusy)
+	})
+}
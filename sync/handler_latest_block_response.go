@@ -20,8 +20,6 @@ func (handler *latestBlocksResponseHandler) ParsPayload(p payload.Payload, initi
 	pld := p.(*payload.LatestBlocksResponsePayload)
 	handler.logger.Trace("Parsing latest blocks response payload", "pld", pld)
 
-	// TODO: to increase performance we can watch block stream
-	// And add them into cache if they are close to our height
 	if pld.Target != handler.SelfID() {
 		return nil
 	}
@@ -30,9 +28,8 @@ func (handler *latestBlocksResponseHandler) ParsPayload(p payload.Payload, initi
 		handler.logger.Warn("Query blocks request is rejected", "pid", initiator, "response", pld.ResponseCode)
 	} else {
 		handler.cache.AddCertificate(pld.LastCertificate)
-		handler.cache.AddBlocks(pld.From, pld.Blocks)
 		handler.cache.AddTransactions(pld.Transactions)
-		handler.tryCommitBlocks()
+		handler.checkReceivedBlocks(initiator, pld.SessionID, pld.From, pld.Blocks)
 	}
 	handler.updateSession(pld.ResponseCode, pld.SessionID, pld.Target)
 
@@ -41,7 +38,7 @@ func (handler *latestBlocksResponseHandler) ParsPayload(p payload.Payload, initi
 
 func (handler *latestBlocksResponseHandler) PrepareMessage(p payload.Payload) *message.Message {
 	msg := message.NewMessage(handler.SelfID(), p)
-	msg.CompressIt()
+	msg.Codec = handler.codecFor(p.(*payload.LatestBlocksResponsePayload).Target)
 
 	return msg
 }
@@ -21,7 +21,10 @@ func (handler *proposalHandler) ParsPayload(p payload.Payload, initiator peer.ID
 	handler.logger.Trace("Parsing proposal payload", "pld", pld)
 
 	handler.cache.AddProposal(pld.Proposal)
-	handler.consensus.SetProposal(pld.Proposal)
+	if len(pld.Transactions) > 0 {
+		handler.addTransactions(pld.Transactions)
+	}
+	handler.setProposal(pld.Proposal)
 
 	return nil
 }
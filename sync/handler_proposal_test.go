@@ -1,14 +1,47 @@
 package sync
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zarbchain/zarb-go/block"
 	"github.com/zarbchain/zarb-go/consensus/proposal"
+	"github.com/zarbchain/zarb-go/crypto"
+	"github.com/zarbchain/zarb-go/network"
 	"github.com/zarbchain/zarb-go/sync/message/payload"
+	"github.com/zarbchain/zarb-go/tx"
 	"github.com/zarbchain/zarb-go/util"
 )
 
+func generateTestProposalWithTransactions(height, round int) (*proposal.Proposal, []*tx.Tx) {
+	addr, _, pv := crypto.GenerateTestKeyPair()
+	b, trxs := block.GenerateTestBlock(&addr, nil)
+	p := proposal.NewProposal(height, round, b)
+	sig := pv.Sign(p.SignBytes())
+	p.SetSignature(sig)
+	return p, trxs
+}
+
+// waitForPayload returns the first published payload with this type, without sending it to the other peer.
+func waitForPayload(t *testing.T, net *network.MockNetwork, payloadType payload.Type) payload.Payload {
+	timeout := time.NewTimer(2 * time.Second)
+
+	for {
+		select {
+		case <-timeout.C:
+			require.NoError(t, fmt.Errorf("waitForPayload %v: Timeout", payloadType))
+			return nil
+		case msg := <-net.BroadcastCh:
+			if msg.Payload.Type() == payloadType {
+				return msg.Payload
+			}
+		}
+	}
+}
+
 func TestParsingProposalMessages(t *testing.T) {
 	setup(t)
 
@@ -22,3 +55,65 @@ func TestParsingProposalMessages(t *testing.T) {
 		assert.NotNil(t, tAliceConsensus.RoundProposal(0))
 	})
 }
+
+func TestCompactProposal(t *testing.T) {
+	setup(t)
+	disableHeartbeat(t)
+	joinAliceToCommittee(t)
+
+	consensusHeight := tAliceState.LastBlockHeight() + 1
+
+	t.Run("Alice has all the transactions. She sends the proposal to consensus", func(t *testing.T) {
+		p, trxs := generateTestProposalWithTransactions(consensusHeight, 0)
+		for _, trx := range trxs {
+			assert.NoError(t, tAliceState.AddPendingTx(trx))
+		}
+
+		tAliceNet.ReceivingMessageFromOtherPeer(tBobPeerID, payload.NewProposalPayload(p))
+		assert.Equal(t, tAliceConsensus.RoundProposal(0).Hash(), p.Hash())
+		shouldNotPublishPayloadWithThisType(t, tAliceNet, payload.PayloadTypeQueryTransactions)
+	})
+
+	t.Run("Alice misses some transactions. She queries them at once", func(t *testing.T) {
+		p, trxs := generateTestProposalWithTransactions(consensusHeight, 1)
+		assert.NoError(t, tAliceState.AddPendingTx(trxs[0]))
+
+		tAliceNet.ReceivingMessageFromOtherPeer(tBobPeerID, payload.NewProposalPayload(p))
+		assert.Nil(t, tAliceConsensus.RoundProposal(1))
+
+		pld := waitForPayload(t, tAliceNet, payload.PayloadTypeQueryTransactions)
+		assert.Equal(t, len(pld.(*payload.QueryTransactionsPayload).IDs), 3)
+
+		tAliceNet.ReceivingMessageFromOtherPeer(tBobPeerID, payload.NewTransactionsPayload(trxs[1:]))
+		assert.Equal(t, tAliceConsensus.RoundProposal(1).Hash(), p.Hash())
+		assert.Zero(t, tAliceSync.relay.Len())
+	})
+
+	t.Run("Alice doesn't receive the transactions. She queries the full proposal", func(t *testing.T) {
+		p, trxs := generateTestProposalWithTransactions(consensusHeight, 2)
+		for _, trx := range trxs {
+			assert.NoError(t, tBobState.AddPendingTx(trx))
+		}
+		tBobConsensus.Round = 2
+		tBobConsensus.SetProposal(p)
+
+		// The query is not delivered to Bob
+		tAliceNet.ReceivingMessageFromOtherPeer(tBobPeerID, payload.NewProposalPayload(p))
+		waitForPayload(t, tAliceNet, payload.PayloadTypeQueryTransactions)
+		assert.Nil(t, tAliceConsensus.RoundProposal(2))
+
+		time.Sleep(2 * tAliceConfig.RelayTimeout)
+		pld := waitForPayload(t, tAliceNet, payload.PayloadTypeQueryProposal)
+		assert.True(t, pld.(*payload.QueryProposalPayload).Full)
+
+		tBobNet.ReceivingMessageFromOtherPeer(tAlicePeerID, pld)
+		pld = waitForPayload(t, tBobNet, payload.PayloadTypeProposal)
+		assert.Equal(t, len(pld.(*payload.ProposalPayload).Transactions), 4)
+
+		tAliceNet.ReceivingMessageFromOtherPeer(tBobPeerID, pld)
+		assert.Equal(t, tAliceConsensus.RoundProposal(2).Hash(), p.Hash())
+		for _, trx := range trxs {
+			assert.NotNil(t, tAliceState.PendingTx(trx.ID()))
+		}
+	})
+}
@@ -29,7 +29,13 @@ func (handler *queryProposalHandler) ParsPayload(p payload.Payload, initiator pe
 
 		p := handler.consensus.RoundProposal(pld.Round)
 		if p != nil {
-			response := payload.NewProposalPayload(p)
+			var response payload.Payload
+			if pld.Full {
+				trxs := handler.prepareTransactions(p.Block().TxIDs().IDs())
+				response = payload.NewFullProposalPayload(p, trxs)
+			} else {
+				response = payload.NewProposalPayload(p)
+			}
 			handler.broadcast(response)
 		}
 	}
@@ -41,10 +47,13 @@ func (handler *queryProposalHandler) PrepareMessage(p payload.Payload) *message.
 	pld := p.(*payload.QueryProposalPayload)
 	proposal := handler.consensus.RoundProposal(pld.Round)
 	if proposal == nil {
-		proposal = handler.cache.GetProposal(pld.Height, pld.Round)
+		// For full proposal, we have the proposal but not its transactions
+		if !pld.Full {
+			proposal = handler.cache.GetProposal(pld.Height, pld.Round)
+		}
 		if proposal != nil {
 			// We have the proposal inside the cache
-			handler.consensus.SetProposal(proposal)
+			handler.setProposal(proposal)
 		} else {
 			if handler.weAreInTheCommittee() {
 				msg := message.NewMessage(handler.SelfID(), p)
@@ -12,7 +12,7 @@ func TestParsingQueryProposalMessages(t *testing.T) {
 	disableHeartbeat(t)
 
 	consensusHeight := tAliceState.LastBlockHeight() + 1
-	proposalRound0, _ := proposal.GenerateTestProposal(consensusHeight, 0)
+	proposalRound0, trxsRound0 := generateTestProposalWithTransactions(consensusHeight, 0)
 	proposalRound1, _ := proposal.GenerateTestProposal(consensusHeight, 1)
 
 	pldRound0 := payload.NewQueryProposalPayload(consensusHeight, 0)
@@ -42,6 +42,7 @@ func TestParsingQueryProposalMessages(t *testing.T) {
 
 	t.Run("Alice should not query for proposal, because she has proposal in her cache", func(t *testing.T) {
 		tAliceSync.cache.AddProposal(proposalRound0)
+		tAliceSync.cache.AddTransactions(trxsRound0)
 
 		tAliceBroadcastCh <- pldRound0
 		shouldNotPublishPayloadWithThisType(t, tAliceNet, payload.PayloadTypeQueryProposal)
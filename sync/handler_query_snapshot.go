@@ -0,0 +1,73 @@
+package sync
+
+import (
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/zarbchain/zarb-go/block"
+	"github.com/zarbchain/zarb-go/errors"
+	"github.com/zarbchain/zarb-go/sync/message"
+	"github.com/zarbchain/zarb-go/sync/message/payload"
+	"github.com/zarbchain/zarb-go/sync/peerset"
+)
+
+type querySnapshotHandler struct {
+	*synchronizer
+}
+
+func newQuerySnapshotHandler(sync *synchronizer) payloadHandler {
+	return &querySnapshotHandler{
+		sync,
+	}
+}
+
+func (handler *querySnapshotHandler) ParsPayload(p payload.Payload, initiator peer.ID) error {
+	pld := p.(*payload.QuerySnapshotPayload)
+	handler.logger.Trace("Parsing query snapshot payload", "pld", pld)
+
+	if pld.Target != handler.SelfID() {
+		return nil
+	}
+
+	peer := handler.peerSet.MustGetPeer(initiator)
+	if peer.Status() != peerset.StatusCodeOK {
+		response := payload.NewSnapshotPayload(payload.ResponseCodeRejected, pld.SessionID, initiator, nil, nil, nil, nil)
+		handler.sendTo(response, initiator)
+
+		return errors.Errorf(errors.ErrInvalidMessage, "Peer status is not ok: %v", peer.Status())
+	}
+
+	s := handler.state.LastSnapshot()
+	if s == nil {
+		handler.logger.Debug("We have no snapshot", "pid", initiator)
+		response := payload.NewSnapshotPayload(payload.ResponseCodeRejected, pld.SessionID, initiator, nil, nil, nil, nil)
+		handler.sendTo(response, initiator)
+
+		return nil
+	}
+
+	// The state of the snapshot is certified by the next block and its certificate
+	height := s.Manifest().BlockHeight()
+	b := handler.state.Block(height + 1)
+	var cert *block.Certificate
+	if height+1 == handler.state.LastBlockHeight() {
+		cert = handler.state.LastCertificate()
+	} else if nb := handler.state.Block(height + 2); nb != nil {
+		cert = nb.LastCertificate()
+	}
+	if b == nil || cert == nil {
+		handler.logger.Debug("Snapshot is not certified yet", "height", height)
+		response := payload.NewSnapshotPayload(payload.ResponseCodeRejected, pld.SessionID, initiator, nil, nil, nil, nil)
+		handler.sendTo(response, initiator)
+
+		return nil
+	}
+
+	trxs := handler.prepareTransactions(b.TxIDs().IDs())
+	response := payload.NewSnapshotPayload(payload.ResponseCodeOK, pld.SessionID, initiator, s.Manifest(), b, cert, trxs)
+	handler.sendTo(response, initiator)
+
+	return nil
+}
+
+func (handler *querySnapshotHandler) PrepareMessage(p payload.Payload) *message.Message {
+	return message.NewMessage(handler.SelfID(), p)
+}
@@ -0,0 +1,45 @@
+package sync
+
+import (
+	"testing"
+
+	"github.com/zarbchain/zarb-go/state/snapshot"
+	"github.com/zarbchain/zarb-go/sync/message/payload"
+	"github.com/zarbchain/zarb-go/util"
+)
+
+func TestQuerySnapshotMessages(t *testing.T) {
+	setup(t)
+	disableHeartbeat(t)
+
+	t.Run("Alice received query from unknown peer. Query should be rejected", func(t *testing.T) {
+		pld := payload.NewQuerySnapshotPayload(1, tAlicePeerID)
+		tAliceNet.ReceivingMessageFromOtherPeer(util.RandomPeerID(), pld)
+
+		shouldPublishPayloadWithThisTypeAndResponseCode(t, tAliceNet, payload.PayloadTypeSnapshot, payload.ResponseCodeRejected)
+	})
+
+	t.Run("Alice should not pay attention to queries for other peers", func(t *testing.T) {
+		pld := payload.NewQuerySnapshotPayload(1, util.RandomPeerID())
+		tAliceNet.ReceivingMessageFromOtherPeer(tBobPeerID, pld)
+
+		shouldNotPublishPayloadWithThisType(t, tAliceNet, payload.PayloadTypeSnapshot)
+	})
+
+	t.Run("Alice has no snapshot", func(t *testing.T) {
+		pld := payload.NewQuerySnapshotPayload(1, tAlicePeerID)
+		tAliceNet.ReceivingMessageFromOtherPeer(tBobPeerID, pld)
+
+		shouldPublishPayloadWithThisTypeAndResponseCode(t, tAliceNet, payload.PayloadTypeSnapshot, payload.ResponseCodeRejected)
+	})
+
+	t.Run("Alice offers her snapshot", func(t *testing.T) {
+		s, _, _ := snapshot.GenerateTestSnapshot(20)
+		tAliceState.Snapshot = s
+
+		pld := payload.NewQuerySnapshotPayload(1, tAlicePeerID)
+		tAliceNet.ReceivingMessageFromOtherPeer(tBobPeerID, pld)
+
+		shouldPublishPayloadWithThisTypeAndResponseCode(t, tAliceNet, payload.PayloadTypeSnapshot, payload.ResponseCodeOK)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/zarbchain/zarb-go/crypto"
 	"github.com/zarbchain/zarb-go/errors"
+	"github.com/zarbchain/zarb-go/sync/firewall"
 	"github.com/zarbchain/zarb-go/sync/message"
 	"github.com/zarbchain/zarb-go/sync/message/payload"
 )
@@ -22,6 +23,12 @@ func (handler *queryTransactionsHandler) ParsPayload(p payload.Payload, initiato
 	pld := p.(*payload.QueryTransactionsPayload)
 	handler.logger.Trace("Parsing query transactions payload", "pld", pld)
 
+	if !handler.firewall.Allow(initiator, pld.Type()) {
+		handler.logger.Debug("Peer exceeds the rate limit", "pld", pld, "pid", initiator)
+		handler.firewall.Penalize(initiator, firewall.MisbehaviorExceedRateLimit)
+		return nil
+	}
+
 	if !handler.peerIsInTheCommittee(initiator) {
 		return errors.Errorf(errors.ErrInvalidMessage, "peers is not in the commmittee")
 	}
@@ -3,6 +3,7 @@ package sync
 import (
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/zarbchain/zarb-go/errors"
+	"github.com/zarbchain/zarb-go/sync/firewall"
 	"github.com/zarbchain/zarb-go/sync/message"
 	"github.com/zarbchain/zarb-go/sync/message/payload"
 )
@@ -21,6 +22,12 @@ func (handler *queryVotesHandler) ParsPayload(p payload.Payload, initiator peer.
 	pld := p.(*payload.QueryVotesPayload)
 	handler.logger.Trace("Parsing query votes payload", "pld", pld)
 
+	if !handler.firewall.Allow(initiator, pld.Type()) {
+		handler.logger.Debug("Peer exceeds the rate limit", "pld", pld, "pid", initiator)
+		handler.firewall.Penalize(initiator, firewall.MisbehaviorExceedRateLimit)
+		return nil
+	}
+
 	height, _ := handler.consensus.HeightRound()
 	if pld.Height == height {
 		if !handler.peerIsInTheCommittee(initiator) {
@@ -3,8 +3,11 @@ package sync
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/zarbchain/zarb-go/consensus/vote"
+	"github.com/zarbchain/zarb-go/sync/message"
 	"github.com/zarbchain/zarb-go/sync/message/payload"
+	"github.com/zarbchain/zarb-go/util"
 )
 
 func TestParsingQueryVotesMessages(t *testing.T) {
@@ -39,3 +42,38 @@ func TestParsingQueryVotesMessages(t *testing.T) {
 		shouldPublishPayloadWithThisType(t, tBobNet, payload.PayloadTypeVote)
 	})
 }
+
+func TestQueryVotesRateLimit(t *testing.T) {
+	tBobConfig.Firewall.Enabled = true
+	defer func() { tBobConfig.Firewall.Enabled = false }()
+	setup(t)
+	disableHeartbeat(t)
+	joinAliceToCommittee(t)
+
+	consensusHeight := tAliceState.LastBlockHeight() + 1
+	v1, _ := vote.GenerateTestPrecommitVote(consensusHeight, 0)
+	tBobConsensus.AddVote(v1)
+	pld := payload.NewQueryVotesPayload(consensusHeight, 1)
+
+	for i := 0; i < tBobConfig.Firewall.QueryVotesLimit.Burst; i++ {
+		tBobNet.ReceivingMessageFromOtherPeer(tAlicePeerID, pld)
+		shouldPublishPayloadWithThisType(t, tBobNet, payload.PayloadTypeVote)
+	}
+
+	tBobNet.ReceivingMessageFromOtherPeer(tAlicePeerID, pld)
+	shouldNotPublishPayloadWithThisType(t, tBobNet, payload.PayloadTypeVote)
+	assert.Greater(t, tBobSync.firewall.Score(tAlicePeerID), 0.0)
+
+	t.Run("Messages with forged initiator are dropped and the sender is penalized", func(t *testing.T) {
+		victim := util.RandomPeerID()
+		attacker := util.RandomPeerID()
+		d, _ := message.NewMessage(victim, pld).Encode()
+		for i := 0; i < tBobConfig.Firewall.QueryVotesLimit.Burst+1; i++ {
+			tBobNet.CallbackFn(d, attacker)
+		}
+		shouldNotPublishPayloadWithThisType(t, tBobNet, payload.PayloadTypeVote)
+		assert.Zero(t, tBobSync.firewall.Score(victim))
+		assert.False(t, tBobSync.firewall.IsBanned(victim))
+		assert.True(t, tBobSync.firewall.IsBanned(attacker))
+	})
+}
@@ -32,12 +32,25 @@ func (handler *salamHandler) ParsPayload(p payload.Payload, initiator peer.ID) e
 		return nil
 	}
 
+	if err := pld.Capabilities.CheckCompatibility(handler.network.Name()); err != nil {
+		handler.logger.Info("Received a message from incompatible peer", "err", err, "peer", util.FingerprintPeerID(initiator))
+		peer.UpdateStatus(peerset.StatusCodeBanned)
+		handler.broadcastAleyk(initiator, payload.ResponseCodeRejected, err.Error())
+		return nil
+	}
+
 	peer.UpdateStatus(peerset.StatusCodeOK)
 	peer.UpdateMoniker(pld.Moniker)
 	peer.UpdateHeight(pld.Height)
 	peer.UpdateNodeVersion(pld.NodeVersion)
 	peer.UpdatePublicKey(pld.PublicKey)
 	peer.UpdateInitialBlockDownload(util.IsFlagSet(pld.Flags, FlagInitialBlockDownload))
+	peer.UpdateCodecs(pld.Codecs)
+	caps := pld.Capabilities
+	if caps.IsLegacy() {
+		caps = handler.legacyCapabilities()
+	}
+	peer.UpdateCapabilities(caps)
 
 	handler.peerSet.UpdateMaxClaimedHeight(pld.Height)
 
@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/zarbchain/zarb-go/crypto"
+	"github.com/zarbchain/zarb-go/sync/message/codec"
 	"github.com/zarbchain/zarb-go/sync/message/payload"
 	"github.com/zarbchain/zarb-go/sync/peerset"
 	"github.com/zarbchain/zarb-go/util"
@@ -17,7 +18,7 @@ func TestParsingSalamMessages(t *testing.T) {
 	t.Run("Alice receives Salam message from a peer. Genesis hash is wrong. Alice should not handshake", func(t *testing.T) {
 		invGenHash := crypto.GenerateTestHash()
 		_, pub, _ := crypto.GenerateTestKeyPair()
-		pld := payload.NewSalamPayload("bad-genesis", pub, invGenHash, 0, 0)
+		pld := payload.NewSalamPayload("bad-genesis", pub, invGenHash, 0, 0, tAliceSync.capabilities())
 		pid := util.RandomPeerID()
 		tAliceNet.ReceivingMessageFromOtherPeer(pid, pld)
 
@@ -26,10 +27,50 @@ func TestParsingSalamMessages(t *testing.T) {
 		shouldPublishPayloadWithThisTypeAndResponseCode(t, tAliceNet, payload.PayloadTypeAleyk, payload.ResponseCodeRejected)
 	})
 
+	t.Run("Alice receives Salam message from a peer in other network. Alice should not handshake", func(t *testing.T) {
+		_, pub, _ := crypto.GenerateTestKeyPair()
+		caps := tAliceSync.capabilities()
+		caps.Network = "zarb-other"
+		pld := payload.NewSalamPayload("other-network", pub, tAliceState.GenHash, 0, 0, caps)
+		pid := util.RandomPeerID()
+		tAliceNet.ReceivingMessageFromOtherPeer(pid, pld)
+
+		peer := tAliceSync.peerSet.GetPeer(pid)
+		assert.Equal(t, peer.Status(), peerset.StatusCodeBanned)
+		shouldPublishPayloadWithThisTypeAndResponseCode(t, tAliceNet, payload.PayloadTypeAleyk, payload.ResponseCodeRejected)
+	})
+
+	t.Run("Alice receives Salam message from a peer with old protocol. Alice should not handshake", func(t *testing.T) {
+		_, pub, _ := crypto.GenerateTestKeyPair()
+		caps := tAliceSync.capabilities()
+		caps.ProtocolVersion = 0
+		pld := payload.NewSalamPayload("old-protocol", pub, tAliceState.GenHash, 0, 0, caps)
+		pid := util.RandomPeerID()
+		tAliceNet.ReceivingMessageFromOtherPeer(pid, pld)
+
+		peer := tAliceSync.peerSet.GetPeer(pid)
+		assert.Equal(t, peer.Status(), peerset.StatusCodeBanned)
+		shouldPublishPayloadWithThisTypeAndResponseCode(t, tAliceNet, payload.PayloadTypeAleyk, payload.ResponseCodeRejected)
+	})
+
+	t.Run("Alice receives Salam message from a legacy peer without capabilities. Alice should handshake", func(t *testing.T) {
+		_, pub, _ := crypto.GenerateTestKeyPair()
+		pld := payload.NewSalamPayload("legacy", pub, tAliceState.GenHash, 0, 0, payload.Capabilities{})
+		pid := util.RandomPeerID()
+		tAliceNet.ReceivingMessageFromOtherPeer(pid, pld)
+
+		peer := tAliceSync.peerSet.GetPeer(pid)
+		assert.Equal(t, peer.Status(), peerset.StatusCodeOK)
+		shouldPublishPayloadWithThisTypeAndResponseCode(t, tAliceNet, payload.PayloadTypeAleyk, payload.ResponseCodeOK)
+		assert.Equal(t, peer.Capabilities(), tAliceSync.legacyCapabilities())
+		assert.False(t, peer.HasService(payload.ServiceArchive))
+		assert.True(t, peer.SupportsPayload(payload.PayloadTypeLatestBlocksRequest))
+	})
+
 	t.Run("Alice receives Salam message from a peer. Genesis hash is Ok. Alice should update the peer info", func(t *testing.T) {
 		_, pub, _ := crypto.GenerateTestKeyPair()
 
-		pld := payload.NewSalamPayload("kitty", pub, tAliceState.GenHash, 3, 0x1)
+		pld := payload.NewSalamPayload("kitty", pub, tAliceState.GenHash, 3, 0x1, tAliceSync.capabilities())
 		pid := util.RandomPeerID()
 		tAliceNet.ReceivingMessageFromOtherPeer(pid, pld)
 
@@ -45,13 +86,17 @@ func TestParsingSalamMessages(t *testing.T) {
 		assert.Equal(t, p.PeerID(), pid)
 		assert.Equal(t, p.Height(), 3)
 		assert.Equal(t, p.InitialBlockDownload(), true)
+		assert.Equal(t, p.Codecs(), codec.Supported)
+		assert.Equal(t, p.Capabilities(), tAliceSync.capabilities())
+		assert.True(t, p.HasService(payload.ServiceArchive))
+		assert.True(t, p.SupportsPayload(payload.PayloadTypeDownloadRequest))
 	})
 
 	t.Run("Alice receives Salam message from a peer. Peer is ahead. Alice should request for blocks", func(t *testing.T) {
 		tAliceSync.peerSet.Clear()
 		_, pub, _ := crypto.GenerateTestKeyPair()
 		claimedHeight := tAliceState.LastBlockHeight() + 5
-		pld := payload.NewSalamPayload("kitty", pub, tAliceState.GenHash, claimedHeight, 0)
+		pld := payload.NewSalamPayload("kitty", pub, tAliceState.GenHash, claimedHeight, 0, tAliceSync.capabilities())
 		tAliceNet.ReceivingMessageFromOtherPeer(util.RandomPeerID(), pld)
 
 		shouldPublishPayloadWithThisTypeAndResponseCode(t, tAliceNet, payload.PayloadTypeAleyk, payload.ResponseCodeOK)
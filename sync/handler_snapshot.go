@@ -0,0 +1,41 @@
+package sync
+
+import (
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/zarbchain/zarb-go/sync/message"
+	"github.com/zarbchain/zarb-go/sync/message/payload"
+	"github.com/zarbchain/zarb-go/util"
+)
+
+type snapshotHandler struct {
+	*synchronizer
+}
+
+func newSnapshotHandler(sync *synchronizer) payloadHandler {
+	return &snapshotHandler{
+		sync,
+	}
+}
+
+func (handler *snapshotHandler) ParsPayload(p payload.Payload, initiator peer.ID) error {
+	pld := p.(*payload.SnapshotPayload)
+	handler.logger.Trace("Parsing snapshot payload", "pld", pld)
+
+	if pld.Target != handler.SelfID() {
+		return nil
+	}
+
+	if pld.ResponseCode == payload.ResponseCodeOK {
+		if err := handler.stateSync.AddOffer(initiator, handler.state.GenesisHash(), pld.Manifest, pld.Block, pld.Certificate, pld.Transactions); err != nil {
+			handler.logger.Debug("Snapshot offer ignored", "pid", util.FingerprintPeerID(initiator), "err", err)
+		}
+	}
+	handler.peerSet.CloseSession(pld.SessionID)
+	handler.updateBlokchain()
+
+	return nil
+}
+
+func (handler *snapshotHandler) PrepareMessage(p payload.Payload) *message.Message {
+	return message.NewMessage(handler.SelfID(), p)
+}
@@ -3,6 +3,7 @@ package sync
 import (
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/zarbchain/zarb-go/sync/message"
+	"github.com/zarbchain/zarb-go/sync/message/codec"
 	"github.com/zarbchain/zarb-go/sync/message/payload"
 )
 
@@ -20,19 +21,18 @@ func (handler *transactionsHandler) ParsPayload(p payload.Payload, initiator pee
 	pld := p.(*payload.TransactionsPayload)
 	handler.logger.Trace("Parsing transactions payload", "pld", pld)
 
-	handler.cache.AddTransactions(pld.Transactions)
-
-	for _, trx := range pld.Transactions {
-		if err := handler.state.AddPendingTx(trx); err != nil {
-			handler.logger.Debug("Cannot append transaction", "tx", trx, "err", err)
-
-			// TODO: set peer as bad peer?
-		}
-	}
+	handler.addTransactions(pld.Transactions)
 
 	return nil
 }
 
 func (handler *transactionsHandler) PrepareMessage(p payload.Payload) *message.Message {
-	return message.NewMessage(handler.SelfID(), p)
+	msg := message.NewMessage(handler.SelfID(), p)
+	// Compressing a single transaction isn't worth it.
+	// Transactions are gossiped, so they are compressed by the legacy codec that all the nodes can decode.
+	if len(p.(*payload.TransactionsPayload).Transactions) > 1 {
+		msg.Codec = codec.Gzip
+	}
+
+	return msg
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/zarbchain/zarb-go/sync/message/codec"
 	"github.com/zarbchain/zarb-go/sync/message/payload"
 	"github.com/zarbchain/zarb-go/tx"
 )
@@ -23,3 +24,22 @@ func TestParsingTransactionsMessages(t *testing.T) {
 		assert.NotNil(t, tBobSync.state.PendingTx(trx1.ID()))
 	})
 }
+
+func TestTransactionsCodec(t *testing.T) {
+	setup(t)
+	disableHeartbeat(t)
+
+	trx1, _ := tx.GenerateTestBondTx()
+	trx2, _ := tx.GenerateTestBondTx()
+	handler := newTransactionsHandler(tAliceSync)
+
+	t.Run("A single transaction should not be compressed", func(t *testing.T) {
+		msg := handler.PrepareMessage(payload.NewTransactionsPayload([]*tx.Tx{trx1}))
+		assert.Equal(t, msg.Codec, codec.None)
+	})
+
+	t.Run("Transactions should be compressed by the legacy codec, even if all peers support zstd", func(t *testing.T) {
+		msg := handler.PrepareMessage(payload.NewTransactionsPayload([]*tx.Tx{trx1, trx2}))
+		assert.Equal(t, msg.Codec, codec.Gzip)
+	})
+}
@@ -2,7 +2,9 @@ package sync
 
 import (
 	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/zarbchain/zarb-go/network"
 	"github.com/zarbchain/zarb-go/sync/peerset"
+	"github.com/zarbchain/zarb-go/sync/scheduler"
 )
 
 type Synchronizer interface {
@@ -11,4 +13,6 @@ type Synchronizer interface {
 	SelfID() peer.ID
 	Peers() []*peerset.Peer
 	Fingerprint() string
+	DownloadProgress() scheduler.Progress
+	AddressBook() []network.AddressInfo
 }
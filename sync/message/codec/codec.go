@@ -0,0 +1,105 @@
+package codec
+
+import (
+	"fmt"
+
+	"github.com/DataDog/zstd"
+	"github.com/golang/snappy"
+	"github.com/zarbchain/zarb-go/errors"
+	"github.com/zarbchain/zarb-go/util"
+)
+
+// Codec defines the compression algorithm of the message payload
+type Codec int
+
+const (
+	None   = Codec(0)
+	Gzip   = Codec(1)
+	Zstd   = Codec(2)
+	Snappy = Codec(3)
+)
+
+// Supported lists the codecs that this node supports, the most preferred first.
+// The order comes from BenchmarkCodecs: the blocks are mostly hashes and signatures,
+// so all the codecs compress them almost equally, and the faster codec is preferred.
+var Supported = []Codec{Snappy, Zstd, Gzip}
+
+// Legacy lists the codecs that nodes without advertising their codecs support.
+var Legacy = []Codec{Gzip}
+
+func (c Codec) String() string {
+	switch c {
+	case None:
+		return "none"
+	case Gzip:
+		return "gzip"
+	case Zstd:
+		return "zstd"
+	case Snappy:
+		return "snappy"
+	}
+	return fmt.Sprintf("%d", c)
+}
+
+func (c Codec) IsValid() bool {
+	return c >= None && c <= Snappy
+}
+
+func (c Codec) Compress(data []byte) ([]byte, error) {
+	switch c {
+	case None:
+		return data, nil
+	case Gzip:
+		return util.CompressBuffer(data)
+	case Zstd:
+		return zstd.Compress(nil, data)
+	case Snappy:
+		return snappy.Encode(nil, data), nil
+	}
+	return nil, errors.Errorf(errors.ErrInvalidMessage, "unknown codec: %v", c)
+}
+
+func (c Codec) Decompress(data []byte) ([]byte, error) {
+	switch c {
+	case None:
+		return data, nil
+	case Gzip:
+		return util.DecompressBuffer(data)
+	case Zstd:
+		return zstd.Decompress(nil, data)
+	case Snappy:
+		return snappy.Decode(nil, data)
+	}
+	return nil, errors.Errorf(errors.ErrInvalidMessage, "unknown codec: %v", c)
+}
+
+// Best returns the most preferred codec of this node that all the given codec lists include.
+// An empty list means the legacy codecs.
+// It returns None if there is no common codec.
+func Best(lists ...[]Codec) Codec {
+	for _, c := range Supported {
+		common := true
+		for _, l := range lists {
+			if len(l) == 0 {
+				l = Legacy
+			}
+			if !contains(l, c) {
+				common = false
+				break
+			}
+		}
+		if common {
+			return c
+		}
+	}
+	return None
+}
+
+func contains(l []Codec, c Codec) bool {
+	for _, i := range l {
+		if i == c {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,36 @@
+package codec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zarbchain/zarb-go/util"
+)
+
+func TestCompressDecompress(t *testing.T) {
+	data := append(util.Int64ToSlice(util.RandInt64(0)), make([]byte, 1024)...)
+	for _, c := range []Codec{None, Gzip, Zstd, Snappy} {
+		compressed, err := c.Compress(data)
+		assert.NoError(t, err)
+		decompressed, err := c.Decompress(compressed)
+		assert.NoError(t, err)
+		assert.Equal(t, decompressed, data, "codec: %v", c)
+	}
+
+	_, err := Codec(8).Compress(data)
+	assert.Error(t, err)
+	_, err = Codec(8).Decompress(data)
+	assert.Error(t, err)
+	_, err = Zstd.Decompress([]byte("invalid"))
+	assert.Error(t, err)
+}
+
+func TestBest(t *testing.T) {
+	assert.Equal(t, Best(), Snappy)
+	assert.Equal(t, Best(Supported), Snappy)
+	assert.Equal(t, Best(Supported, nil), Gzip)
+	assert.Equal(t, Best(Supported, []Codec{Zstd, Gzip}), Zstd)
+	assert.Equal(t, Best([]Codec{Snappy}), Snappy)
+	assert.Equal(t, Best([]Codec{Snappy}, []Codec{Zstd}), None)
+	assert.Equal(t, Best([]Codec{Codec(8)}), None)
+}
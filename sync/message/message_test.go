@@ -5,10 +5,15 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/fxamacker/cbor/v2"
+	lp2pcrypto "github.com/libp2p/go-libp2p-core/crypto"
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/stretchr/testify/assert"
 	"github.com/zarbchain/zarb-go/block"
 	"github.com/zarbchain/zarb-go/consensus/vote"
+	"github.com/zarbchain/zarb-go/crypto"
+	"github.com/zarbchain/zarb-go/sortition"
+	"github.com/zarbchain/zarb-go/sync/message/codec"
 	"github.com/zarbchain/zarb-go/sync/message/payload"
 	"github.com/zarbchain/zarb-go/tx"
 	"github.com/zarbchain/zarb-go/util"
@@ -28,7 +33,7 @@ func TestInvalidCBOR(t *testing.T) {
 	m2 := new(Message)
 	assert.Error(t, m2.Decode(d))
 }
-func TestMessageCompress(t *testing.T) {
+func generateTestDownloadResponse() payload.Payload {
 	var blocks = []*block.Block{}
 	var trxs = []*tx.Tx{}
 	for i := 0; i < 10; i++ {
@@ -36,22 +41,141 @@ func TestMessageCompress(t *testing.T) {
 		trxs = append(trxs, t...)
 		blocks = append(blocks, b)
 	}
-	pld := payload.NewLatestBlocksResponsePayload(payload.ResponseCodeBusy, 1234, tPeerID2, 888, blocks, trxs, nil)
+	return payload.NewDownloadResponsePayload(payload.ResponseCodeMoreBlocks, 1234, tPeerID2, 888, blocks, trxs)
+}
+
+// generateRealisticDownloadResponse generates a chain of linked blocks with their transactions.
+// Like the real chain, the blocks are proposed and certified by a small committee,
+// and the transactions are sent between a limited set of accounts.
+func generateRealisticDownloadResponse() payload.Payload {
+	committee := make([]crypto.Signer, 7)
+	for i := range committee {
+		committee[i] = crypto.GenerateTestSigner()
+	}
+	accounts := make([]crypto.Signer, 50)
+	sequences := make([]int, len(accounts))
+	for i := range accounts {
+		accounts[i] = crypto.GenerateTestSigner()
+	}
+
+	blocks := []*block.Block{}
+	trxs := []*tx.Tx{}
+	lastHash := crypto.GenerateTestHash()
+	seed := sortition.GenerateRandomSeed()
+	for i := 0; i < 10; i++ {
+		proposer := committee[i%len(committee)]
+		ids := block.NewTxIDs()
+
+		mintbase := tx.NewMintbaseTx(lastHash, 1000+i, proposer.Address(), 100000000, "")
+		trxs = append(trxs, mintbase)
+		ids.Append(mintbase.ID())
+		for j := 0; j < 20; j++ {
+			k := util.RandInt(len(accounts))
+			sequences[k]++
+			receiver := accounts[util.RandInt(len(accounts))].Address()
+			trx := tx.NewSendTx(lastHash, sequences[k], accounts[k].Address(), receiver, util.RandInt64(1000000), 1000, "")
+			accounts[k].SignMsg(trx)
+			trxs = append(trxs, trx)
+			ids.Append(trx.ID())
+		}
+
+		sigs := make([]crypto.Signature, 0, len(committee))
+		for _, signer := range committee {
+			sigs = append(sigs, signer.SignData(block.CertificateSignBytes(lastHash, 0)))
+		}
+		cert := block.NewCertificate(lastHash, 0, []int{0, 1, 2, 3, 4, 5, 6}, []int{}, crypto.Aggregate(sigs))
+		seed = seed.Generate(proposer)
+		b := block.MakeBlock(1, util.Now(), ids, lastHash, crypto.GenerateTestHash(), cert, seed, proposer.Address())
+		blocks = append(blocks, b)
+		lastHash = b.Hash()
+	}
+	return payload.NewDownloadResponsePayload(payload.ResponseCodeMoreBlocks, 1234, tPeerID2, 888, blocks, trxs)
+}
+
+func TestMessageCompress(t *testing.T) {
+	pld := generateTestDownloadResponse()
 	msg := NewMessage(tPeerID1, pld)
 	bs0, err := msg.Encode()
 	assert.NoError(t, err)
-	msg.CompressIt()
-	bs1, err := msg.Encode()
-	assert.NoError(t, err)
-	fmt.Printf("Compressed :%v%%\n", 100-len(bs1)*100/(len(bs0)))
 	fmt.Printf("Uncompressed len :%v\n", len(bs0))
-	fmt.Printf("Compressed len :%v\n", len(bs1))
-	msg2 := new(Message)
-	msg3 := new(Message)
-	assert.NoError(t, msg2.Decode(bs0))
-	assert.NoError(t, msg3.Decode(bs1))
-	assert.NoError(t, msg2.SanityCheck())
-	assert.NoError(t, msg3.SanityCheck())
+
+	for _, c := range []codec.Codec{codec.Gzip, codec.Zstd, codec.Snappy} {
+		msg.Codec = c
+		bs1, err := msg.Encode()
+		assert.NoError(t, err)
+		fmt.Printf("Compressed by %v: %v%%, len: %v\n", c, 100-len(bs1)*100/(len(bs0)), len(bs1))
+		assert.Less(t, len(bs1), len(bs0))
+
+		msg2 := new(Message)
+		assert.NoError(t, msg2.Decode(bs1))
+		assert.NoError(t, msg2.SanityCheck())
+		assert.Equal(t, msg2.Codec, c)
+		assert.Equal(t, msg2.Flags, 0)
+		d1, _ := cbor.Marshal(msg2.Payload)
+		d2, _ := cbor.Marshal(pld)
+		assert.Equal(t, d1, d2)
+	}
+}
+
+func TestLegacyCompressFlag(t *testing.T) {
+	pld := generateTestDownloadResponse()
+	msg := NewMessage(tPeerID1, pld)
+
+	t.Run("Gzip messages set the legacy flag", func(t *testing.T) {
+		msg.Codec = codec.Gzip
+		bs, _ := msg.Encode()
+		var raw _Message
+		assert.NoError(t, cbor.Unmarshal(bs, &raw))
+		assert.Equal(t, raw.Flags, flagCompressed)
+		assert.Equal(t, raw.Codec, codec.None)
+	})
+
+	t.Run("Other codecs don't set the legacy flag", func(t *testing.T) {
+		msg.Codec = codec.Zstd
+		bs, _ := msg.Encode()
+		var raw _Message
+		assert.NoError(t, cbor.Unmarshal(bs, &raw))
+		assert.Equal(t, raw.Flags, 0)
+		assert.Equal(t, raw.Codec, codec.Zstd)
+	})
+
+	t.Run("Both legacy flag and codec are set", func(t *testing.T) {
+		msg.Codec = codec.Zstd
+		raw, _ := msg.encode()
+		raw.Flags = flagCompressed
+		bs, _ := cbor.Marshal(raw)
+		assert.Error(t, new(Message).Decode(bs))
+	})
+
+	t.Run("Unknown codec", func(t *testing.T) {
+		msg.Codec = codec.Codec(8)
+		_, err := msg.Encode()
+		assert.Error(t, err)
+		assert.Error(t, msg.SanityCheck())
+	})
+}
+
+// BenchmarkCodecs measures the speed and the compression ratio of the codecs,
+// by compressing and decompressing a download response of realistic blocks.
+func BenchmarkCodecs(b *testing.B) {
+	data, _ := cbor.Marshal(generateRealisticDownloadResponse())
+	for _, c := range []codec.Codec{codec.Gzip, codec.Zstd, codec.Snappy} {
+		compressed, _ := c.Compress(data)
+
+		b.Run(fmt.Sprintf("Compress-%v", c), func(b *testing.B) {
+			b.SetBytes(int64(len(data)))
+			b.ReportMetric(float64(len(data))/float64(len(compressed)), "ratio")
+			for i := 0; i < b.N; i++ {
+				_, _ = c.Compress(data)
+			}
+		})
+		b.Run(fmt.Sprintf("Decompress-%v", c), func(b *testing.B) {
+			b.SetBytes(int64(len(data)))
+			for i := 0; i < b.N; i++ {
+				_, _ = c.Decompress(compressed)
+			}
+		})
+	}
 }
 
 func TestDecodeVoteMessage(t *testing.T) {
@@ -60,7 +184,7 @@ func TestDecodeVoteMessage(t *testing.T) {
 	msg := NewMessage(tPeerID1, pld)
 	bs0, err := msg.Encode()
 	assert.NoError(t, err)
-	msg.CompressIt()
+	msg.Codec = codec.Gzip
 	bs1, err := msg.Encode()
 	assert.NoError(t, err)
 	fmt.Printf("Compressed :%v%%\n", 100-len(bs1)*100/(len(bs0)))
@@ -77,6 +201,51 @@ func TestDecodeVoteCBOR(t *testing.T) {
 	assert.NoError(t, m1.Decode(d1))
 	assert.NoError(t, m2.Decode(d2))
 	assert.NoError(t, m2.SanityCheck())
+	assert.Equal(t, m2.Codec, codec.Gzip)
 
 	assert.Equal(t, m1.Payload, m2.Payload)
 }
+
+func TestSignMessage(t *testing.T) {
+	key, _, _ := lp2pcrypto.GenerateEd25519Key(nil)
+	pid, _ := peer.IDFromPrivateKey(key)
+
+	pld := payload.NewHeartBeatPayload(100, 1, crypto.GenerateTestHash())
+	msg := NewMessage(pid, pld)
+	assert.False(t, msg.IsSigned())
+	assert.Error(t, msg.Verify())
+
+	msg.Codec = codec.Zstd
+	assert.NoError(t, msg.Sign(key))
+	assert.True(t, msg.IsSigned())
+	assert.NoError(t, msg.Verify())
+
+	bs, err := msg.Encode()
+	assert.NoError(t, err)
+	msg2 := new(Message)
+	assert.NoError(t, msg2.Decode(bs))
+	assert.NoError(t, msg2.SanityCheck())
+	assert.NoError(t, msg2.Verify())
+
+	t.Run("Spoofed initiator", func(t *testing.T) {
+		msg3 := NewMessage(pid, pld)
+		otherKey, _, _ := lp2pcrypto.GenerateEd25519Key(nil)
+		assert.NoError(t, msg3.Sign(otherKey))
+		assert.Error(t, msg3.Verify())
+	})
+
+	t.Run("Initiator without public key", func(t *testing.T) {
+		msg3 := NewMessage(tPeerID1, pld)
+		assert.NoError(t, msg3.Sign(key))
+		assert.Error(t, msg3.Verify())
+	})
+
+	t.Run("Modified payload", func(t *testing.T) {
+		msg3 := NewMessage(pid, payload.NewHeartBeatPayload(101, 1, crypto.GenerateTestHash()))
+		msg3.Signature = msg.Signature
+		bs, _ := msg3.Encode()
+		msg4 := new(Message)
+		assert.NoError(t, msg4.Decode(bs))
+		assert.Error(t, msg4.Verify())
+	})
+}
@@ -4,27 +4,37 @@ import (
 	"fmt"
 
 	"github.com/fxamacker/cbor/v2"
+	lp2pcrypto "github.com/libp2p/go-libp2p-core/crypto"
 	"github.com/libp2p/go-libp2p-core/peer"
-	"github.com/zarbchain/zarb-go/crypto"
 	"github.com/zarbchain/zarb-go/errors"
+	"github.com/zarbchain/zarb-go/sync/message/codec"
 	"github.com/zarbchain/zarb-go/sync/message/payload"
 	"github.com/zarbchain/zarb-go/util"
 )
 
 const LastVersion = 1
-const FlagCompressed = 0x1
+
+// flagCompressed is the legacy flag for gzip compressed messages.
+// We keep setting it for gzip, so the older nodes can decode these messages.
+const flagCompressed = 0x1
 
 type Message struct {
 	Version   int
 	Flags     int
+	Codec     codec.Codec
 	Initiator peer.ID
 	Payload   payload.Payload
+	Signature []byte
+
+	// signBytes keeps the signed bytes of a received message
+	signBytes []byte
 }
 
 func NewMessage(initiator peer.ID, pld payload.Payload) *Message {
 	return &Message{
 		Version:   LastVersion,
 		Flags:     0,
+		Codec:     codec.None,
 		Initiator: initiator,
 		Payload:   pld,
 	}
@@ -34,9 +44,12 @@ func (m *Message) SanityCheck() error {
 	if err := m.Payload.SanityCheck(); err != nil {
 		return err
 	}
-	if m.Flags|FlagCompressed != FlagCompressed {
+	if m.Flags != 0 {
 		return errors.Errorf(errors.ErrInvalidMessage, "invalid flags")
 	}
+	if !m.Codec.IsValid() {
+		return errors.Errorf(errors.ErrInvalidMessage, "invalid codec")
+	}
 	if err := m.Initiator.Validate(); err != nil {
 		return errors.Errorf(errors.ErrInvalidMessage, "invalid initiator peer id: %v", err)
 	}
@@ -47,30 +60,90 @@ func (m *Message) Fingerprint() string {
 	return fmt.Sprintf("{%s: %s%s}", util.FingerprintPeerID(m.Initiator), m.Payload.Type(), m.Payload.Fingerprint())
 }
 
-func (m *Message) CompressIt() {
-	m.Flags = util.SetFlag(m.Flags, FlagCompressed)
+// IsSigned returns true if the message has a signature
+func (m *Message) IsSigned() bool {
+	return len(m.Signature) > 0
+}
+
+// Sign signs the message by the private key of the initiator.
+func (m *Message) Sign(key lp2pcrypto.PrivKey) error {
+	bs, err := m.SignBytes()
+	if err != nil {
+		return err
+	}
+	sig, err := key.Sign(bs)
+	if err != nil {
+		return err
+	}
+	m.Signature = sig
+	return nil
+}
+
+// Verify checks the signature of the message against the public key of the initiator.
+// Initiator's public key is extracted from its peer ID.
+func (m *Message) Verify() error {
+	if !m.IsSigned() {
+		return errors.Errorf(errors.ErrInvalidSignature, "message is not signed")
+	}
+	pub, err := m.Initiator.ExtractPublicKey()
+	if err != nil {
+		return errors.Errorf(errors.ErrInvalidSignature, "unable to extract public key: %v", err)
+	}
+	bs, err := m.SignBytes()
+	if err != nil {
+		return err
+	}
+	ok, err := pub.Verify(bs, m.Signature)
+	if err != nil || !ok {
+		return errors.Errorf(errors.ErrInvalidSignature, "signature doesn't match the initiator")
+	}
+	return nil
 }
 
+// SignBytes returns the encoded message without signature.
+// For a received message, it returns the bytes as they were received.
+func (m *Message) SignBytes() ([]byte, error) {
+	if m.signBytes != nil {
+		return m.signBytes, nil
+	}
+	msg, err := m.encode()
+	if err != nil {
+		return nil, err
+	}
+	return cbor.Marshal(msg)
+}
+
+// Key 6 was reserved for a validator signature and it has never been used.
+// Nodes of the older versions can't decode other type of data in that key.
 type _Message struct {
-	Version     int               `cbor:"1,keyasint"`
-	Flags       int               `cbor:"2,keyasint"`
-	Initiator   peer.ID           `cbor:"3,keyasint"`
-	PayloadType payload.Type      `cbor:"4,keyasint"`
-	Payload     []byte            `cbor:"5,keyasint"`
-	Signature   *crypto.Signature `cbor:"6,keyasint,omitempty"`
+	Version     int          `cbor:"1,keyasint"`
+	Flags       int          `cbor:"2,keyasint"`
+	Initiator   peer.ID      `cbor:"3,keyasint"`
+	PayloadType payload.Type `cbor:"4,keyasint"`
+	Payload     []byte       `cbor:"5,keyasint"`
+	Signature   []byte       `cbor:"7,keyasint,omitempty"`
+	Codec       codec.Codec  `cbor:"8,keyasint,omitempty"`
 }
 
 func (m *Message) Encode() ([]byte, error) {
+	msg, err := m.encode()
+	if err != nil {
+		return nil, err
+	}
+	msg.Signature = m.Signature
+
+	return cbor.Marshal(msg)
+}
+
+func (m *Message) encode() (*_Message, error) {
 	data, err := cbor.Marshal(m.Payload)
 	if err != nil {
 		return nil, err
 	}
 
-	if util.IsFlagSet(m.Flags, FlagCompressed) {
-		c, err := util.CompressBuffer(data)
-		if err == nil {
-			data = c
-		}
+	data, err = m.Codec.Compress(data)
+	if err != nil {
+		return nil, err
 	}
 
 	msg := &_Message{
@@ -80,8 +153,13 @@ func (m *Message) Encode() ([]byte, error) {
 		PayloadType: m.Payload.Type(),
 		Payload:     data,
 	}
+	if m.Codec == codec.Gzip {
+		msg.Flags = util.SetFlag(msg.Flags, flagCompressed)
+	} else {
+		msg.Codec = m.Codec
+	}
 
-	return cbor.Marshal(msg)
+	return msg, nil
 }
 
 func (m *Message) Decode(bs []byte) error {
@@ -97,17 +175,33 @@ func (m *Message) Decode(bs []byte) error {
 		return errors.Errorf(errors.ErrInvalidMessage, "invalid payload")
 	}
 
-	if util.IsFlagSet(msg.Flags, FlagCompressed) {
-		c, err := util.DecompressBuffer(msg.Payload)
-		if err != nil {
-			return errors.Errorf(errors.ErrInvalidMessage, err.Error())
+	flags := msg.Flags
+	c := msg.Codec
+	if util.IsFlagSet(flags, flagCompressed) {
+		if c != codec.None {
+			return errors.Errorf(errors.ErrInvalidMessage, "invalid codec")
 		}
-		data = c
+		flags = util.UnsetFlag(flags, flagCompressed)
+		c = codec.Gzip
+	}
+	data, err = c.Decompress(data)
+	if err != nil {
+		return errors.Errorf(errors.ErrInvalidMessage, err.Error())
 	}
 
 	m.Version = msg.Version
-	m.Flags = msg.Flags
+	m.Flags = flags
+	m.Codec = c
 	m.Initiator = msg.Initiator
 	m.Payload = pld
+	m.Signature = msg.Signature
+	m.signBytes = nil
+	if m.IsSigned() {
+		msg.Signature = nil
+		m.signBytes, err = cbor.Marshal(msg)
+		if err != nil {
+			return err
+		}
+	}
 	return cbor.Unmarshal(data, pld)
 }
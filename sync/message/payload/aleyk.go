@@ -6,6 +6,7 @@ import (
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/zarbchain/zarb-go/crypto"
 	"github.com/zarbchain/zarb-go/errors"
+	"github.com/zarbchain/zarb-go/sync/message/codec"
 	"github.com/zarbchain/zarb-go/version"
 )
 
@@ -18,10 +19,12 @@ type AleykPayload struct {
 	PublicKey       crypto.PublicKey `cbor:"6,keyasint"`
 	Height          int              `cbor:"7,keyasint"`
 	Flags           int              `cbor:"8,keyasint"`
+	Codecs          []codec.Codec    `cbor:"9,keyasint,omitempty"`
+	Capabilities    Capabilities     `cbor:"10,keyasint"`
 }
 
 func NewAleykPayload(target peer.ID, code ResponseCode, msg string, moniker string,
-	pub crypto.PublicKey, height int, flags int) Payload {
+	pub crypto.PublicKey, height int, flags int, caps Capabilities) Payload {
 	return &AleykPayload{
 		ResponseTarget:  target,
 		ResponseCode:    code,
@@ -31,6 +34,8 @@ func NewAleykPayload(target peer.ID, code ResponseCode, msg string, moniker stri
 		PublicKey:       pub,
 		Height:          height,
 		Flags:           flags,
+		Codecs:          codec.Supported,
+		Capabilities:    caps,
 	}
 }
 
@@ -16,21 +16,21 @@ func TestAleykType(t *testing.T) {
 func TestAleykPayload(t *testing.T) {
 	t.Run("Invalid target", func(t *testing.T) {
 		p := NewAleykPayload("", ResponseCodeRejected, "rejected",
-			"Eve", crypto.GenerateTestSigner().PublicKey(), 100, 0)
+			"Eve", crypto.GenerateTestSigner().PublicKey(), 100, 0, NewCapabilities("zarb", 0, nil))
 
 		assert.Error(t, p.SanityCheck())
 	})
 
 	t.Run("Invalid height", func(t *testing.T) {
 		p := NewAleykPayload(util.RandomPeerID(), ResponseCodeRejected, "rejected",
-			"Eve", crypto.GenerateTestSigner().PublicKey(), -1, 0)
+			"Eve", crypto.GenerateTestSigner().PublicKey(), -1, 0, NewCapabilities("zarb", 0, nil))
 
 		assert.Error(t, p.SanityCheck())
 	})
 
 	t.Run("Ok", func(t *testing.T) {
 		p := NewAleykPayload(util.RandomPeerID(), ResponseCodeRejected, "welcome",
-			"Alice", crypto.GenerateTestSigner().PublicKey(), 100, 0)
+			"Alice", crypto.GenerateTestSigner().PublicKey(), 100, 0, NewCapabilities("zarb", 0, nil))
 
 		assert.NoError(t, p.SanityCheck())
 		assert.Contains(t, p.Fingerprint(), "Alice")
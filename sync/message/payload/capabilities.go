@@ -0,0 +1,90 @@
+package payload
+
+import (
+	"strings"
+
+	"github.com/zarbchain/zarb-go/errors"
+)
+
+// ProtocolVersion is the version of the sync protocol.
+// It should be increased when the payloads change in a way that older nodes can't understand them.
+const ProtocolVersion = 1
+
+// MinProtocolVersion is the oldest protocol version that we can talk with
+const MinProtocolVersion = 1
+
+// Services are the services that a node offers to other peers
+type Services int
+
+const (
+	// ServiceArchive means the node keeps all the blocks since genesis.
+	// Nodes that are restored from a snapshot don't have the blocks before the snapshot.
+	ServiceArchive = Services(0x1)
+	// ServiceSnapshots means the node offers snapshots of its state
+	ServiceSnapshots = Services(0x2)
+)
+
+func (s Services) Has(service Services) bool {
+	return s&service == service
+}
+
+func (s Services) String() string {
+	services := []string{}
+	if s.Has(ServiceArchive) {
+		services = append(services, "archive")
+	}
+	if s.Has(ServiceSnapshots) {
+		services = append(services, "snapshots")
+	}
+	return strings.Join(services, "|")
+}
+
+// Capabilities describes the protocol and the services of a node.
+// They are exchanged in the Salam and Aleyk messages.
+type Capabilities struct {
+	ProtocolVersion int      `cbor:"1,keyasint"`
+	Network         string   `cbor:"2,keyasint"`
+	Services        Services `cbor:"3,keyasint"`
+	PayloadTypes    []Type   `cbor:"4,keyasint"`
+}
+
+func NewCapabilities(network string, services Services, payloadTypes []Type) Capabilities {
+	return Capabilities{
+		ProtocolVersion: ProtocolVersion,
+		Network:         network,
+		Services:        services,
+		PayloadTypes:    payloadTypes,
+	}
+}
+
+// Supports returns true if the node can handle this type of payload
+func (c *Capabilities) Supports(t Type) bool {
+	for _, pt := range c.PayloadTypes {
+		if pt == t {
+			return true
+		}
+	}
+	return false
+}
+
+// IsLegacy returns true if the node didn't send any capabilities.
+// Nodes before the protocol version 1 don't send them.
+func (c *Capabilities) IsLegacy() bool {
+	return c.ProtocolVersion == 0 && c.Network == "" && c.Services == 0 && len(c.PayloadTypes) == 0
+}
+
+// CheckCompatibility returns an error if a node with these capabilities can't join our network.
+// Legacy nodes are accepted.
+func (c *Capabilities) CheckCompatibility(network string) error {
+	if c.IsLegacy() {
+		return nil
+	}
+	if c.ProtocolVersion < MinProtocolVersion {
+		return errors.Errorf(errors.ErrInvalidMessage, "unsupported protocol version %d, minimum is %d",
+			c.ProtocolVersion, MinProtocolVersion)
+	}
+	if c.Network != network {
+		return errors.Errorf(errors.ErrInvalidMessage, "different network %s, expected %s", c.Network, network)
+	}
+	return nil
+}
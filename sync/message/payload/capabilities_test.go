@@ -0,0 +1,40 @@
+package payload
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServices(t *testing.T) {
+	s := ServiceArchive | ServiceSnapshots
+	assert.True(t, s.Has(ServiceArchive))
+	assert.True(t, s.Has(ServiceSnapshots))
+	assert.Equal(t, s.String(), "archive|snapshots")
+	assert.False(t, ServiceSnapshots.Has(ServiceArchive))
+	assert.Empty(t, Services(0).String())
+}
+
+func TestCapabilities(t *testing.T) {
+	payloadTypes := []Type{PayloadTypeSalam, PayloadTypeAleyk, PayloadTypeHeartBeat}
+	c := NewCapabilities("zarb", ServiceArchive, payloadTypes)
+	assert.NoError(t, c.CheckCompatibility("zarb"))
+	assert.True(t, c.Supports(PayloadTypeSalam))
+	assert.False(t, c.Supports(PayloadTypeQuerySnapshot))
+
+	t.Run("Different network", func(t *testing.T) {
+		assert.Error(t, c.CheckCompatibility("zarb-testnet"))
+	})
+
+	t.Run("Old protocol version", func(t *testing.T) {
+		c := NewCapabilities("zarb", ServiceArchive, payloadTypes)
+		c.ProtocolVersion = MinProtocolVersion - 1
+		assert.Error(t, c.CheckCompatibility("zarb"))
+	})
+
+	t.Run("Legacy node without capabilities", func(t *testing.T) {
+		c := Capabilities{}
+		assert.True(t, c.IsLegacy())
+		assert.NoError(t, c.CheckCompatibility("zarb"))
+	})
+}
@@ -0,0 +1,48 @@
+package payload
+
+import (
+	"fmt"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/zarbchain/zarb-go/crypto"
+	"github.com/zarbchain/zarb-go/errors"
+	"github.com/zarbchain/zarb-go/util"
+)
+
+type ChunkRequestPayload struct {
+	SessionID    int         `cbor:"1,keyasint"`
+	Target       peer.ID     `cbor:"2,keyasint"`
+	ManifestHash crypto.Hash `cbor:"3,keyasint"`
+	Index        int         `cbor:"4,keyasint"`
+}
+
+func NewChunkRequestPayload(sid int, target peer.ID, manifestHash crypto.Hash, index int) Payload {
+	return &ChunkRequestPayload{
+		SessionID:    sid,
+		Target:       target,
+		ManifestHash: manifestHash,
+		Index:        index,
+	}
+}
+
+func (p *ChunkRequestPayload) SanityCheck() error {
+	if err := p.Target.Validate(); err != nil {
+		return errors.Errorf(errors.ErrInvalidMessage, "invalid target peer id: %v", err)
+	}
+	if err := p.ManifestHash.SanityCheck(); err != nil {
+		return errors.Errorf(errors.ErrInvalidMessage, "invalid manifest hash: %v", err)
+	}
+	if p.Index < 0 {
+		return errors.Errorf(errors.ErrInvalidMessage, "invalid index")
+	}
+	return nil
+}
+
+func (p *ChunkRequestPayload) Type() Type {
+	return PayloadTypeChunkRequest
+}
+
+func (p *ChunkRequestPayload) Fingerprint() string {
+	return fmt.Sprintf("{⚓ %d %v %v#%v}", p.SessionID, util.FingerprintPeerID(p.Target),
+		p.ManifestHash.Fingerprint(), p.Index)
+}
@@ -0,0 +1,41 @@
+package payload
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zarbchain/zarb-go/crypto"
+	"github.com/zarbchain/zarb-go/util"
+)
+
+func TestChunkRequestType(t *testing.T) {
+	p := &ChunkRequestPayload{}
+	assert.Equal(t, p.Type(), PayloadTypeChunkRequest)
+}
+
+func TestChunkRequestPayload(t *testing.T) {
+	t.Run("Invalid target", func(t *testing.T) {
+		p := NewChunkRequestPayload(1, "", crypto.GenerateTestHash(), 0)
+
+		assert.Error(t, p.SanityCheck())
+	})
+
+	t.Run("Invalid manifest hash", func(t *testing.T) {
+		p := NewChunkRequestPayload(1, util.RandomPeerID(), crypto.UndefHash, 0)
+
+		assert.Error(t, p.SanityCheck())
+	})
+
+	t.Run("Invalid index", func(t *testing.T) {
+		p := NewChunkRequestPayload(1, util.RandomPeerID(), crypto.GenerateTestHash(), -1)
+
+		assert.Error(t, p.SanityCheck())
+	})
+
+	t.Run("OK", func(t *testing.T) {
+		p := NewChunkRequestPayload(1, util.RandomPeerID(), crypto.GenerateTestHash(), 7)
+
+		assert.NoError(t, p.SanityCheck())
+		assert.Contains(t, p.Fingerprint(), "#7")
+	})
+}
@@ -0,0 +1,46 @@
+package payload
+
+import (
+	"fmt"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/zarbchain/zarb-go/errors"
+	"github.com/zarbchain/zarb-go/state/snapshot"
+)
+
+type ChunkResponsePayload struct {
+	ResponseCode ResponseCode    `cbor:"1,keyasint"`
+	SessionID    int             `cbor:"2,keyasint"`
+	Target       peer.ID         `cbor:"3,keyasint"`
+	Chunk        *snapshot.Chunk `cbor:"4,keyasint"`
+}
+
+func NewChunkResponsePayload(code ResponseCode, sid int, target peer.ID, chunk *snapshot.Chunk) Payload {
+	return &ChunkResponsePayload{
+		ResponseCode: code,
+		SessionID:    sid,
+		Target:       target,
+		Chunk:        chunk,
+	}
+}
+
+func (p *ChunkResponsePayload) SanityCheck() error {
+	if err := p.Target.Validate(); err != nil {
+		return errors.Errorf(errors.ErrInvalidMessage, "invalid target peer id: %v", err)
+	}
+	if p.ResponseCode == ResponseCodeOK && p.Chunk == nil {
+		return errors.Errorf(errors.ErrInvalidMessage, "no chunk")
+	}
+	return nil
+}
+
+func (p *ChunkResponsePayload) Type() Type {
+	return PayloadTypeChunkResponse
+}
+
+func (p *ChunkResponsePayload) Fingerprint() string {
+	if p.Chunk == nil {
+		return fmt.Sprintf("{⚓ %d %s}", p.SessionID, p.ResponseCode)
+	}
+	return fmt.Sprintf("{⚓ %d %s #%v}", p.SessionID, p.ResponseCode, p.Chunk.Index())
+}
@@ -0,0 +1,43 @@
+package payload
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zarbchain/zarb-go/state/snapshot"
+	"github.com/zarbchain/zarb-go/util"
+)
+
+func TestChunkResponseType(t *testing.T) {
+	p := &ChunkResponsePayload{}
+	assert.Equal(t, p.Type(), PayloadTypeChunkResponse)
+}
+
+func TestChunkResponsePayload(t *testing.T) {
+	s, _, _ := snapshot.GenerateTestSnapshot(10)
+
+	t.Run("Invalid target", func(t *testing.T) {
+		p := NewChunkResponsePayload(ResponseCodeOK, 1, "", s.Chunks()[0])
+
+		assert.Error(t, p.SanityCheck())
+	})
+
+	t.Run("No chunk", func(t *testing.T) {
+		p := NewChunkResponsePayload(ResponseCodeOK, 1, util.RandomPeerID(), nil)
+
+		assert.Error(t, p.SanityCheck())
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		p := NewChunkResponsePayload(ResponseCodeRejected, 1, util.RandomPeerID(), nil)
+
+		assert.NoError(t, p.SanityCheck())
+	})
+
+	t.Run("OK", func(t *testing.T) {
+		p := NewChunkResponsePayload(ResponseCodeOK, 1, util.RandomPeerID(), s.Chunks()[0])
+
+		assert.NoError(t, p.SanityCheck())
+		assert.Contains(t, p.Fingerprint(), "#0")
+	})
+}
@@ -51,6 +51,10 @@ const (
 	PayloadTypeBlockAnnounce        = Type(12)
 	PayloadTypeDownloadRequest      = Type(13)
 	PayloadTypeDownloadResponse     = Type(14)
+	PayloadTypeQuerySnapshot        = Type(15)
+	PayloadTypeSnapshot             = Type(16)
+	PayloadTypeChunkRequest         = Type(17)
+	PayloadTypeChunkResponse        = Type(18)
 )
 
 func (t Type) String() string {
@@ -83,6 +87,14 @@ func (t Type) String() string {
 		return "download-req"
 	case PayloadTypeDownloadResponse:
 		return "download-res"
+	case PayloadTypeQuerySnapshot:
+		return "query-snapshot"
+	case PayloadTypeSnapshot:
+		return "snapshot"
+	case PayloadTypeChunkRequest:
+		return "chunk-req"
+	case PayloadTypeChunkResponse:
+		return "chunk-res"
 	}
 	return fmt.Sprintf("%d", t)
 }
@@ -117,6 +129,14 @@ func MakePayload(t Type) Payload {
 		return &DownloadRequestPayload{}
 	case PayloadTypeDownloadResponse:
 		return &DownloadResponsePayload{}
+	case PayloadTypeQuerySnapshot:
+		return &QuerySnapshotPayload{}
+	case PayloadTypeSnapshot:
+		return &SnapshotPayload{}
+	case PayloadTypeChunkRequest:
+		return &ChunkRequestPayload{}
+	case PayloadTypeChunkResponse:
+		return &ChunkResponsePayload{}
 	}
 
 	//
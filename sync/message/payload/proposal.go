@@ -1,12 +1,19 @@
 package payload
 
 import (
+	"fmt"
+
 	"github.com/zarbchain/zarb-go/consensus/proposal"
 	"github.com/zarbchain/zarb-go/errors"
+	"github.com/zarbchain/zarb-go/tx"
 )
 
+// ProposalPayload carries the proposal, which has only the block header and the transaction IDs.
+// A full proposal also carries the transactions of the block,
+// it is sent when a peer can't find the missing transactions.
 type ProposalPayload struct {
-	Proposal *proposal.Proposal `cbor:"1,keyasint"`
+	Proposal     *proposal.Proposal `cbor:"1,keyasint"`
+	Transactions []*tx.Tx           `cbor:"2,keyasint,omitempty"`
 }
 
 func NewProposalPayload(p *proposal.Proposal) Payload {
@@ -15,10 +22,31 @@ func NewProposalPayload(p *proposal.Proposal) Payload {
 	}
 }
 
+func NewFullProposalPayload(p *proposal.Proposal, trxs []*tx.Tx) Payload {
+	return &ProposalPayload{
+		Proposal:     p,
+		Transactions: trxs,
+	}
+}
+
 func (p *ProposalPayload) SanityCheck() error {
 	if err := p.Proposal.SanityCheck(); err != nil {
 		return errors.Errorf(errors.ErrInvalidMessage, err.Error())
 	}
+	if len(p.Transactions) > 0 {
+		ids := make(map[tx.ID]bool)
+		for _, id := range p.Proposal.Block().TxIDs().IDs() {
+			ids[id] = true
+		}
+		for _, trx := range p.Transactions {
+			if err := trx.SanityCheck(); err != nil {
+				return err
+			}
+			if !ids[trx.ID()] {
+				return errors.Errorf(errors.ErrInvalidMessage, "transaction %v is not in the block", trx.ID().Fingerprint())
+			}
+		}
+	}
 
 	return nil
 }
@@ -28,5 +56,8 @@ func (p *ProposalPayload) Type() Type {
 }
 
 func (p *ProposalPayload) Fingerprint() string {
+	if len(p.Transactions) > 0 {
+		return fmt.Sprintf("%v ⌘ %v", p.Proposal.Fingerprint(), len(p.Transactions))
+	}
 	return p.Proposal.Fingerprint()
 }
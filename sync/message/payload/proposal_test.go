@@ -4,7 +4,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/zarbchain/zarb-go/block"
 	"github.com/zarbchain/zarb-go/consensus/proposal"
+	"github.com/zarbchain/zarb-go/crypto"
+	"github.com/zarbchain/zarb-go/tx"
 )
 
 func TestProposalType(t *testing.T) {
@@ -28,3 +31,25 @@ func TestProposalPayload(t *testing.T) {
 		assert.Contains(t, p.Fingerprint(), "100")
 	})
 }
+
+func TestFullProposalPayload(t *testing.T) {
+	t.Run("Transaction is not in the block", func(t *testing.T) {
+		proposal, _ := proposal.GenerateTestProposal(100, 0)
+		trx, _ := tx.GenerateTestSendTx()
+		p := NewFullProposalPayload(proposal, []*tx.Tx{trx})
+
+		assert.Error(t, p.SanityCheck())
+	})
+
+	t.Run("OK", func(t *testing.T) {
+		addr, _, pv := crypto.GenerateTestKeyPair()
+		b, trxs := block.GenerateTestBlock(&addr, nil)
+		proposal := proposal.NewProposal(100, 0, b)
+		sig := pv.Sign(proposal.SignBytes())
+		proposal.SetSignature(sig)
+		p := NewFullProposalPayload(proposal, trxs)
+
+		assert.NoError(t, p.SanityCheck())
+		assert.Contains(t, p.Fingerprint(), "⌘ 4")
+	})
+}
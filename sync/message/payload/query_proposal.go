@@ -6,9 +6,12 @@ import (
 	"github.com/zarbchain/zarb-go/errors"
 )
 
+// QueryProposalPayload asks for the proposal of the given height and round.
+// If Full is set, the transactions of the block should be sent with the proposal.
 type QueryProposalPayload struct {
-	Height int `cbor:"1,keyasint"`
-	Round  int `cbor:"2,keyasint"`
+	Height int  `cbor:"1,keyasint"`
+	Round  int  `cbor:"2,keyasint"`
+	Full   bool `cbor:"3,keyasint,omitempty"`
 }
 
 func NewQueryProposalPayload(h, r int) Payload {
@@ -18,6 +21,14 @@ func NewQueryProposalPayload(h, r int) Payload {
 	}
 }
 
+func NewFullQueryProposalPayload(h, r int) Payload {
+	return &QueryProposalPayload{
+		Height: h,
+		Round:  r,
+		Full:   true,
+	}
+}
+
 func (p *QueryProposalPayload) SanityCheck() error {
 	if p.Height < 0 {
 		return errors.Errorf(errors.ErrInvalidMessage, "invalid height")
@@ -34,5 +45,8 @@ func (p *QueryProposalPayload) Type() Type {
 }
 
 func (p *QueryProposalPayload) Fingerprint() string {
+	if p.Full {
+		return fmt.Sprintf("%v/%v full", p.Height, p.Round)
+	}
 	return fmt.Sprintf("%v/%v", p.Height, p.Round)
 }
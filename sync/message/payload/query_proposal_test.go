@@ -31,3 +31,11 @@ func TestQueryProposalPayload(t *testing.T) {
 		assert.Contains(t, p.Fingerprint(), "100")
 	})
 }
+
+func TestFullQueryProposalPayload(t *testing.T) {
+	p := NewFullQueryProposalPayload(100, 1)
+
+	assert.NoError(t, p.SanityCheck())
+	assert.True(t, p.(*QueryProposalPayload).Full)
+	assert.Contains(t, p.Fingerprint(), "full")
+}
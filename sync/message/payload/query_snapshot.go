@@ -0,0 +1,36 @@
+package payload
+
+import (
+	"fmt"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/zarbchain/zarb-go/errors"
+	"github.com/zarbchain/zarb-go/util"
+)
+
+type QuerySnapshotPayload struct {
+	SessionID int     `cbor:"1,keyasint"`
+	Target    peer.ID `cbor:"2,keyasint"`
+}
+
+func NewQuerySnapshotPayload(sid int, target peer.ID) Payload {
+	return &QuerySnapshotPayload{
+		SessionID: sid,
+		Target:    target,
+	}
+}
+
+func (p *QuerySnapshotPayload) SanityCheck() error {
+	if err := p.Target.Validate(); err != nil {
+		return errors.Errorf(errors.ErrInvalidMessage, "invalid target peer id: %v", err)
+	}
+	return nil
+}
+
+func (p *QuerySnapshotPayload) Type() Type {
+	return PayloadTypeQuerySnapshot
+}
+
+func (p *QuerySnapshotPayload) Fingerprint() string {
+	return fmt.Sprintf("{⚓ %d %v}", p.SessionID, util.FingerprintPeerID(p.Target))
+}
@@ -0,0 +1,28 @@
+package payload
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zarbchain/zarb-go/util"
+)
+
+func TestQuerySnapshotType(t *testing.T) {
+	p := &QuerySnapshotPayload{}
+	assert.Equal(t, p.Type(), PayloadTypeQuerySnapshot)
+}
+
+func TestQuerySnapshotPayload(t *testing.T) {
+	t.Run("Invalid target", func(t *testing.T) {
+		p := NewQuerySnapshotPayload(1, "")
+
+		assert.Error(t, p.SanityCheck())
+	})
+
+	t.Run("OK", func(t *testing.T) {
+		p := NewQuerySnapshotPayload(1, util.RandomPeerID())
+
+		assert.NoError(t, p.SanityCheck())
+		assert.Contains(t, p.Fingerprint(), "1")
+	})
+}
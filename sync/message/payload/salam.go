@@ -5,28 +5,33 @@ import (
 
 	"github.com/zarbchain/zarb-go/crypto"
 	"github.com/zarbchain/zarb-go/errors"
+	"github.com/zarbchain/zarb-go/sync/message/codec"
 	"github.com/zarbchain/zarb-go/version"
 )
 
 type SalamPayload struct {
-	NodeVersion string           `cbor:"1,keyasint"`
-	Moniker     string           `cbor:"2,keyasint"`
-	PublicKey   crypto.PublicKey `cbor:"3,keyasint"`
-	GenesisHash crypto.Hash      `cbor:"4,keyasint"`
-	Height      int              `cbor:"5,keyasint"`
-	Flags       int              `cbor:"6,keyasint"`
+	NodeVersion  string           `cbor:"1,keyasint"`
+	Moniker      string           `cbor:"2,keyasint"`
+	PublicKey    crypto.PublicKey `cbor:"3,keyasint"`
+	GenesisHash  crypto.Hash      `cbor:"4,keyasint"`
+	Height       int              `cbor:"5,keyasint"`
+	Flags        int              `cbor:"6,keyasint"`
+	Codecs       []codec.Codec    `cbor:"7,keyasint,omitempty"`
+	Capabilities Capabilities     `cbor:"8,keyasint"`
 }
 
 func NewSalamPayload(moniker string,
 	publicKey crypto.PublicKey, genesisHash crypto.Hash,
-	height int, flags int) Payload {
+	height int, flags int, caps Capabilities) Payload {
 	return &SalamPayload{
-		NodeVersion: version.Version(),
-		Moniker:     moniker,
-		PublicKey:   publicKey,
-		GenesisHash: genesisHash,
-		Height:      height,
-		Flags:       flags,
+		NodeVersion:  version.Version(),
+		Moniker:      moniker,
+		PublicKey:    publicKey,
+		GenesisHash:  genesisHash,
+		Height:       height,
+		Flags:        flags,
+		Codecs:       codec.Supported,
+		Capabilities: caps,
 	}
 }
 
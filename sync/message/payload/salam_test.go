@@ -14,13 +14,13 @@ func TestSalamType(t *testing.T) {
 
 func TestSalamPayload(t *testing.T) {
 	t.Run("Invalid height", func(t *testing.T) {
-		p := NewSalamPayload("Eve", crypto.GenerateTestSigner().PublicKey(), crypto.GenerateTestHash(), -1, 0)
+		p := NewSalamPayload("Eve", crypto.GenerateTestSigner().PublicKey(), crypto.GenerateTestHash(), -1, 0, NewCapabilities("zarb", 0, nil))
 
 		assert.Error(t, p.SanityCheck())
 	})
 
 	t.Run("Ok", func(t *testing.T) {
-		p := NewSalamPayload("Alice", crypto.GenerateTestSigner().PublicKey(), crypto.GenerateTestHash(), 0, 0)
+		p := NewSalamPayload("Alice", crypto.GenerateTestSigner().PublicKey(), crypto.GenerateTestHash(), 0, 0, NewCapabilities("zarb", 0, nil))
 
 		assert.NoError(t, p.SanityCheck())
 		assert.Contains(t, p.Fingerprint(), "Alice")
@@ -0,0 +1,76 @@
+package payload
+
+import (
+	"fmt"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/zarbchain/zarb-go/block"
+	"github.com/zarbchain/zarb-go/errors"
+	"github.com/zarbchain/zarb-go/state/snapshot"
+	"github.com/zarbchain/zarb-go/tx"
+)
+
+// SnapshotPayload offers a snapshot to the target peer.
+// Block is the block committed right after the snapshot,
+// and certificate is the certificate of this block.
+type SnapshotPayload struct {
+	ResponseCode ResponseCode       `cbor:"1,keyasint"`
+	SessionID    int                `cbor:"2,keyasint"`
+	Target       peer.ID            `cbor:"3,keyasint"`
+	Manifest     *snapshot.Manifest `cbor:"4,keyasint"`
+	Block        *block.Block       `cbor:"5,keyasint"`
+	Certificate  *block.Certificate `cbor:"6,keyasint"`
+	Transactions []*tx.Tx           `cbor:"7,keyasint"`
+}
+
+func NewSnapshotPayload(code ResponseCode, sid int, target peer.ID,
+	manifest *snapshot.Manifest, b *block.Block, cert *block.Certificate, trxs []*tx.Tx) Payload {
+	return &SnapshotPayload{
+		ResponseCode: code,
+		SessionID:    sid,
+		Target:       target,
+		Manifest:     manifest,
+		Block:        b,
+		Certificate:  cert,
+		Transactions: trxs,
+	}
+}
+
+func (p *SnapshotPayload) SanityCheck() error {
+	if err := p.Target.Validate(); err != nil {
+		return errors.Errorf(errors.ErrInvalidMessage, "invalid target peer id: %v", err)
+	}
+	if p.ResponseCode != ResponseCodeOK {
+		return nil
+	}
+	if p.Manifest == nil || p.Block == nil || p.Certificate == nil {
+		return errors.Errorf(errors.ErrInvalidMessage, "incomplete snapshot offer")
+	}
+	if err := p.Manifest.SanityCheck(); err != nil {
+		return errors.Errorf(errors.ErrInvalidMessage, "invalid manifest: %v", err)
+	}
+	if err := p.Block.SanityCheck(); err != nil {
+		return errors.Errorf(errors.ErrInvalidMessage, "invalid block: %v", err)
+	}
+	if err := p.Certificate.SanityCheck(); err != nil {
+		return errors.Errorf(errors.ErrInvalidMessage, "invalid certificate: %v", err)
+	}
+	for _, trx := range p.Transactions {
+		if err := trx.SanityCheck(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *SnapshotPayload) Type() Type {
+	return PayloadTypeSnapshot
+}
+
+func (p *SnapshotPayload) Fingerprint() string {
+	if p.Manifest == nil {
+		return fmt.Sprintf("{⚓ %d %s}", p.SessionID, p.ResponseCode)
+	}
+	return fmt.Sprintf("{⚓ %d %s %v %v}", p.SessionID, p.ResponseCode,
+		p.Manifest.BlockHeight(), p.Manifest.Hash().Fingerprint())
+}
@@ -0,0 +1,44 @@
+package payload
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zarbchain/zarb-go/state/snapshot"
+	"github.com/zarbchain/zarb-go/util"
+)
+
+func TestSnapshotType(t *testing.T) {
+	p := &SnapshotPayload{}
+	assert.Equal(t, p.Type(), PayloadTypeSnapshot)
+}
+
+func TestSnapshotPayload(t *testing.T) {
+	s, b, c := snapshot.GenerateTestSnapshot(10)
+
+	t.Run("Invalid target", func(t *testing.T) {
+		p := NewSnapshotPayload(ResponseCodeOK, 1, "", s.Manifest(), b, c, nil)
+
+		assert.Error(t, p.SanityCheck())
+	})
+
+	t.Run("Incomplete offer", func(t *testing.T) {
+		p := NewSnapshotPayload(ResponseCodeOK, 1, util.RandomPeerID(), s.Manifest(), nil, c, nil)
+
+		assert.Error(t, p.SanityCheck())
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		p := NewSnapshotPayload(ResponseCodeRejected, 1, util.RandomPeerID(), nil, nil, nil, nil)
+
+		assert.NoError(t, p.SanityCheck())
+		assert.Contains(t, p.Fingerprint(), "rejected")
+	})
+
+	t.Run("OK", func(t *testing.T) {
+		p := NewSnapshotPayload(ResponseCodeOK, 1, util.RandomPeerID(), s.Manifest(), b, c, nil)
+
+		assert.NoError(t, p.SanityCheck())
+		assert.Contains(t, p.Fingerprint(), s.Manifest().Hash().Fingerprint())
+	})
+}
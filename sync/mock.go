@@ -5,7 +5,9 @@ import (
 
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/zarbchain/zarb-go/crypto"
+	"github.com/zarbchain/zarb-go/network"
 	"github.com/zarbchain/zarb-go/sync/peerset"
+	"github.com/zarbchain/zarb-go/sync/scheduler"
 	"github.com/zarbchain/zarb-go/util"
 	"github.com/zarbchain/zarb-go/version"
 )
@@ -13,8 +15,10 @@ import (
 var _ Synchronizer = &MockSync{}
 
 type MockSync struct {
-	ID      peer.ID
-	PeerSet *peerset.PeerSet
+	ID        peer.ID
+	PeerSet   *peerset.PeerSet
+	Progress  scheduler.Progress
+	Addresses []network.AddressInfo
 }
 
 func MockingSync() *MockSync {
@@ -61,6 +65,14 @@ func (m *MockSync) Peers() []*peerset.Peer {
 	return m.PeerSet.GetPeerList()
 }
 
+func (m *MockSync) DownloadProgress() scheduler.Progress {
+	return m.Progress
+}
+
+func (m *MockSync) AddressBook() []network.AddressInfo {
+	return m.Addresses
+}
+
 // AddPeer will add new peer to mocked PeerSet
 func (m *MockSync) AddPeer(name string, height int) *peerset.Peer {
 	newPeer := m.PeerSet.MustGetPeer(util.RandomPeerID())
@@ -7,6 +7,8 @@ import (
 
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/zarbchain/zarb-go/crypto"
+	"github.com/zarbchain/zarb-go/sync/message/codec"
+	"github.com/zarbchain/zarb-go/sync/message/payload"
 	"github.com/zarbchain/zarb-go/util"
 )
 
@@ -37,6 +39,13 @@ func (code StatusCode) MarshalJSON() ([]byte, error) {
 type Peer struct {
 	lk   sync.RWMutex
 	data peerData
+	// served keeps the ranges of blocks that we have sent to the peer, sorted and merged
+	served []blockRange
+}
+
+type blockRange struct {
+	from int
+	to   int
 }
 
 type peerData struct {
@@ -47,6 +56,8 @@ type peerData struct {
 	Address              *crypto.Address
 	PublicKey            crypto.PublicKey
 	InitialBlockDownload bool
+	Codecs               []codec.Codec
+	Capabilities         payload.Capabilities
 	Height               int
 	ReceivedMessages     int
 	InvalidMessages      int
@@ -110,6 +121,36 @@ func (p *Peer) InitialBlockDownload() bool {
 	return p.data.InitialBlockDownload
 }
 
+func (p *Peer) Codecs() []codec.Codec {
+	p.lk.RLock()
+	defer p.lk.RUnlock()
+
+	return p.data.Codecs
+}
+
+func (p *Peer) Capabilities() payload.Capabilities {
+	p.lk.RLock()
+	defer p.lk.RUnlock()
+
+	return p.data.Capabilities
+}
+
+// HasService returns true if the peer offers this service
+func (p *Peer) HasService(service payload.Services) bool {
+	p.lk.RLock()
+	defer p.lk.RUnlock()
+
+	return p.data.Capabilities.Services.Has(service)
+}
+
+// SupportsPayload returns true if the peer can handle this type of payload
+func (p *Peer) SupportsPayload(t payload.Type) bool {
+	p.lk.RLock()
+	defer p.lk.RUnlock()
+
+	return p.data.Capabilities.Supports(t)
+}
+
 func (p *Peer) ReceivedMessages() int {
 	p.lk.RLock()
 	defer p.lk.RUnlock()
@@ -152,6 +193,20 @@ func (p *Peer) UpdateInitialBlockDownload(initialBlockDownload bool) {
 	p.data.InitialBlockDownload = initialBlockDownload
 }
 
+func (p *Peer) UpdateCodecs(codecs []codec.Codec) {
+	p.lk.Lock()
+	defer p.lk.Unlock()
+
+	p.data.Codecs = codecs
+}
+
+func (p *Peer) UpdateCapabilities(caps payload.Capabilities) {
+	p.lk.Lock()
+	defer p.lk.Unlock()
+
+	p.data.Capabilities = caps
+}
+
 func (p *Peer) UpdateNodeVersion(version string) {
 	p.lk.Lock()
 	defer p.lk.Unlock()
@@ -220,6 +275,37 @@ func (p *Peer) IncreaseInvalidMessage() {
 	p.data.InvalidMessages++
 }
 
+// HasServed returns true if we have sent any block of this range to the peer before
+func (p *Peer) HasServed(from, to int) bool {
+	p.lk.RLock()
+	defer p.lk.RUnlock()
+
+	for _, r := range p.served {
+		if from <= r.to && to >= r.from {
+			return true
+		}
+	}
+	return false
+}
+
+// AddServed keeps the range of blocks that we have sent to the peer
+func (p *Peer) AddServed(from, to int) {
+	p.lk.Lock()
+	defer p.lk.Unlock()
+
+	served := make([]blockRange, 0, len(p.served)+1)
+	i := 0
+	for ; i < len(p.served) && p.served[i].to+1 < from; i++ {
+		served = append(served, p.served[i])
+	}
+	for ; i < len(p.served) && p.served[i].from <= to+1; i++ {
+		from = util.Min(from, p.served[i].from)
+		to = util.Max(to, p.served[i].to)
+	}
+	served = append(served, blockRange{from: from, to: to})
+	p.served = append(served, p.served[i:]...)
+}
+
 func (p *Peer) MarshalJSON() ([]byte, error) {
 	p.lk.RLock()
 	defer p.lk.RUnlock()
@@ -17,6 +17,7 @@ type PeerSet struct {
 
 	peers            map[peer.ID]*Peer
 	sessions         map[int]*Session
+	expiredSessions  []*Session
 	nextSessionID    int
 	maxClaimedHeight int
 	sessionTimeout   time.Duration
@@ -78,11 +79,25 @@ func (ps *PeerSet) HasAnyOpenSession() bool {
 	return len(ps.sessions) != 0
 }
 
+// ExpiredSessions removes the expired sessions and returns them,
+// including the sessions that are removed before. The peers of these sessions didn't answer in time.
+func (ps *PeerSet) ExpiredSessions() []*Session {
+	ps.lk.Lock()
+	defer ps.lk.Unlock()
+
+	ps.removeExpiredSessions()
+	expired := ps.expiredSessions
+	ps.expiredSessions = nil
+
+	return expired
+}
+
 func (ps *PeerSet) removeExpiredSessions() {
 	// First remove old sessions
 	for id, s := range ps.sessions {
 		if ps.sessionTimeout < util.Now().Sub(s.LastActivityAt()) {
 			delete(ps.sessions, id)
+			ps.expiredSessions = append(ps.expiredSessions, s)
 		}
 	}
 }
@@ -146,6 +161,7 @@ func (ps *PeerSet) Clear() {
 
 	ps.peers = make(map[peer.ID]*Peer)
 	ps.sessions = make(map[int]*Session)
+	ps.expiredSessions = nil
 	ps.maxClaimedHeight = 0
 }
 
@@ -0,0 +1,101 @@
+package relay
+
+import (
+	"sync"
+
+	"github.com/zarbchain/zarb-go/consensus/proposal"
+	"github.com/zarbchain/zarb-go/crypto"
+	"github.com/zarbchain/zarb-go/tx"
+)
+
+// Relay keeps the proposals that are waiting for their missing transactions.
+// A proposal carries only the block header and the transaction IDs,
+// the block is rebuilt from the transaction pool once all the transactions are received.
+type Relay struct {
+	lk sync.Mutex
+
+	pendings map[crypto.Hash]*pending
+}
+
+type pending struct {
+	proposal *proposal.Proposal
+	missed   map[tx.ID]bool
+}
+
+func NewRelay() *Relay {
+	return &Relay{
+		pendings: make(map[crypto.Hash]*pending),
+	}
+}
+
+// AddProposal keeps the proposal until the missed transactions are received.
+// It returns false if the proposal is already waiting.
+func (r *Relay) AddProposal(p *proposal.Proposal, missed []tx.ID) bool {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	if _, ok := r.pendings[p.Hash()]; ok {
+		return false
+	}
+	pen := &pending{
+		proposal: p,
+		missed:   make(map[tx.ID]bool),
+	}
+	for _, id := range missed {
+		pen.missed[id] = true
+	}
+	r.pendings[p.Hash()] = pen
+	return true
+}
+
+// RemoveProposal removes the waiting proposal and returns it.
+// It returns nil if the proposal is not waiting.
+func (r *Relay) RemoveProposal(hash crypto.Hash) *proposal.Proposal {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	pen, ok := r.pendings[hash]
+	if !ok {
+		return nil
+	}
+	delete(r.pendings, hash)
+	return pen.proposal
+}
+
+// AddTransactions marks the transactions as received.
+// It returns the proposals that are not waiting for any transaction anymore and removes them.
+func (r *Relay) AddTransactions(ids []tx.ID) []*proposal.Proposal {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	completed := []*proposal.Proposal{}
+	for hash, pen := range r.pendings {
+		for _, id := range ids {
+			delete(pen.missed, id)
+		}
+		if len(pen.missed) == 0 {
+			completed = append(completed, pen.proposal)
+			delete(r.pendings, hash)
+		}
+	}
+	return completed
+}
+
+// Prune removes the proposals with a height lower than the given height.
+func (r *Relay) Prune(height int) {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	for hash, pen := range r.pendings {
+		if pen.proposal.Height() < height {
+			delete(r.pendings, hash)
+		}
+	}
+}
+
+func (r *Relay) Len() int {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	return len(r.pendings)
+}
@@ -0,0 +1,52 @@
+package relay
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zarbchain/zarb-go/consensus/proposal"
+)
+
+func TestAddTransactions(t *testing.T) {
+	r := NewRelay()
+	p1, _ := proposal.GenerateTestProposal(10, 0)
+	p2, _ := proposal.GenerateTestProposal(10, 1)
+	ids1 := p1.Block().TxIDs().IDs()
+	ids2 := p2.Block().TxIDs().IDs()
+
+	assert.True(t, r.AddProposal(p1, ids1[:2]))
+	assert.False(t, r.AddProposal(p1, ids1[:2]))
+	assert.True(t, r.AddProposal(p2, ids2))
+	assert.Equal(t, r.Len(), 2)
+
+	assert.Empty(t, r.AddTransactions(ids1[:1]))
+	assert.Empty(t, r.AddTransactions(ids2[:1]))
+
+	completed := r.AddTransactions(ids1)
+	assert.Equal(t, len(completed), 1)
+	assert.Equal(t, completed[0].Hash(), p1.Hash())
+	assert.Equal(t, r.Len(), 1)
+}
+
+func TestRemoveProposal(t *testing.T) {
+	r := NewRelay()
+	p, _ := proposal.GenerateTestProposal(10, 0)
+
+	assert.Nil(t, r.RemoveProposal(p.Hash()))
+	r.AddProposal(p, p.Block().TxIDs().IDs())
+	assert.Equal(t, r.RemoveProposal(p.Hash()).Hash(), p.Hash())
+	assert.Nil(t, r.RemoveProposal(p.Hash()))
+}
+
+func TestPrune(t *testing.T) {
+	r := NewRelay()
+	p1, _ := proposal.GenerateTestProposal(10, 0)
+	p2, _ := proposal.GenerateTestProposal(11, 0)
+
+	r.AddProposal(p1, p1.Block().TxIDs().IDs())
+	r.AddProposal(p2, p2.Block().TxIDs().IDs())
+	r.Prune(11)
+	assert.Equal(t, r.Len(), 1)
+	assert.Nil(t, r.RemoveProposal(p1.Hash()))
+	assert.NotNil(t, r.RemoveProposal(p2.Hash()))
+}
@@ -0,0 +1,570 @@
+package scheduler
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/zarbchain/zarb-go/block"
+	"github.com/zarbchain/zarb-go/crypto"
+	"github.com/zarbchain/zarb-go/errors"
+	"github.com/zarbchain/zarb-go/sync/peerset"
+	"github.com/zarbchain/zarb-go/util"
+)
+
+// Scheduler splits the missing blocks into chunks and downloads them from several peers in parallel.
+// Faster peers get the lower chunks, so the blocks can be committed sooner.
+// Each peer can have more than one chunk in flight, to avoid waiting for the round trips.
+// Received blocks are kept in a reorder buffer and they are committed in order.
+type Scheduler struct {
+	lk       sync.Mutex
+	commitLk sync.Mutex
+
+	chunkSize int
+	pipeline  int
+	window    int
+
+	height   int
+	hash     crypto.Hash
+	target   int
+	nextFrom int
+	queue    []*chunk
+	inFlight map[int]*chunk
+	buffer   map[int]*buffered
+	certs    map[int]*buffered
+	peers    map[peer.ID]*peerStats
+
+	// unverifiable is the first height that its certificate can't be verified yet, zero if there is none
+	unverifiable int
+
+	startedAt   time.Time
+	startHeight int
+}
+
+type chunk struct {
+	from     int
+	to       int
+	pid      peer.ID
+	excluded peer.ID
+	activeAt time.Time
+}
+
+type buffered struct {
+	block  *block.Block
+	cert   *block.Certificate
+	source peer.ID
+}
+
+type peerStats struct {
+	// throughput is the number of blocks per second that the peer sends us
+	throughput float64
+	inFlight   int
+}
+
+// Request is a download request that should be sent to the peer
+type Request struct {
+	SessionID int
+	PeerID    peer.ID
+	From      int
+	To        int
+}
+
+// Progress shows the progress of downloading blocks
+type Progress struct {
+	Downloading     bool
+	Height          int
+	TargetHeight    int
+	BlocksPerSecond float64
+	ETA             time.Duration
+}
+
+// NewScheduler creates a new scheduler.
+// chunkSize is the number of blocks in each download request,
+// pipeline is the number of requests in flight for each peer and
+// window is the maximum number of blocks that can be downloaded ahead of the committed blocks.
+func NewScheduler(chunkSize, pipeline, window int) *Scheduler {
+	return &Scheduler{
+		chunkSize: chunkSize,
+		pipeline:  pipeline,
+		window:    window,
+		inFlight:  make(map[int]*chunk),
+		buffer:    make(map[int]*buffered),
+		certs:     make(map[int]*buffered),
+		peers:     make(map[peer.ID]*peerStats),
+	}
+}
+
+// SetTarget updates the last committed block and the height that should be downloaded.
+func (s *Scheduler) SetTarget(height int, hash crypto.Hash, target int) {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	if !s.isActive() && target > height {
+		s.startedAt = util.Now()
+		s.startHeight = height
+	}
+	s.updateHeight(height, hash)
+	s.target = target
+}
+
+func (s *Scheduler) IsActive() bool {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	return s.isActive()
+}
+
+func (s *Scheduler) isActive() bool {
+	return s.target > s.height
+}
+
+func (s *Scheduler) updateHeight(height int, hash crypto.Hash) {
+	if height < s.height {
+		return
+	}
+	s.height = height
+	s.hash = hash
+	if s.unverifiable > 0 && s.unverifiable <= height+1 {
+		// Now we have the committee of this height
+		s.unverifiable = 0
+	}
+	if s.nextFrom <= height {
+		s.nextFrom = height + 1
+	}
+	for h := range s.buffer {
+		if h <= height {
+			delete(s.buffer, h)
+		}
+	}
+	for h := range s.certs {
+		if h <= height {
+			delete(s.certs, h)
+		}
+	}
+	queue := s.queue[:0]
+	for _, c := range s.queue {
+		if c.to > height {
+			queue = append(queue, c)
+		}
+	}
+	s.queue = queue
+}
+
+// Schedule assigns the chunks to the peers and returns the requests that should be sent.
+// capacity is the maximum number of new requests and openSession should open a session for the request.
+func (s *Scheduler) Schedule(peers []*peerset.Peer, capacity int, openSession func(pid peer.ID) int) []Request {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	reqs := []Request{}
+	if !s.isActive() {
+		return reqs
+	}
+	s.splitChunks(capacity)
+	peers = append([]*peerset.Peer{}, peers...)
+	s.sortPeers(peers)
+
+	// In the first pass, the chunks are not assigned to the peers that failed to send them before.
+	for pass := 0; pass < 2; pass++ {
+		for round := 0; round < s.pipeline; round++ {
+			for _, p := range peers {
+				if len(reqs) >= capacity || len(s.queue) == 0 {
+					return reqs
+				}
+				st := s.stats(p.PeerID())
+				if st.inFlight > round {
+					continue
+				}
+				i := s.findChunk(p, pass > 0)
+				if i < 0 {
+					continue
+				}
+				c := s.queue[i]
+				s.queue = append(s.queue[:i], s.queue[i+1:]...)
+
+				sid := openSession(p.PeerID())
+				c.pid = p.PeerID()
+				c.activeAt = util.Now()
+				s.inFlight[sid] = c
+				st.inFlight++
+
+				reqs = append(reqs, Request{
+					SessionID: sid,
+					PeerID:    p.PeerID(),
+					From:      c.from,
+					To:        c.to,
+				})
+			}
+		}
+	}
+	return reqs
+}
+
+// splitChunks splits the next heights into chunks, but not further than the window.
+func (s *Scheduler) splitChunks(count int) {
+	for len(s.queue) < count {
+		if s.nextFrom > s.target || s.nextFrom > s.height+s.window {
+			return
+		}
+		to := s.nextFrom + s.chunkSize - 1
+		if to > s.target {
+			to = s.target
+		}
+		s.queue = append(s.queue, &chunk{from: s.nextFrom, to: to})
+		s.nextFrom = to + 1
+	}
+}
+
+// sortPeers sorts the peers by their throughput, the fastest first.
+// Peers without any measurement are considered as average.
+func (s *Scheduler) sortPeers(peers []*peerset.Peer) {
+	sum := float64(0)
+	count := 0
+	for _, st := range s.peers {
+		if st.throughput > 0 {
+			sum += st.throughput
+			count++
+		}
+	}
+	avg := float64(0)
+	if count > 0 {
+		avg = sum / float64(count)
+	}
+	throughput := func(pid peer.ID) float64 {
+		st, ok := s.peers[pid]
+		if !ok || st.throughput == 0 {
+			return avg
+		}
+		return st.throughput
+	}
+	sort.SliceStable(peers, func(i, j int) bool {
+		return throughput(peers[i].PeerID()) > throughput(peers[j].PeerID())
+	})
+}
+
+// findChunk returns the index of the lowest chunk that the peer has.
+// Chunks after an unverifiable certificate are not assigned, until we commit enough blocks to verify it.
+func (s *Scheduler) findChunk(p *peerset.Peer, allowExcluded bool) int {
+	for i, c := range s.queue {
+		if s.unverifiable > 0 && c.from >= s.unverifiable {
+			continue
+		}
+		if p.Height() < c.to {
+			continue
+		}
+		if !allowExcluded && c.excluded == p.PeerID() {
+			continue
+		}
+		return i
+	}
+	return -1
+}
+
+func (s *Scheduler) stats(pid peer.ID) *peerStats {
+	st, ok := s.peers[pid]
+	if !ok {
+		st = &peerStats{}
+		s.peers[pid] = st
+	}
+	return st
+}
+
+// AddBlocks adds the received blocks to the reorder buffer.
+// It verifies that each block refers to the hash and the certificate of its previous block,
+// and verifies the certificates by verifyFn before buffering the blocks.
+// It returns false if the session doesn't belong to the scheduler.
+func (s *Scheduler) AddBlocks(sessionID int, from int, blocks []*block.Block,
+	verifyFn func(height int, cert *block.Certificate) error) (bool, error) {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	c, ok := s.inFlight[sessionID]
+	if !ok {
+		return false, nil
+	}
+	if len(blocks) == 0 {
+		return true, nil
+	}
+	to := from + len(blocks) - 1
+	// The last response has one extra block, which has the certificate of the last block
+	if from < c.from || to > c.to+1 {
+		s.fail(sessionID)
+		return true, errors.Errorf(errors.ErrInvalidBlock, "blocks are out of range: %v-%v", from, to)
+	}
+	if err := s.verifyLinks(from, blocks); err != nil {
+		s.fail(sessionID)
+		return true, err
+	}
+	blocks, err := s.verifyCertificates(from, blocks, verifyFn)
+	if err != nil {
+		s.fail(sessionID)
+		return true, err
+	}
+	if len(blocks) == 0 {
+		return true, nil
+	}
+
+	for i, b := range blocks {
+		h := from + i
+		// The certificate of each block is inside the next block
+		if h-1 > s.height {
+			if _, ok := s.certs[h-1]; !ok {
+				s.certs[h-1] = &buffered{cert: b.LastCertificate(), source: c.pid}
+			}
+		}
+		if h <= s.height || h > c.to {
+			continue
+		}
+		if _, ok := s.buffer[h]; !ok {
+			s.buffer[h] = &buffered{block: b, source: c.pid}
+		}
+	}
+
+	st := s.stats(c.pid)
+	elapsed := util.Now().Sub(c.activeAt).Seconds()
+	if elapsed > 0 {
+		rate := float64(len(blocks)) / elapsed
+		if st.throughput == 0 {
+			st.throughput = rate
+		} else {
+			st.throughput = (st.throughput + rate) / 2
+		}
+	}
+	c.activeAt = util.Now()
+
+	if s.firstMissing(c) > c.to {
+		delete(s.inFlight, sessionID)
+		st.inFlight--
+	}
+	return true, nil
+}
+
+func (s *Scheduler) verifyLinks(from int, blocks []*block.Block) error {
+	prevHash := s.blockHash(from - 1)
+	for i, b := range blocks {
+		h := b.Hash()
+		if known := s.blockHash(from + i); known != nil && !known.EqualsTo(h) {
+			return errors.Errorf(errors.ErrInvalidBlock, "block %v is different from the block that we have", from+i)
+		}
+		if prevHash != nil {
+			if !b.Header().LastBlockHash().EqualsTo(*prevHash) {
+				return errors.Errorf(errors.ErrInvalidBlock, "block %v doesn't refer to the previous block", from+i)
+			}
+			if !prevHash.IsUndef() {
+				c := b.LastCertificate()
+				if c == nil || !c.BlockHash().EqualsTo(*prevHash) {
+					return errors.Errorf(errors.ErrInvalidBlock, "block %v doesn't have the certificate of the previous block", from+i)
+				}
+			}
+		}
+		prevHash = &h
+	}
+	next, ok := s.buffer[from+len(blocks)]
+	if ok && !next.block.Header().LastBlockHash().EqualsTo(*prevHash) {
+		return errors.Errorf(errors.ErrInvalidBlock, "block %v doesn't refer to the next block", from+len(blocks)-1)
+	}
+	return nil
+}
+
+// verifyCertificates verifies the certificate inside each block, which certifies the previous block.
+// The certificates far ahead of us can be signed by the validators that we don't know yet.
+// In this case the blocks are cut from the block that its certificate can't be verified,
+// and the window is cut to that height.
+// Only the certificate of the next height is fully verifiable, and failing to verify it means the blocks are invalid.
+func (s *Scheduler) verifyCertificates(from int, blocks []*block.Block,
+	verifyFn func(height int, cert *block.Certificate) error) ([]*block.Block, error) {
+	for i, b := range blocks {
+		h := from + i - 1
+		if h <= s.height {
+			continue
+		}
+		cert := b.LastCertificate()
+		if cert == nil {
+			return nil, errors.Errorf(errors.ErrInvalidBlock, "block %v has no certificate", from+i)
+		}
+		if err := verifyFn(h, cert); err != nil {
+			if h == s.height+1 {
+				return nil, err
+			}
+			if s.unverifiable == 0 || h < s.unverifiable {
+				s.unverifiable = h
+			}
+			if i == 0 {
+				return nil, nil
+			}
+			return blocks[:i], nil
+		}
+	}
+	return blocks, nil
+}
+
+// blockHash returns the hash of the block at this height, if we know it
+func (s *Scheduler) blockHash(height int) *crypto.Hash {
+	if height == s.height {
+		h := s.hash
+		return &h
+	}
+	b, ok := s.buffer[height]
+	if ok {
+		h := b.block.Hash()
+		return &h
+	}
+	return nil
+}
+
+// firstMissing returns the first height of the chunk that we don't have its block or its certificate.
+// Blocks are sent in order, therefore the rest of the chunk is missing too.
+func (s *Scheduler) firstMissing(c *chunk) int {
+	for h := c.from; h <= c.to; h++ {
+		if h <= s.height {
+			continue
+		}
+		if _, ok := s.buffer[h]; !ok {
+			return h
+		}
+		if _, ok := s.certs[h]; !ok {
+			return h
+		}
+	}
+	return c.to + 1
+}
+
+// CloseSession closes the session of the chunk.
+// If the chunk is not downloaded completely, the rest of it will be requested again, preferably from other peers.
+func (s *Scheduler) CloseSession(sessionID int) {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	c, ok := s.inFlight[sessionID]
+	if !ok {
+		return
+	}
+	delete(s.inFlight, sessionID)
+	st := s.stats(c.pid)
+	st.inFlight--
+
+	from := s.firstMissing(c)
+	if from > c.to {
+		return
+	}
+	st.throughput /= 2
+	s.requeue(&chunk{from: from, to: c.to, excluded: c.pid})
+}
+
+// fail removes the blocks of the chunk and puts it back into the queue.
+// The throughput of the peer is decreased.
+func (s *Scheduler) fail(sessionID int) {
+	c := s.inFlight[sessionID]
+	delete(s.inFlight, sessionID)
+
+	st := s.stats(c.pid)
+	st.inFlight--
+	st.throughput /= 2
+
+	s.remove(c.from, c.to, c.pid)
+	s.requeue(&chunk{from: c.from, to: c.to, excluded: c.pid})
+}
+
+// remove removes the blocks and the certificates between these heights that the source has sent.
+func (s *Scheduler) remove(from, to int, source peer.ID) {
+	for h := from - 1; h <= to; h++ {
+		if b, ok := s.buffer[h]; ok && b.source == source && h >= from {
+			delete(s.buffer, h)
+		}
+		if c, ok := s.certs[h]; ok && c.source == source {
+			delete(s.certs, h)
+		}
+	}
+}
+
+func (s *Scheduler) requeue(c *chunk) {
+	i := sort.Search(len(s.queue), func(i int) bool {
+		return s.queue[i].from > c.from
+	})
+	s.queue = append(s.queue, nil)
+	copy(s.queue[i+1:], s.queue[i:])
+	s.queue[i] = c
+}
+
+// CommitBlocks commits the buffered blocks in order.
+// heightFn should return the last committed height. If committing a block fails,
+// the blocks of that peer are removed from the buffer and requested again.
+// It returns the peer that has sent the invalid block.
+func (s *Scheduler) CommitBlocks(heightFn func() int,
+	commitFn func(height int, b *block.Block, c *block.Certificate) error) (peer.ID, error) {
+	s.commitLk.Lock()
+	defer s.commitLk.Unlock()
+
+	for {
+		height := heightFn() + 1
+
+		s.lk.Lock()
+		b, ok1 := s.buffer[height]
+		c, ok2 := s.certs[height]
+		s.lk.Unlock()
+		if !ok1 || !ok2 {
+			return "", nil
+		}
+
+		if err := commitFn(height, b.block, c.cert); err != nil {
+			if heightFn() >= height {
+				// The block is committed by someone else
+				continue
+			}
+			s.lk.Lock()
+			s.reject(height, b.source)
+			if c.source != b.source {
+				s.reject(height, c.source)
+			}
+			s.lk.Unlock()
+
+			return b.source, err
+		}
+
+		s.lk.Lock()
+		s.updateHeight(height, b.block.Hash())
+		s.lk.Unlock()
+	}
+}
+
+// reject removes the blocks of the source from the buffer, starting from this height,
+// and requests them again.
+func (s *Scheduler) reject(height int, source peer.ID) {
+	to := height
+	for {
+		b, ok := s.buffer[to+1]
+		if !ok || b.source != source {
+			break
+		}
+		to++
+	}
+	s.remove(height, to, source)
+	s.stats(source).throughput /= 2
+	if s.firstMissing(&chunk{from: height, to: to}) <= to {
+		s.requeue(&chunk{from: height, to: to, excluded: source})
+	}
+}
+
+// Progress returns the progress of downloading blocks
+func (s *Scheduler) Progress() Progress {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+
+	p := Progress{
+		Downloading:  s.isActive(),
+		Height:       s.height,
+		TargetHeight: s.target,
+	}
+	if !p.Downloading {
+		return p
+	}
+	elapsed := util.Now().Sub(s.startedAt).Seconds()
+	if elapsed > 0 {
+		p.BlocksPerSecond = float64(s.height-s.startHeight) / elapsed
+	}
+	if p.BlocksPerSecond > 0 {
+		p.ETA = time.Duration(float64(s.target-s.height) / p.BlocksPerSecond * float64(time.Second))
+	}
+	return p
+}
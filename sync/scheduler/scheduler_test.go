@@ -0,0 +1,389 @@
+package scheduler
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zarbchain/zarb-go/block"
+	"github.com/zarbchain/zarb-go/crypto"
+	"github.com/zarbchain/zarb-go/sync/peerset"
+	"github.com/zarbchain/zarb-go/util"
+)
+
+// generateTestChain generates blocks from height 1 to count. Index zero is nil.
+func generateTestChain(count int) []*block.Block {
+	blocks := make([]*block.Block, count+1)
+	lastBlockHash := crypto.UndefHash
+	for i := 1; i <= count; i++ {
+		b, _ := block.GenerateTestBlock(nil, &lastBlockHash)
+		lastBlockHash = b.Hash()
+		blocks[i] = b
+	}
+	return blocks
+}
+
+func generateTestPeers(heights ...int) []*peerset.Peer {
+	ps := peerset.NewPeerSet(time.Second)
+	peers := []*peerset.Peer{}
+	for _, h := range heights {
+		p := ps.MustGetPeer(util.RandomPeerID())
+		p.UpdateHeight(h)
+		peers = append(peers, p)
+	}
+	return peers
+}
+
+func sessionOpener() func(pid peer.ID) int {
+	id := 0
+	return func(pid peer.ID) int {
+		id++
+		return id
+	}
+}
+
+func verifyAll(height int, cert *block.Certificate) error {
+	return nil
+}
+
+// verifyUpTo returns a verifier that can't verify the certificates after this height
+func verifyUpTo(height int) func(int, *block.Certificate) error {
+	return func(h int, cert *block.Certificate) error {
+		if h > height {
+			return fmt.Errorf("unknown committer")
+		}
+		return nil
+	}
+}
+
+type committer struct {
+	height    int
+	committed []int
+	invalid   *crypto.Hash
+}
+
+func (c *committer) lastHeight() int {
+	return c.height
+}
+
+func (c *committer) commit(h int, b *block.Block, cert *block.Certificate) error {
+	if h != c.height+1 {
+		return fmt.Errorf("invalid height")
+	}
+	if !cert.BlockHash().EqualsTo(b.Hash()) {
+		return fmt.Errorf("invalid certificate")
+	}
+	if c.invalid != nil && b.Hash().EqualsTo(*c.invalid) {
+		return fmt.Errorf("invalid block")
+	}
+	c.height = h
+	c.committed = append(c.committed, h)
+	return nil
+}
+
+func TestSchedule(t *testing.T) {
+	s := NewScheduler(10, 2, 100)
+	peers := generateTestPeers(100, 100, 35)
+
+	assert.Empty(t, s.Schedule(peers, 10, sessionOpener()))
+
+	s.SetTarget(0, crypto.UndefHash, 100)
+	reqs := s.Schedule(peers, 5, sessionOpener())
+	require.Equal(t, len(reqs), 5)
+
+	assert.Equal(t, reqs[0].From, 1)
+	assert.Equal(t, reqs[0].To, 10)
+	assert.Equal(t, reqs[1].From, 11)
+	assert.Equal(t, reqs[1].To, 20)
+	assert.Equal(t, reqs[2].PeerID, peers[2].PeerID())
+	assert.Equal(t, reqs[2].From, 21)
+	assert.Equal(t, reqs[2].To, 30)
+	assert.Equal(t, reqs[2].SessionID, 3)
+	assert.Equal(t, reqs[3].From, 31)
+	assert.Equal(t, reqs[4].From, 41)
+
+	t.Run("Peers don't have more blocks or have two requests in flight", func(t *testing.T) {
+		reqs := s.Schedule(peers, 10, sessionOpener())
+		assert.Empty(t, reqs)
+	})
+
+	t.Run("No more than window", func(t *testing.T) {
+		s := NewScheduler(10, 100, 30)
+		s.SetTarget(0, crypto.UndefHash, 100)
+		reqs := s.Schedule(peers, 10, sessionOpener())
+		assert.Equal(t, len(reqs), 3)
+		assert.Equal(t, reqs[2].To, 30)
+	})
+
+	t.Run("Last chunk is smaller", func(t *testing.T) {
+		s := NewScheduler(10, 2, 100)
+		s.SetTarget(0, crypto.UndefHash, 15)
+		reqs := s.Schedule(peers, 10, sessionOpener())
+		assert.Equal(t, len(reqs), 2)
+		assert.Equal(t, reqs[1].From, 11)
+		assert.Equal(t, reqs[1].To, 15)
+	})
+}
+
+func TestFasterPeersGetLowerChunks(t *testing.T) {
+	chain := generateTestChain(41)
+	s := NewScheduler(10, 1, 100)
+	peers := generateTestPeers(100, 100)
+	slow := peers[0]
+	fast := peers[1]
+
+	s.SetTarget(0, crypto.UndefHash, 100)
+	open := sessionOpener()
+	reqs := s.Schedule(peers, 2, open)
+	require.Equal(t, reqs[0].PeerID, slow.PeerID())
+	require.Equal(t, reqs[1].PeerID, fast.PeerID())
+
+	_, err := s.AddBlocks(reqs[0].SessionID, 1, chain[1:12], verifyAll)
+	assert.NoError(t, err)
+	_, err = s.AddBlocks(reqs[1].SessionID, 11, chain[11:22], verifyAll)
+	assert.NoError(t, err)
+
+	s.peers[slow.PeerID()].throughput = 10
+	s.peers[fast.PeerID()].throughput = 100
+
+	reqs = s.Schedule(peers, 2, open)
+	require.Equal(t, len(reqs), 2)
+	assert.Equal(t, reqs[0].PeerID, fast.PeerID())
+	assert.Equal(t, reqs[0].From, 21)
+	assert.Equal(t, reqs[1].PeerID, slow.PeerID())
+	assert.Equal(t, reqs[1].From, 31)
+}
+
+func TestCommitInOrder(t *testing.T) {
+	chain := generateTestChain(30)
+	s := NewScheduler(10, 2, 100)
+	peers := generateTestPeers(100, 100)
+	c := &committer{}
+
+	s.SetTarget(0, crypto.UndefHash, 20)
+	reqs := s.Schedule(peers, 10, sessionOpener())
+	require.Equal(t, len(reqs), 2)
+
+	t.Run("Unknown session", func(t *testing.T) {
+		handled, err := s.AddBlocks(1000, 1, chain[1:11], verifyAll)
+		assert.False(t, handled)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Second chunk arrives first", func(t *testing.T) {
+		handled, err := s.AddBlocks(reqs[1].SessionID, 11, chain[11:22], verifyAll)
+		assert.True(t, handled)
+		assert.NoError(t, err)
+
+		_, err = s.CommitBlocks(c.lastHeight, c.commit)
+		assert.NoError(t, err)
+		assert.Empty(t, c.committed)
+	})
+
+	t.Run("First chunk arrives", func(t *testing.T) {
+		_, err := s.AddBlocks(reqs[0].SessionID, 1, chain[1:6], verifyAll)
+		assert.NoError(t, err)
+		_, err = s.AddBlocks(reqs[0].SessionID, 6, chain[6:11], verifyAll)
+		assert.NoError(t, err)
+
+		_, err = s.CommitBlocks(c.lastHeight, c.commit)
+		assert.NoError(t, err)
+		assert.Equal(t, c.height, 20)
+		for i, h := range c.committed {
+			assert.Equal(t, h, i+1)
+		}
+		assert.False(t, s.IsActive())
+		assert.Empty(t, s.inFlight)
+		assert.Empty(t, s.buffer)
+	})
+}
+
+func TestInvalidBlocks(t *testing.T) {
+	chain := generateTestChain(21)
+	s := NewScheduler(10, 1, 100)
+	peers := generateTestPeers(100, 100)
+
+	s.SetTarget(0, crypto.UndefHash, 20)
+	reqs := s.Schedule(peers, 10, sessionOpener())
+	require.Equal(t, len(reqs), 2)
+
+	t.Run("Out of range", func(t *testing.T) {
+		_, err := s.AddBlocks(reqs[0].SessionID, 5, chain[5:15], verifyAll)
+		assert.Error(t, err)
+
+		_, err = s.AddBlocks(reqs[0].SessionID, 1, chain[1:11], verifyAll)
+		assert.NoError(t, err)
+		assert.NotContains(t, s.buffer, 1)
+	})
+
+	t.Run("Not linked to the previous block", func(t *testing.T) {
+		b, _ := block.GenerateTestBlock(nil, nil)
+		blocks := append([]*block.Block{}, chain[11:21]...)
+		blocks[5] = b
+		_, err := s.AddBlocks(reqs[1].SessionID, 11, blocks, verifyAll)
+		assert.Error(t, err)
+		assert.Empty(t, s.buffer)
+	})
+
+	t.Run("The chunks are requested again from the other peer", func(t *testing.T) {
+		reqs2 := s.Schedule(peers, 10, sessionOpener())
+		require.Equal(t, len(reqs2), 2)
+		for _, r := range reqs2 {
+			if r.From == 1 {
+				assert.Equal(t, r.PeerID, reqs[1].PeerID)
+			} else {
+				assert.Equal(t, r.From, 11)
+				assert.Equal(t, r.PeerID, reqs[0].PeerID)
+			}
+		}
+	})
+}
+
+func TestVerifyCertificates(t *testing.T) {
+	chain := generateTestChain(31)
+	s := NewScheduler(10, 1, 100)
+	peers := generateTestPeers(100, 100)
+	opener := sessionOpener()
+	c := &committer{}
+
+	s.SetTarget(0, crypto.UndefHash, 30)
+	reqs := s.Schedule(peers, 10, opener)
+	require.Equal(t, len(reqs), 2)
+
+	t.Run("Unverifiable certificates cut the blocks", func(t *testing.T) {
+		_, err := s.AddBlocks(reqs[1].SessionID, 11, chain[11:22], verifyUpTo(14))
+		assert.NoError(t, err)
+		// The block with the unverifiable certificate is cut, not the block that it certifies
+		assert.Contains(t, s.buffer, 15)
+		assert.NotContains(t, s.buffer, 16)
+		assert.Contains(t, s.certs, 14)
+		assert.NotContains(t, s.certs, 15)
+		assert.Equal(t, s.unverifiable, 15)
+	})
+
+	t.Run("Chunks after the unverifiable certificate are not assigned", func(t *testing.T) {
+		s.CloseSession(reqs[1].SessionID)
+		reqs2 := s.Schedule(peers, 10, opener)
+		assert.Empty(t, reqs2)
+	})
+
+	t.Run("Invalid certificate of the next height", func(t *testing.T) {
+		_, err := s.AddBlocks(reqs[0].SessionID, 1, chain[1:12], verifyUpTo(0))
+		assert.Error(t, err)
+		assert.NotContains(t, s.buffer, 1)
+	})
+
+	t.Run("Committing the blocks opens the window", func(t *testing.T) {
+		reqs3 := s.Schedule(peers, 10, opener)
+		require.Equal(t, len(reqs3), 1)
+		assert.Equal(t, reqs3[0].From, 1)
+
+		_, err := s.AddBlocks(reqs3[0].SessionID, 1, chain[1:12], verifyAll)
+		assert.NoError(t, err)
+		_, err = s.CommitBlocks(c.lastHeight, c.commit)
+		assert.NoError(t, err)
+		assert.Equal(t, c.height, 14)
+		assert.Zero(t, s.unverifiable)
+
+		reqs4 := s.Schedule(peers, 10, opener)
+		require.Equal(t, len(reqs4), 2)
+		assert.Equal(t, reqs4[0].From, 15)
+		assert.Equal(t, reqs4[1].From, 21)
+
+		_, err = s.AddBlocks(reqs4[0].SessionID, 15, chain[15:22], verifyAll)
+		assert.NoError(t, err)
+		_, err = s.CommitBlocks(c.lastHeight, c.commit)
+		assert.NoError(t, err)
+		assert.Equal(t, c.height, 20)
+	})
+}
+
+func TestCloseSession(t *testing.T) {
+	chain := generateTestChain(21)
+	s := NewScheduler(10, 1, 100)
+	peers := generateTestPeers(100, 100)
+
+	s.SetTarget(0, crypto.UndefHash, 20)
+	reqs := s.Schedule(peers[:1], 10, sessionOpener())
+	require.Equal(t, len(reqs), 1)
+
+	_, err := s.AddBlocks(reqs[0].SessionID, 1, chain[1:6], verifyAll)
+	assert.NoError(t, err)
+	s.CloseSession(reqs[0].SessionID)
+	assert.Empty(t, s.inFlight)
+
+	reqs = s.Schedule(peers, 10, sessionOpener())
+	require.Equal(t, len(reqs), 2)
+	assert.Equal(t, reqs[0].PeerID, peers[0].PeerID())
+	assert.Equal(t, reqs[0].From, 11)
+	// The rest of the chunk is requested from the other peer. Block 5 needs the certificate.
+	assert.Equal(t, reqs[1].PeerID, peers[1].PeerID())
+	assert.Equal(t, reqs[1].From, 5)
+	assert.Equal(t, reqs[1].To, 10)
+
+	t.Run("Closing a completed session", func(t *testing.T) {
+		_, err := s.AddBlocks(reqs[1].SessionID, 5, chain[5:12], verifyAll)
+		assert.NoError(t, err)
+		s.CloseSession(reqs[1].SessionID)
+		assert.Empty(t, s.Schedule(peers, 10, sessionOpener()))
+	})
+}
+
+func TestCommitFailed(t *testing.T) {
+	chain := generateTestChain(21)
+	s := NewScheduler(10, 1, 100)
+	peers := generateTestPeers(100, 100)
+	invalid := chain[3].Hash()
+	c := &committer{invalid: &invalid}
+
+	s.SetTarget(0, crypto.UndefHash, 20)
+	reqs := s.Schedule(peers, 10, sessionOpener())
+	require.Equal(t, len(reqs), 2)
+
+	_, err := s.AddBlocks(reqs[0].SessionID, 1, chain[1:12], verifyAll)
+	assert.NoError(t, err)
+	_, err = s.AddBlocks(reqs[1].SessionID, 11, chain[11:22], verifyAll)
+	assert.NoError(t, err)
+
+	pid, err := s.CommitBlocks(c.lastHeight, c.commit)
+	assert.Error(t, err)
+	assert.Equal(t, pid, reqs[0].PeerID)
+	assert.Equal(t, c.height, 2)
+
+	failed := reqs[0].PeerID
+	reqs = s.Schedule(peers, 10, sessionOpener())
+	require.Equal(t, len(reqs), 1)
+	assert.NotEqual(t, reqs[0].PeerID, failed)
+	assert.Equal(t, reqs[0].From, 3)
+	assert.Equal(t, reqs[0].To, 10)
+}
+
+func TestProgress(t *testing.T) {
+	chain := generateTestChain(11)
+	s := NewScheduler(10, 1, 100)
+	peers := generateTestPeers(100)
+	c := &committer{}
+
+	assert.False(t, s.Progress().Downloading)
+
+	s.SetTarget(0, crypto.UndefHash, 100)
+	p := s.Progress()
+	assert.True(t, p.Downloading)
+	assert.Equal(t, p.TargetHeight, 100)
+	assert.Zero(t, p.ETA)
+
+	s.startedAt = util.Now().Add(-10 * time.Second)
+	reqs := s.Schedule(peers, 10, sessionOpener())
+	_, err := s.AddBlocks(reqs[0].SessionID, 1, chain[1:12], verifyAll)
+	assert.NoError(t, err)
+	_, err = s.CommitBlocks(c.lastHeight, c.commit)
+	assert.NoError(t, err)
+
+	p = s.Progress()
+	assert.Equal(t, p.Height, 10)
+	assert.InDelta(t, p.BlocksPerSecond, 1, 0.1)
+	assert.InDelta(t, p.ETA.Seconds(), 90, 10)
+}
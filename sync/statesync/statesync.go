@@ -0,0 +1,224 @@
+package statesync
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/zarbchain/zarb-go/block"
+	"github.com/zarbchain/zarb-go/crypto"
+	"github.com/zarbchain/zarb-go/errors"
+	"github.com/zarbchain/zarb-go/state/snapshot"
+	"github.com/zarbchain/zarb-go/tx"
+)
+
+// StateSync keeps the progress of downloading a snapshot from other peers.
+// The first valid offer is chosen and the chunks are requested from all the peers
+// that offer the same snapshot.
+//
+// The snapshot and its committee come from other peers, so they can be forged.
+// We only accept a snapshot if the block after it is our trusted block.
+// The state hash of the trusted block proves the state of the snapshot.
+type StateSync struct {
+	lk sync.RWMutex
+
+	trustedHeight int
+	trustedHash   crypto.Hash
+	active        bool
+	queried       bool
+	manifest      *snapshot.Manifest
+	block         *block.Block
+	certificate   *block.Certificate
+	trxs          []*tx.Tx
+	providers     []peer.ID
+	chunks        map[int]*snapshot.Chunk
+}
+
+func NewStateSync(active bool, trustedHeight int, trustedHash crypto.Hash) *StateSync {
+	return &StateSync{
+		active:        active,
+		trustedHeight: trustedHeight,
+		trustedHash:   trustedHash,
+		chunks:        make(map[int]*snapshot.Chunk),
+	}
+}
+
+// IsActive returns true if state sync is neither finished nor disabled
+func (ss *StateSync) IsActive() bool {
+	ss.lk.RLock()
+	defer ss.lk.RUnlock()
+
+	return ss.active
+}
+
+// Disable stops state syncing. Node should continue with downloading blocks.
+func (ss *StateSync) Disable() {
+	ss.lk.Lock()
+	defer ss.lk.Unlock()
+
+	ss.active = false
+	ss.reset()
+}
+
+// SetQueried marks that we have asked peers for their snapshots
+func (ss *StateSync) SetQueried() {
+	ss.lk.Lock()
+	defer ss.lk.Unlock()
+
+	ss.queried = true
+}
+
+func (ss *StateSync) Queried() bool {
+	ss.lk.RLock()
+	defer ss.lk.RUnlock()
+
+	return ss.queried
+}
+
+func (ss *StateSync) HasOffer() bool {
+	ss.lk.RLock()
+	defer ss.lk.RUnlock()
+
+	return ss.manifest != nil
+}
+
+func (ss *StateSync) Manifest() *snapshot.Manifest {
+	ss.lk.RLock()
+	defer ss.lk.RUnlock()
+
+	return ss.manifest
+}
+
+func (ss *StateSync) Providers() []peer.ID {
+	ss.lk.RLock()
+	defer ss.lk.RUnlock()
+
+	providers := make([]peer.ID, len(ss.providers))
+	copy(providers, ss.providers)
+	return providers
+}
+
+// AddOffer adds a snapshot offer. The block is the block committed right after the snapshot
+// and the transactions are the transactions of this block.
+// If we already have an offer, the peer is added as a provider only if it offers the same snapshot.
+func (ss *StateSync) AddOffer(pid peer.ID, genHash crypto.Hash, m *snapshot.Manifest,
+	b *block.Block, cert *block.Certificate, trxs []*tx.Tx) error {
+	ss.lk.Lock()
+	defer ss.lk.Unlock()
+
+	if !ss.active {
+		return errors.Errorf(errors.ErrGeneric, "state sync is not active")
+	}
+	if ss.manifest != nil {
+		if !ss.manifest.Hash().EqualsTo(m.Hash()) {
+			return errors.Errorf(errors.ErrGeneric, "we have chosen another snapshot")
+		}
+		for _, p := range ss.providers {
+			if p == pid {
+				return nil
+			}
+		}
+		ss.providers = append(ss.providers, pid)
+		return nil
+	}
+
+	if !m.GenesisHash().EqualsTo(genHash) {
+		return errors.Errorf(errors.ErrGeneric, "snapshot belongs to another network")
+	}
+	if m.BlockHeight()+1 != ss.trustedHeight || !b.HashesTo(ss.trustedHash) {
+		return errors.Errorf(errors.ErrGeneric, "snapshot doesn't match the trusted block")
+	}
+	if err := m.VerifyNextBlock(b); err != nil {
+		return err
+	}
+	if !cert.BlockHash().EqualsTo(b.Hash()) {
+		return errors.Errorf(errors.ErrGeneric, "certificate is not for this block")
+	}
+
+	ss.manifest = m
+	ss.block = b
+	ss.certificate = cert
+	ss.trxs = trxs
+	ss.providers = []peer.ID{pid}
+	return nil
+}
+
+// RemoveProvider removes a peer that is not able to provide the chunks.
+// If no provider left, the offer is dropped.
+func (ss *StateSync) RemoveProvider(pid peer.ID) {
+	ss.lk.Lock()
+	defer ss.lk.Unlock()
+
+	for i, p := range ss.providers {
+		if p == pid {
+			ss.providers = append(ss.providers[:i], ss.providers[i+1:]...)
+			break
+		}
+	}
+	if len(ss.providers) == 0 {
+		ss.reset()
+	}
+}
+
+// AddChunk verifies the chunk against the manifest and keeps it
+func (ss *StateSync) AddChunk(c *snapshot.Chunk) error {
+	ss.lk.Lock()
+	defer ss.lk.Unlock()
+
+	if ss.manifest == nil {
+		return errors.Errorf(errors.ErrGeneric, "no snapshot offer")
+	}
+	if err := snapshot.NewSnapshot(ss.manifest, nil).VerifyChunk(c); err != nil {
+		return err
+	}
+	ss.chunks[c.Index()] = c
+	return nil
+}
+
+// MissingChunks returns the index of chunks that we don't have yet
+func (ss *StateSync) MissingChunks() []int {
+	ss.lk.RLock()
+	defer ss.lk.RUnlock()
+
+	missing := make([]int, 0)
+	if ss.manifest == nil {
+		return missing
+	}
+	for i := 0; i < ss.manifest.TotalChunks(); i++ {
+		if _, ok := ss.chunks[i]; !ok {
+			missing = append(missing, i)
+		}
+	}
+	return missing
+}
+
+func (ss *StateSync) IsCompleted() bool {
+	ss.lk.RLock()
+	defer ss.lk.RUnlock()
+
+	return ss.manifest != nil && len(ss.chunks) == ss.manifest.TotalChunks()
+}
+
+// Snapshot returns the downloaded snapshot, alongside with the certified block and its transactions.
+func (ss *StateSync) Snapshot() (*snapshot.Snapshot, *block.Block, *block.Certificate, []*tx.Tx) {
+	ss.lk.RLock()
+	defer ss.lk.RUnlock()
+
+	chunks := make([]*snapshot.Chunk, 0, len(ss.chunks))
+	for _, c := range ss.chunks {
+		chunks = append(chunks, c)
+	}
+	sort.Slice(chunks, func(i, j int) bool { return chunks[i].Index() < chunks[j].Index() })
+
+	return snapshot.NewSnapshot(ss.manifest, chunks), ss.block, ss.certificate, ss.trxs
+}
+
+func (ss *StateSync) reset() {
+	ss.queried = false
+	ss.manifest = nil
+	ss.block = nil
+	ss.certificate = nil
+	ss.trxs = nil
+	ss.providers = nil
+	ss.chunks = make(map[int]*snapshot.Chunk)
+}
@@ -0,0 +1,103 @@
+package statesync
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/zarbchain/zarb-go/block"
+	"github.com/zarbchain/zarb-go/crypto"
+	"github.com/zarbchain/zarb-go/state/snapshot"
+	"github.com/zarbchain/zarb-go/util"
+)
+
+func TestInactive(t *testing.T) {
+	s, b, c := snapshot.GenerateTestSnapshot(10)
+	ss := NewStateSync(false, 11, b.Hash())
+
+	assert.False(t, ss.IsActive())
+	assert.Error(t, ss.AddOffer(util.RandomPeerID(), s.Manifest().GenesisHash(), s.Manifest(), b, c, nil))
+}
+
+func TestAddOffer(t *testing.T) {
+	s, b, c := snapshot.GenerateTestSnapshot(10)
+	ss := NewStateSync(true, 11, b.Hash())
+	m := s.Manifest()
+	pid1 := util.RandomPeerID()
+	pid2 := util.RandomPeerID()
+
+	t.Run("Invalid genesis hash", func(t *testing.T) {
+		assert.Error(t, ss.AddOffer(pid1, crypto.GenerateTestHash(), m, b, c, nil))
+	})
+
+	t.Run("Snapshot doesn't match the trusted block", func(t *testing.T) {
+		ss := NewStateSync(true, 11, crypto.GenerateTestHash())
+		assert.Error(t, ss.AddOffer(pid1, m.GenesisHash(), m, b, c, nil))
+
+		ss = NewStateSync(true, 12, b.Hash())
+		assert.Error(t, ss.AddOffer(pid1, m.GenesisHash(), m, b, c, nil))
+	})
+
+	t.Run("Block is not linked to the snapshot", func(t *testing.T) {
+		b2, _ := block.GenerateTestBlock(nil, nil)
+		assert.Error(t, ss.AddOffer(pid1, m.GenesisHash(), m, b2, c, nil))
+	})
+
+	t.Run("Certificate is not for the block", func(t *testing.T) {
+		c2 := block.GenerateTestCertificate(crypto.GenerateTestHash())
+		assert.Error(t, ss.AddOffer(pid1, m.GenesisHash(), m, b, c2, nil))
+	})
+
+	assert.False(t, ss.HasOffer())
+	assert.NoError(t, ss.AddOffer(pid1, m.GenesisHash(), m, b, c, nil))
+	assert.True(t, ss.HasOffer())
+
+	t.Run("Same offer from another peer", func(t *testing.T) {
+		assert.NoError(t, ss.AddOffer(pid2, m.GenesisHash(), m, b, c, nil))
+		assert.NoError(t, ss.AddOffer(pid2, m.GenesisHash(), m, b, c, nil))
+		assert.Equal(t, ss.Providers(), []peer.ID{pid1, pid2})
+	})
+
+	t.Run("Another offer", func(t *testing.T) {
+		s2, b2, c2 := snapshot.GenerateTestSnapshot(10)
+		assert.Error(t, ss.AddOffer(util.RandomPeerID(), s2.Manifest().GenesisHash(), s2.Manifest(), b2, c2, nil))
+	})
+
+	t.Run("Remove providers", func(t *testing.T) {
+		ss.RemoveProvider(pid1)
+		assert.True(t, ss.HasOffer())
+		ss.RemoveProvider(pid2)
+		assert.False(t, ss.HasOffer())
+	})
+}
+
+func TestDownloadChunks(t *testing.T) {
+	s, b, c := snapshot.GenerateTestSnapshot(10)
+	ss := NewStateSync(true, 11, b.Hash())
+	m := s.Manifest()
+
+	assert.Error(t, ss.AddChunk(s.Chunks()[0]))
+	assert.NoError(t, ss.AddOffer(util.RandomPeerID(), m.GenesisHash(), m, b, c, nil))
+	assert.Equal(t, len(ss.MissingChunks()), m.TotalChunks())
+
+	t.Run("Invalid chunk", func(t *testing.T) {
+		s2, _, _ := snapshot.GenerateTestSnapshot(10)
+		assert.Error(t, ss.AddChunk(s2.Chunks()[0]))
+	})
+
+	for i := len(s.Chunks()) - 1; i >= 0; i-- {
+		assert.False(t, ss.IsCompleted())
+		assert.NoError(t, ss.AddChunk(s.Chunks()[i]))
+	}
+	assert.True(t, ss.IsCompleted())
+	assert.Empty(t, ss.MissingChunks())
+
+	s2, b2, c2, _ := ss.Snapshot()
+	assert.Equal(t, b2.Hash(), b.Hash())
+	assert.Equal(t, c2.Hash(), c.Hash())
+	assert.NoError(t, s2.Verify(m.GenesisHash(), m.BlockHash(), m.StateHash()))
+
+	ss.Disable()
+	assert.False(t, ss.IsActive())
+	assert.False(t, ss.HasOffer())
+}
@@ -3,19 +3,26 @@ package sync
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/zarbchain/zarb-go/block"
 	"github.com/zarbchain/zarb-go/consensus"
+	"github.com/zarbchain/zarb-go/consensus/proposal"
 	"github.com/zarbchain/zarb-go/crypto"
 	"github.com/zarbchain/zarb-go/logger"
 	"github.com/zarbchain/zarb-go/network"
 	"github.com/zarbchain/zarb-go/state"
 	"github.com/zarbchain/zarb-go/sync/cache"
 	"github.com/zarbchain/zarb-go/sync/firewall"
+	"github.com/zarbchain/zarb-go/sync/message"
+	"github.com/zarbchain/zarb-go/sync/message/codec"
 	"github.com/zarbchain/zarb-go/sync/message/payload"
 	"github.com/zarbchain/zarb-go/sync/peerset"
+	"github.com/zarbchain/zarb-go/sync/relay"
+	"github.com/zarbchain/zarb-go/sync/scheduler"
+	"github.com/zarbchain/zarb-go/sync/statesync"
 	"github.com/zarbchain/zarb-go/tx"
 	"github.com/zarbchain/zarb-go/util"
 )
@@ -40,6 +47,9 @@ type synchronizer struct {
 	peerSet         *peerset.PeerSet
 	firewall        *firewall.Firewall
 	cache           *cache.Cache
+	relay           *relay.Relay
+	stateSync       *statesync.StateSync
+	scheduler       *scheduler.Scheduler
 	handlers        map[payload.Type]payloadHandler
 	broadcastCh     <-chan payload.Payload
 	network         network.Network
@@ -66,7 +76,10 @@ func NewSynchronizer(
 
 	peerSet := peerset.NewPeerSet(conf.SessionTimeout)
 	logger := logger.NewLogger("_sync", sync)
-	firewall := firewall.NewFirewall(conf.Firewall, net, peerSet, state, logger)
+	firewall, err := firewall.NewFirewall(conf.Firewall, net, peerSet, state, logger)
+	if err != nil {
+		return nil, err
+	}
 	cache, err := cache.NewCache(conf.CacheSize, state)
 	if err != nil {
 		return nil, err
@@ -74,8 +87,25 @@ func NewSynchronizer(
 
 	sync.logger = logger
 	sync.cache = cache
+	sync.relay = relay.NewRelay()
 	sync.peerSet = peerSet
 	sync.firewall = firewall
+	trustedHash := crypto.UndefHash
+	if conf.StateSync {
+		h, err := crypto.HashFromString(conf.TrustedHash)
+		if err != nil {
+			return nil, err
+		}
+		trustedHash = h
+	}
+	sync.stateSync = statesync.NewStateSync(conf.StateSync, conf.TrustedHeight, trustedHash)
+	// Peers don't accept download requests for more than LatestBlockInterval blocks
+	chunkSize := conf.DownloadChunkSize
+	if chunkSize > LatestBlockInterval {
+		chunkSize = LatestBlockInterval
+	}
+	sync.scheduler = scheduler.NewScheduler(chunkSize, conf.DownloadPipeline,
+		chunkSize*conf.MaximumOpenSessions*2)
 
 	handlers := make(map[payload.Type]payloadHandler)
 
@@ -94,6 +124,10 @@ func NewSynchronizer(
 	handlers[payload.PayloadTypeDownloadResponse] = newDownloadResponseHandler(sync)
 	handlers[payload.PayloadTypeLatestBlocksRequest] = newLatestBlocksRequestHandler(sync)
 	handlers[payload.PayloadTypeLatestBlocksResponse] = newLatestBlocksResponseHandler(sync)
+	handlers[payload.PayloadTypeQuerySnapshot] = newQuerySnapshotHandler(sync)
+	handlers[payload.PayloadTypeSnapshot] = newSnapshotHandler(sync)
+	handlers[payload.PayloadTypeChunkRequest] = newChunkRequestHandler(sync)
+	handlers[payload.PayloadTypeChunkResponse] = newChunkResponseHandler(sync)
 
 	sync.handlers = handlers
 
@@ -105,7 +139,7 @@ func (sync *synchronizer) Start() error {
 		return err
 	}
 
-	if sync.config.InitialBlockDownload {
+	if sync.config.InitialBlockDownload || sync.config.StateSync {
 		if err := sync.network.JoinDownloadTopic(); err != nil {
 			return err
 		}
@@ -153,6 +187,7 @@ func (sync *synchronizer) heartBeatTickerLoop() {
 			return
 		case <-sync.heartBeatTicker.C:
 			sync.broadcastHeartBeat()
+			sync.penalizeUnansweredSessions()
 		}
 	}
 }
@@ -172,6 +207,19 @@ func (sync *synchronizer) broadcastHeartBeat() {
 	sync.broadcast(pld)
 }
 
+// penalizeUnansweredSessions penalizes the peers that didn't answer our requests in time
+func (sync *synchronizer) penalizeUnansweredSessions() {
+	expired := sync.peerSet.ExpiredSessions()
+	for _, s := range expired {
+		sync.logger.Debug("Session expired", "session", s)
+		sync.firewall.Penalize(s.PeerID(), firewall.MisbehaviorUnansweredRequest)
+		sync.scheduler.CloseSession(s.SessionID())
+	}
+	if len(expired) > 0 && sync.scheduler.IsActive() {
+		sync.downloadBlocks()
+	}
+}
+
 func (sync *synchronizer) broadcastSalam() {
 	flags := 0
 	if sync.config.InitialBlockDownload {
@@ -182,7 +230,8 @@ func (sync *synchronizer) broadcastSalam() {
 		sync.signer.PublicKey(),
 		sync.state.GenesisHash(),
 		sync.state.LastBlockHeight(),
-		flags)
+		flags,
+		sync.capabilities())
 
 	sync.broadcast(pld)
 }
@@ -199,11 +248,56 @@ func (sync *synchronizer) broadcastAleyk(target peer.ID, code payload.ResponseCo
 		sync.config.Moniker,
 		sync.signer.PublicKey(),
 		sync.state.LastBlockHeight(),
-		flags)
+		flags,
+		sync.capabilities())
 
 	sync.broadcast(response)
 }
 
+// capabilities returns our protocol version, network name, services and the payloads that we can handle
+func (sync *synchronizer) capabilities() payload.Capabilities {
+	services := payload.Services(0)
+	// Nodes that are restored from a snapshot don't have the blocks before the snapshot
+	if sync.state.LastBlockHeight() == 0 || sync.state.Block(1) != nil {
+		services |= payload.ServiceArchive
+	}
+	if sync.state.LastSnapshot() != nil {
+		services |= payload.ServiceSnapshots
+	}
+
+	payloadTypes := make([]payload.Type, 0, len(sync.handlers))
+	for t := range sync.handlers {
+		payloadTypes = append(payloadTypes, t)
+	}
+	sort.Slice(payloadTypes, func(i, j int) bool { return payloadTypes[i] < payloadTypes[j] })
+
+	return payload.NewCapabilities(sync.network.Name(), services, payloadTypes)
+}
+
+// legacyCapabilities are assumed for the older nodes that don't send their capabilities.
+// They understand the current payloads, but they offer no service.
+func (sync *synchronizer) legacyCapabilities() payload.Capabilities {
+	caps := sync.capabilities()
+	caps.ProtocolVersion = 0
+	caps.Services = 0
+	return caps
+}
+
+// suitablePeers returns the handshaked peers that can handle this type of request and offer the services
+func (sync *synchronizer) suitablePeers(t payload.Type, services payload.Services) []*peerset.Peer {
+	peers := []*peerset.Peer{}
+	for _, peer := range sync.peerSet.GetPeerList() {
+		if peer.Status() != peerset.StatusCodeOK {
+			continue
+		}
+		if !peer.SupportsPayload(t) || !peer.HasService(services) {
+			continue
+		}
+		peers = append(peers, peer)
+	}
+	return peers
+}
+
 func (sync *synchronizer) broadcastLoop() {
 	for {
 		select {
@@ -227,13 +321,33 @@ func (sync *synchronizer) Fingerprint() string {
 // If the node height is shorter than network more than two hours (720 blocks),
 // it should join the download topic and start downloading the blocks,
 // otherwise the node can request the latest blocks from the network.
+// If state sync is enabled and the node is empty, it tries to restore the state
+// from a snapshot of other peers first.
 func (sync *synchronizer) updateBlokchain() {
+	// The scheduler keeps downloading blocks while other sessions are open
+	if sync.scheduler.IsActive() {
+		sync.scheduler.SetTarget(sync.state.LastBlockHeight(), sync.state.LastBlockHash(),
+			sync.peerSet.MaxClaimedHeight()-LatestBlockInterval)
+		if sync.scheduler.IsActive() {
+			sync.downloadBlocks()
+			return
+		}
+	}
+
 	// TODO: write test for me
 	if sync.peerSet.HasAnyOpenSession() {
 		sync.logger.Debug("We have open seasson")
 		return
 	}
 
+	if sync.stateSync.IsActive() {
+		if sync.state.LastBlockHeight() == 0 {
+			sync.syncState()
+			return
+		}
+		sync.stateSync.Disable()
+	}
+
 	ourHeight := sync.state.LastBlockHeight()
 	claimedHeight := sync.peerSet.MaxClaimedHeight()
 	if claimedHeight > ourHeight {
@@ -246,6 +360,8 @@ func (sync *synchronizer) updateBlokchain() {
 			if err := sync.network.JoinDownloadTopic(); err != nil {
 				sync.logger.Info("We can't join download topic", "err", err)
 			} else {
+				// The latest blocks will be asked at the end, because they have the last certificate.
+				sync.scheduler.SetTarget(ourHeight, sync.state.LastBlockHash(), claimedHeight-LatestBlockInterval)
 				sync.downloadBlocks()
 			}
 		} else {
@@ -261,6 +377,14 @@ func (sync *synchronizer) onReceiveData(data []byte, from peer.ID) {
 		return
 	}
 
+	// The initiator is only claimed by the message, but the sender is verified by the network.
+	// The handlers rate limit and penalize the initiator, so it should be the sender.
+	if msg.Initiator != from {
+		sync.firewall.Penalize(from, firewall.MisbehaviorInvalidMessage)
+		sync.logger.Warn("Initiator is not the sender", "from", util.FingerprintPeerID(from), "message", msg)
+		return
+	}
+
 	sync.logger.Debug("Received a message", "from", util.FingerprintPeerID(from), "message", msg)
 	handler := sync.handlers[msg.Payload.Type()]
 	if handler == nil {
@@ -271,18 +395,14 @@ func (sync *synchronizer) onReceiveData(data []byte, from peer.ID) {
 	if err := handler.ParsPayload(msg.Payload, msg.Initiator); err != nil {
 		peer := sync.peerSet.MustGetPeer(from)
 		peer.IncreaseInvalidMessage()
+		sync.firewall.Penalize(from, firewall.MisbehaviorInvalidMessage)
 		sync.logger.Warn("Error on parsing a message", "from", util.FingerprintPeerID(from), "message", msg, "err", err)
 		return
 	}
 }
 
 func (sync *synchronizer) broadcast(pld payload.Payload) {
-	handler := sync.handlers[pld.Type()]
-	if handler == nil {
-		sync.logger.Warn("Invalid payload type: %v", pld.Type())
-		return
-	}
-	msg := handler.PrepareMessage(pld)
+	msg := sync.prepareMessage(pld)
 	if msg != nil {
 		err := sync.network.PublishMessage(msg)
 		if err != nil {
@@ -293,6 +413,37 @@ func (sync *synchronizer) broadcast(pld payload.Payload) {
 	}
 }
 
+// sendTo sends the payload directly to the peer, instead of publishing it to the whole network.
+func (sync *synchronizer) sendTo(pld payload.Payload, to peer.ID) {
+	msg := sync.prepareMessage(pld)
+	if msg != nil {
+		err := sync.network.SendMessage(msg, to)
+		if err != nil {
+			sync.logger.Error("Error on sending message", "message", msg, "to", util.FingerprintPeerID(to), "err", err)
+		} else {
+			sync.logger.Debug("Sending new message", "message", msg, "to", util.FingerprintPeerID(to))
+		}
+	}
+}
+
+func (sync *synchronizer) prepareMessage(pld payload.Payload) *message.Message {
+	handler := sync.handlers[pld.Type()]
+	if handler == nil {
+		sync.logger.Warn("Invalid payload type: %v", pld.Type())
+		return nil
+	}
+	return handler.PrepareMessage(pld)
+}
+
+// codecFor returns the best codec that the peer supports
+func (sync *synchronizer) codecFor(pid peer.ID) codec.Codec {
+	p := sync.peerSet.GetPeer(pid)
+	if p == nil {
+		return codec.Best(nil)
+	}
+	return codec.Best(p.Codecs())
+}
+
 func (sync *synchronizer) SelfID() peer.ID {
 	return sync.network.SelfID()
 }
@@ -301,53 +452,114 @@ func (sync *synchronizer) Peers() []*peerset.Peer {
 	return sync.peerSet.GetPeerList()
 }
 
-// TODO:
-// maximum nodes to query block should be 8
-//
-func (sync *synchronizer) downloadBlocks() {
-	from := sync.state.LastBlockHeight()
-	l := sync.peerSet.GetPeerList()
-	for _, peer := range l {
-		// TODO: write test for me
-		if sync.peerSet.NumberOfOpenSessions() > sync.config.MaximumOpenSessions {
-			sync.logger.Debug("We reached maximum number of open sessions")
-			break
-		}
+func (sync *synchronizer) DownloadProgress() scheduler.Progress {
+	return sync.scheduler.Progress()
+}
+
+func (sync *synchronizer) AddressBook() []network.AddressInfo {
+	return sync.network.AddressBook()
+}
 
-		// TODO: write test for me
+// downloadBlocks asks the scheduler which blocks should be downloaded from which peers
+// and sends the download requests.
+func (sync *synchronizer) downloadBlocks() {
+	// Pruned peers might not have the blocks that we need
+	peers := []*peerset.Peer{}
+	for _, peer := range sync.suitablePeers(payload.PayloadTypeDownloadRequest, payload.ServiceArchive) {
 		if !peer.InitialBlockDownload() {
 			continue
 		}
+		peers = append(peers, peer)
+	}
 
-		// TODO: write test for me
-		if peer.Status() != peerset.StatusCodeOK {
-			continue
+	capacity := sync.config.MaximumOpenSessions - sync.peerSet.NumberOfOpenSessions()
+	reqs := sync.scheduler.Schedule(peers, capacity, func(pid peer.ID) int {
+		return sync.peerSet.OpenSession(pid).SessionID()
+	})
+	for _, req := range reqs {
+		sync.logger.Debug("Sending download request", "from", req.From, "to", req.To, "pid", util.FingerprintPeerID(req.PeerID))
+		pld := payload.NewDownloadRequestPayload(req.SessionID, req.PeerID, req.From, req.To)
+		sync.sendTo(pld, req.PeerID)
+	}
+}
+
+// syncState asks peers for their snapshots and downloads the chunks of the chosen snapshot.
+// If no peer offers a snapshot, the node falls back to downloading blocks.
+func (sync *synchronizer) syncState() {
+	if !sync.stateSync.HasOffer() {
+		if sync.stateSync.Queried() || sync.noPeerOffersSnapshots() {
+			sync.logger.Info("No snapshot is offered. Downloading blocks")
+			sync.stateSync.Disable()
+			sync.updateBlokchain()
+			return
 		}
+		sync.querySnapshot()
+		return
+	}
+	sync.downloadChunks()
+}
 
-		// TODO: write test for me
-		if peer.Height() < from+1 {
-			continue
+// noPeerOffersSnapshots returns true if we have peers to download blocks from, but none of them offers snapshots
+func (sync *synchronizer) noPeerOffersSnapshots() bool {
+	return len(sync.suitablePeers(payload.PayloadTypeQuerySnapshot, payload.ServiceSnapshots)) == 0 &&
+		len(sync.suitablePeers(payload.PayloadTypeDownloadRequest, 0)) > 0
+}
+
+func (sync *synchronizer) querySnapshot() {
+	l := sync.suitablePeers(payload.PayloadTypeQuerySnapshot, payload.ServiceSnapshots)
+	for _, peer := range l {
+		if sync.peerSet.NumberOfOpenSessions() >= sync.config.MaximumOpenSessions {
+			break
 		}
-		to := from + LatestBlockInterval
-		if to > peer.Height() {
-			to = peer.Height()
+		if !peer.InitialBlockDownload() {
+			continue
 		}
 
-		sync.logger.Debug("Sending download request", "from", from+1, "to", to, "pid", util.FingerprintPeerID(peer.PeerID()))
+		sync.logger.Debug("Querying snapshot", "pid", util.FingerprintPeerID(peer.PeerID()))
 		session := sync.peerSet.OpenSession(peer.PeerID())
-		pld := payload.NewDownloadRequestPayload(session.SessionID(), peer.PeerID(), from+1, to)
-		sync.broadcast(pld)
-		from = to
+		pld := payload.NewQuerySnapshotPayload(session.SessionID(), peer.PeerID())
+		sync.sendTo(pld, peer.PeerID())
+		sync.stateSync.SetQueried()
 	}
 }
 
-func (sync *synchronizer) queryLatestBlocks() {
-	randPeer := sync.peerSet.GetRandomPeer()
+func (sync *synchronizer) downloadChunks() {
+	manifestHash := sync.stateSync.Manifest().Hash()
+	providers := sync.stateSync.Providers()
+	for i, index := range sync.stateSync.MissingChunks() {
+		if sync.peerSet.NumberOfOpenSessions() >= sync.config.MaximumOpenSessions {
+			break
+		}
+		pid := providers[i%len(providers)]
 
-	// TODO: write test for me
-	if randPeer == nil || randPeer.Status() != peerset.StatusCodeOK {
+		sync.logger.Debug("Sending chunk request", "index", index, "pid", util.FingerprintPeerID(pid))
+		session := sync.peerSet.OpenSession(pid)
+		pld := payload.NewChunkRequestPayload(session.SessionID(), pid, manifestHash, index)
+		sync.sendTo(pld, pid)
+	}
+}
+
+// tryRestoreSnapshot restores the state if all the chunks are downloaded.
+// The snapshot is verified against the state hash of the certified block.
+func (sync *synchronizer) tryRestoreSnapshot() {
+	if !sync.stateSync.IsCompleted() {
 		return
 	}
+	s, b, cert, trxs := sync.stateSync.Snapshot()
+	if err := sync.state.RestoreSnapshot(s, b, cert, trxs); err != nil {
+		sync.logger.Warn("Restoring snapshot failed. Downloading blocks", "err", err)
+	} else {
+		sync.logger.Info("State is restored from snapshot", "height", sync.state.LastBlockHeight())
+	}
+	sync.stateSync.Disable()
+}
+
+func (sync *synchronizer) queryLatestBlocks() {
+	peers := sync.suitablePeers(payload.PayloadTypeLatestBlocksRequest, 0)
+	if len(peers) == 0 {
+		return
+	}
+	randPeer := peers[util.RandInt(len(peers))]
 
 	// TODO: write test for me
 	from := sync.state.LastBlockHeight()
@@ -359,7 +571,7 @@ func (sync *synchronizer) queryLatestBlocks() {
 	sync.logger.Debug("Querying the latest blocks", "from", from+1, "to", to, "pid", util.FingerprintPeerID(randPeer.PeerID()))
 	session := sync.peerSet.OpenSession(randPeer.PeerID())
 	pld := payload.NewLatestBlocksRequestPayload(session.SessionID(), randPeer.PeerID(), from+1, to)
-	sync.broadcast(pld)
+	sync.sendTo(pld, randPeer.PeerID())
 
 }
 
@@ -379,7 +591,9 @@ func (sync *synchronizer) weAreInTheCommittee() bool {
 	return sync.state.IsInCommittee(sync.signer.PublicKey().Address())
 }
 
-func (sync *synchronizer) tryCommitBlocks() {
+// tryCommitBlocks commits the cached blocks in order.
+// It returns the height of the block that failed to commit, or zero.
+func (sync *synchronizer) tryCommitBlocks() int {
 	for {
 		ourHeight := sync.state.LastBlockHeight()
 		b := sync.cache.GetBlock(ourHeight + 1)
@@ -390,19 +604,70 @@ func (sync *synchronizer) tryCommitBlocks() {
 		if c == nil {
 			break
 		}
-		for _, id := range b.TxIDs().IDs() {
-			if tx := sync.cache.GetTransaction(id); tx != nil {
-				if err := sync.state.AddPendingTx(tx); err != nil {
-					sync.logger.Trace("Error on appending pending transaction", "err", err)
-				}
+		if err := sync.commitBlock(ourHeight+1, b, c); err != nil {
+			// We will ask peers to send this block later ...
+			return ourHeight + 1
+		}
+	}
+	return 0
+}
+
+// commitBlock adds the transactions of the block to the pool and commits the block.
+func (sync *synchronizer) commitBlock(height int, b *block.Block, c *block.Certificate) error {
+	for _, id := range b.TxIDs().IDs() {
+		if tx := sync.cache.GetTransaction(id); tx != nil {
+			if err := sync.state.AddPendingTx(tx); err != nil {
+				sync.logger.Trace("Error on appending pending transaction", "err", err)
 			}
 		}
-		sync.logger.Trace("Committing block", "height", ourHeight+1, "block", b)
-		if err := sync.state.CommitBlock(ourHeight+1, b, c); err != nil {
-			sync.logger.Warn("Committing block failed", "block", b, "err", err, "height", ourHeight+1)
-			// We will ask peers to send this block later ...
-			break
+	}
+	sync.logger.Trace("Committing block", "height", height, "block", b)
+	if err := sync.state.CommitBlock(height, b, c); err != nil {
+		sync.logger.Warn("Committing block failed", "block", b, "err", err, "height", height)
+		return err
+	}
+	return nil
+}
+
+// checkReceivedBlocks adds the received blocks to the cache and tries to commit them.
+// The peer is penalized if the blocks can't be committed, or they are useless for us and we haven't requested them.
+// The blocks that we have requested can be committed in the meantime, by the blocks of other peers.
+func (sync *synchronizer) checkReceivedBlocks(pid peer.ID, sessionID int, from int, blocks []*block.Block) {
+	to := from + len(blocks) - 1
+	if len(blocks) > 0 && to <= sync.state.LastBlockHeight() {
+		sync.logger.Debug("Useless blocks received", "from", from, "to", to, "pid", util.FingerprintPeerID(pid))
+		s := sync.peerSet.FindSession(sessionID)
+		if s == nil || s.PeerID() != pid {
+			sync.firewall.Penalize(pid, firewall.MisbehaviorUselessBlocks)
 		}
+		return
+	}
+
+	sync.cache.AddBlocks(from, blocks)
+	failedHeight := sync.tryCommitBlocks()
+	if failedHeight >= from && failedHeight <= to {
+		sync.firewall.Penalize(pid, firewall.MisbehaviorInvalidBlock)
+	}
+}
+
+// addDownloadedBlocks passes the downloaded blocks to the scheduler and commits the blocks that are ready.
+// Blocks of the sessions that don't belong to the scheduler are checked as before.
+func (sync *synchronizer) addDownloadedBlocks(pid peer.ID, sessionID int, from int, blocks []*block.Block) {
+	handled, err := sync.scheduler.AddBlocks(sessionID, from, blocks,
+		func(_ int, cert *block.Certificate) error { return sync.state.VerifyCertificate(cert) })
+	if !handled {
+		sync.checkReceivedBlocks(pid, sessionID, from, blocks)
+		return
+	}
+	if err != nil {
+		sync.logger.Warn("Invalid blocks received", "from", from, "pid", util.FingerprintPeerID(pid), "err", err)
+		sync.firewall.Penalize(pid, firewall.MisbehaviorInvalidBlock)
+		return
+	}
+
+	invalid, err := sync.scheduler.CommitBlocks(sync.state.LastBlockHeight, sync.commitBlock)
+	if err != nil {
+		sync.firewall.Penalize(invalid, firewall.MisbehaviorInvalidBlock)
 	}
 }
 
@@ -443,6 +708,74 @@ func (sync *synchronizer) prepareBlocksAndTransactions(from, count int) ([]*bloc
 	return blocks, trxs
 }
 
+// setProposal rebuilds the proposed block from the transaction pool and sends the proposal to the consensus.
+// If some transactions are missing, they are queried at once and the proposal waits for them.
+// If they are not received in time, the full proposal is queried.
+func (sync *synchronizer) setProposal(p *proposal.Proposal) {
+	missed := sync.missedTransactions(p.Block().TxIDs().IDs())
+	if len(missed) == 0 || !sync.weAreInTheCommittee() {
+		sync.relay.RemoveProposal(p.Hash())
+		sync.consensus.SetProposal(p)
+		return
+	}
+
+	sync.relay.Prune(sync.state.LastBlockHeight() + 1)
+	if !sync.relay.AddProposal(p, missed) {
+		return
+	}
+
+	sync.logger.Debug("Proposal is waiting for transactions", "proposal", p, "missed", len(missed))
+	sync.broadcast(payload.NewQueryTransactionsPayload(missed))
+
+	util.AfterFunc(sync.config.RelayTimeout, func() {
+		if sync.relay.RemoveProposal(p.Hash()) != nil {
+			sync.logger.Debug("Transactions are not received. Querying full proposal", "proposal", p)
+			sync.broadcast(payload.NewFullQueryProposalPayload(p.Height(), p.Round()))
+		}
+	})
+}
+
+// addTransactions keeps the received transactions and sends the proposals
+// that are not waiting for any transaction anymore to the consensus.
+func (sync *synchronizer) addTransactions(trxs []*tx.Tx) {
+	sync.cache.AddTransactions(trxs)
+
+	ids := make([]tx.ID, 0, len(trxs))
+	for _, trx := range trxs {
+		ids = append(ids, trx.ID())
+		if err := sync.state.AddPendingTx(trx); err != nil {
+			sync.logger.Debug("Cannot append transaction", "tx", trx, "err", err)
+
+			// TODO: set peer as bad peer?
+		}
+	}
+
+	for _, p := range sync.relay.AddTransactions(ids) {
+		sync.logger.Debug("Transactions are received. Setting proposal", "proposal", p)
+		sync.consensus.SetProposal(p)
+	}
+}
+
+// missedTransactions returns the transactions that we don't have.
+// The transactions that are in the cache are added to the pool.
+func (sync *synchronizer) missedTransactions(ids []tx.ID) []tx.ID {
+	missed := []tx.ID{}
+	for _, id := range ids {
+		if sync.state.PendingTx(id) != nil {
+			continue
+		}
+		trx := sync.cache.GetTransaction(id)
+		if trx == nil {
+			missed = append(missed, id)
+			continue
+		}
+		if err := sync.state.AddPendingTx(trx); err != nil {
+			sync.logger.Trace("Error on appending pending transaction", "err", err)
+		}
+	}
+	return missed
+}
+
 func (sync *synchronizer) prepareTransactions(ids []tx.ID) []*tx.Tx {
 	trxs := make([]*tx.Tx, 0, len(ids))
 
@@ -457,6 +790,11 @@ func (sync *synchronizer) prepareTransactions(ids []tx.ID) []*tx.Tx {
 	return trxs
 }
 
+func (sync *synchronizer) closeSession(sessionID int) {
+	sync.peerSet.CloseSession(sessionID)
+	sync.scheduler.CloseSession(sessionID)
+}
+
 func (sync *synchronizer) updateSession(code payload.ResponseCode, sessionID int, target peer.ID) {
 	if target != sync.network.SelfID() {
 		return
@@ -465,12 +803,12 @@ func (sync *synchronizer) updateSession(code payload.ResponseCode, sessionID int
 	switch code {
 	case payload.ResponseCodeRejected:
 		sync.logger.Debug("session rejected, close session", "session-id", sessionID)
-		sync.peerSet.CloseSession(sessionID)
+		sync.closeSession(sessionID)
 		sync.updateBlokchain()
 
 	case payload.ResponseCodeBusy:
 		sync.logger.Debug("Peer is busy. close session", "session-id", sessionID)
-		sync.peerSet.CloseSession(sessionID)
+		sync.closeSession(sessionID)
 		sync.updateBlokchain()
 
 	case payload.ResponseCodeMoreBlocks:
@@ -478,12 +816,12 @@ func (sync *synchronizer) updateSession(code payload.ResponseCode, sessionID int
 
 	case payload.ResponseCodeNoMoreBlocks:
 		sync.logger.Debug("Peer has no more block. close session", "session-id", sessionID)
-		sync.peerSet.CloseSession(sessionID)
+		sync.closeSession(sessionID)
 		sync.updateBlokchain()
 
 	case payload.ResponseCodeSynced:
 		sync.logger.Debug("Peer infomed us we are synced. close session", "session-id", sessionID)
-		sync.peerSet.CloseSession(sessionID)
+		sync.closeSession(sessionID)
 		sync.synced()
 	}
 
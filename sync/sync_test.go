@@ -17,6 +17,7 @@ import (
 	"github.com/zarbchain/zarb-go/network"
 	"github.com/zarbchain/zarb-go/state"
 	"github.com/zarbchain/zarb-go/sync/message/payload"
+	"github.com/zarbchain/zarb-go/sync/peerset"
 	"github.com/zarbchain/zarb-go/util"
 	"github.com/zarbchain/zarb-go/validator"
 )
@@ -173,6 +174,14 @@ func shouldPublishPayloadWithThisTypeAndResponseCode(t *testing.T, net *network.
 				case payload.PayloadTypeLatestBlocksResponse:
 					pld := msg.Payload.(*payload.LatestBlocksResponsePayload)
 					assert.Equal(t, pld.ResponseCode, code)
+
+				case payload.PayloadTypeSnapshot:
+					pld := msg.Payload.(*payload.SnapshotPayload)
+					assert.Equal(t, pld.ResponseCode, code)
+
+				case payload.PayloadTypeChunkResponse:
+					pld := msg.Payload.(*payload.ChunkResponsePayload)
+					assert.Equal(t, pld.ResponseCode, code)
 				}
 				return
 			}
@@ -262,3 +271,89 @@ func TestBroadcastInvalidMessage(t *testing.T) {
 		shouldNotPublishPayloadWithThisType(t, tAliceNet, payload.PayloadTypeHeartBeat)
 	})
 }
+
+func TestPenalizingPeers(t *testing.T) {
+	tAliceConfig.Firewall.Enabled = true
+	defer func() { tAliceConfig.Firewall.Enabled = false }()
+	setup(t)
+
+	t.Run("Alice receives useless blocks", func(t *testing.T) {
+		pid := util.RandomPeerID()
+		b := tAliceState.Block(10)
+		tAliceSync.checkReceivedBlocks(pid, 1000, 10, []*block.Block{b})
+		assert.Greater(t, tAliceSync.firewall.Score(pid), 0.0)
+	})
+
+	t.Run("Alice receives committed blocks that she has requested", func(t *testing.T) {
+		pid := util.RandomPeerID()
+		s := tAliceSync.peerSet.OpenSession(pid)
+		b := tAliceState.Block(10)
+		tAliceSync.checkReceivedBlocks(pid, s.SessionID(), 10, []*block.Block{b})
+		assert.Zero(t, tAliceSync.firewall.Score(pid))
+
+		t.Run("Session is opened with another peer", func(t *testing.T) {
+			pid := util.RandomPeerID()
+			tAliceSync.checkReceivedBlocks(pid, s.SessionID(), 10, []*block.Block{b})
+			assert.Greater(t, tAliceSync.firewall.Score(pid), 0.0)
+		})
+		tAliceSync.peerSet.CloseSession(s.SessionID())
+	})
+
+	t.Run("Alice receives an invalid block", func(t *testing.T) {
+		pid := util.RandomPeerID()
+		b, _ := block.GenerateTestBlock(nil, nil)
+		tAliceState.InvalidBlockHash = b.Hash()
+		tAliceSync.cache.AddCertificate(block.GenerateTestCertificate(b.Hash()))
+		tAliceSync.checkReceivedBlocks(pid, 1000, tAliceState.LastBlockHeight()+1, []*block.Block{b})
+		assert.Greater(t, tAliceSync.firewall.Score(pid), 0.0)
+	})
+
+	t.Run("Bob doesn't answer Alice's request", func(t *testing.T) {
+		tAliceSync.peerSet.OpenSession(tBobPeerID)
+		time.Sleep(2 * tAliceConfig.SessionTimeout)
+
+		tAliceSync.penalizeUnansweredSessions()
+		assert.Greater(t, tAliceSync.firewall.Score(tBobPeerID), 0.0)
+	})
+}
+
+func TestSuitablePeers(t *testing.T) {
+	setup(t)
+	tAliceSync.peerSet.Clear()
+
+	addPeer := func(status peerset.StatusCode, caps payload.Capabilities) *peerset.Peer {
+		p := tAliceSync.peerSet.MustGetPeer(util.RandomPeerID())
+		p.UpdateStatus(status)
+		p.UpdateCapabilities(caps)
+		return p
+	}
+	archive := addPeer(peerset.StatusCodeOK, tAliceSync.capabilities())
+	pruned := addPeer(peerset.StatusCodeOK, payload.NewCapabilities("zarb-test", payload.ServiceSnapshots, archive.Capabilities().PayloadTypes))
+	addPeer(peerset.StatusCodeOK, payload.NewCapabilities("zarb-test", payload.ServiceArchive, []payload.Type{payload.PayloadTypeSalam}))
+	addPeer(peerset.StatusCodeBanned, tAliceSync.capabilities())
+
+	peers := tAliceSync.suitablePeers(payload.PayloadTypeDownloadRequest, payload.ServiceArchive)
+	assert.Equal(t, peers, []*peerset.Peer{archive})
+
+	peers = tAliceSync.suitablePeers(payload.PayloadTypeQuerySnapshot, payload.ServiceSnapshots)
+	assert.Equal(t, peers, []*peerset.Peer{pruned})
+
+	peers = tAliceSync.suitablePeers(payload.PayloadTypeLatestBlocksRequest, 0)
+	assert.ElementsMatch(t, peers, []*peerset.Peer{archive, pruned})
+}
+
+func TestCapabilities(t *testing.T) {
+	setup(t)
+
+	caps := tAliceSync.capabilities()
+	assert.NoError(t, caps.CheckCompatibility(tAliceNet.Name()))
+	assert.Equal(t, caps.ProtocolVersion, payload.ProtocolVersion)
+	assert.True(t, caps.Services.Has(payload.ServiceArchive))
+	assert.False(t, caps.Services.Has(payload.ServiceSnapshots))
+	assert.Equal(t, len(caps.PayloadTypes), len(tAliceSync.handlers))
+
+	t.Run("Node restored from a snapshot is pruned", func(t *testing.T) {
+		delete(tAliceState.Store.Blocks, 1)
+		assert.False(t, tAliceSync.capabilities().Services.Has(payload.ServiceArchive))
+	})
+}
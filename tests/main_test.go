@@ -59,6 +59,9 @@ func TestMain(m *testing.M) {
 
 		tConfigs[i].Store.Path = util.TempDirPath()
 		tConfigs[i].Consensus.ChangeProposerTimeout = 4 * time.Second
+		tConfigs[i].Consensus.WALPath = util.TempFilePath()
+		tConfigs[i].Consensus.SignStatePath = util.TempFilePath()
+		tConfigs[i].Network.AddressBookPath = util.TempFilePath()
 		tConfigs[i].Logger.Levels["default"] = "warning"
 		tConfigs[i].Logger.Levels["_state"] = "info"
 		tConfigs[i].Logger.Levels["_sync"] = "error"
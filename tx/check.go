@@ -0,0 +1,102 @@
+package tx
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/zarbchain/zarb-go/crypto"
+	"github.com/zarbchain/zarb-go/errors"
+)
+
+// CheckTransactions does the sanity check of the transactions in parallel, using a pool of workers.
+// The signatures are verified in a batch. If the batch verification fails,
+// they are verified one by one to find the invalid signature.
+// The result is cached on each transaction, therefore checking them again costs nothing.
+// It returns the error of the first invalid transaction.
+func CheckTransactions(trxs []*Tx) error {
+	errs := make([]error, len(trxs))
+	signBytes := make([][]byte, len(trxs))
+
+	parallel(len(trxs), func(i int) {
+		trx := trxs[i]
+		if trx.isSanityChecked() {
+			return
+		}
+		if err := trx.basicCheck(); err != nil {
+			errs[i] = err
+			return
+		}
+		if !trx.IsMintbaseTx() {
+			signBytes[i] = trx.SignBytes()
+		}
+	})
+	if err := firstError(errs); err != nil {
+		return err
+	}
+
+	indexes := make([]int, 0, len(trxs))
+	pubs := make([]crypto.PublicKey, 0, len(trxs))
+	msgs := make([][]byte, 0, len(trxs))
+	sigs := make([]crypto.Signature, 0, len(trxs))
+	for i, bs := range signBytes {
+		if bs == nil {
+			continue
+		}
+		indexes = append(indexes, i)
+		pubs = append(pubs, *trxs[i].PublicKey())
+		msgs = append(msgs, bs)
+		sigs = append(sigs, *trxs[i].Signature())
+	}
+
+	// Verifying only one signature in a batch is slower
+	if len(sigs) == 1 || (len(sigs) > 1 && !crypto.VerifyBatch(pubs, msgs, sigs)) {
+		parallel(len(indexes), func(j int) {
+			if !pubs[j].Verify(msgs[j], sigs[j]) {
+				errs[indexes[j]] = errors.Errorf(errors.ErrInvalidTx, "invalid signature")
+			}
+		})
+		if err := firstError(errs); err != nil {
+			return err
+		}
+	}
+
+	for _, trx := range trxs {
+		trx.setSanityChecked(true)
+	}
+
+	return nil
+}
+
+// parallel calls the function for 0 to n-1 in parallel and waits for all of them.
+func parallel(n int, fn func(i int)) {
+	workers := runtime.NumCPU()
+	if workers > n {
+		workers = n
+	}
+
+	ch := make(chan int)
+	wg := sync.WaitGroup{}
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range ch {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		ch <- i
+	}
+	close(ch)
+	wg.Wait()
+}
+
+func firstError(errs []error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
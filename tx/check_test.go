@@ -0,0 +1,89 @@
+package tx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zarbchain/zarb-go/crypto"
+)
+
+func generateTestTransactions(n int) []*Tx {
+	trxs := make([]*Tx, 0, n+1)
+	addr, _, _ := crypto.GenerateTestKeyPair()
+	trxs = append(trxs, NewMintbaseTx(crypto.GenerateTestHash(), 1, addr, 1000, "mintbase"))
+	for i := 0; i < n; i++ {
+		trx, _ := GenerateTestSendTx()
+		trxs = append(trxs, trx)
+	}
+	return trxs
+}
+
+func TestCheckTransactions(t *testing.T) {
+	t.Run("No transaction", func(t *testing.T) {
+		assert.NoError(t, CheckTransactions(nil))
+	})
+
+	t.Run("Only subsidy transaction", func(t *testing.T) {
+		trxs := generateTestTransactions(0)
+		assert.NoError(t, CheckTransactions(trxs))
+	})
+
+	t.Run("One transaction", func(t *testing.T) {
+		trxs := generateTestTransactions(1)
+		assert.NoError(t, CheckTransactions(trxs))
+	})
+
+	t.Run("Ok", func(t *testing.T) {
+		trxs := generateTestTransactions(20)
+		assert.NoError(t, CheckTransactions(trxs))
+		for _, trx := range trxs {
+			assert.True(t, trx.isSanityChecked())
+		}
+	})
+
+	t.Run("Invalid signature", func(t *testing.T) {
+		trxs := generateTestTransactions(20)
+		_, _, pvInv := crypto.GenerateTestKeyPair()
+		trxs[7].SetSignature(pvInv.Sign(trxs[7].SignBytes()))
+
+		assert.Error(t, CheckTransactions(trxs))
+		for _, trx := range trxs {
+			assert.False(t, trx.isSanityChecked())
+		}
+		assert.Error(t, trxs[7].SanityCheck())
+		assert.NoError(t, trxs[8].SanityCheck())
+	})
+
+	t.Run("Invalid transaction", func(t *testing.T) {
+		trxs := generateTestTransactions(20)
+		trxs[3].data.Fee = 0
+
+		assert.Error(t, CheckTransactions(trxs))
+	})
+}
+
+func BenchmarkCheckTransactions(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		trxs := generateTestTransactions(1000)
+		b.StartTimer()
+
+		if err := CheckTransactions(trxs); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSanityCheck(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		trxs := generateTestTransactions(1000)
+		b.StartTimer()
+
+		for _, trx := range trxs {
+			if err := trx.SanityCheck(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
@@ -3,6 +3,7 @@ package tx
 import (
 	"encoding/json"
 	"fmt"
+	"sync/atomic"
 
 	"github.com/fxamacker/cbor/v2"
 	"github.com/zarbchain/zarb-go/crypto"
@@ -15,8 +16,9 @@ type ID = crypto.Hash
 
 type Tx struct {
 	// TODO: Memorizing ID is thread safe?
-	memorizedID   *ID
-	sanityChecked bool
+	memorizedID *ID
+	// sanityChecked is set atomically, transactions are checked in parallel
+	sanityChecked int32
 
 	data txData
 }
@@ -44,19 +46,35 @@ func (tx *Tx) PublicKey() *crypto.PublicKey { return tx.data.PublicKey }
 func (tx *Tx) Signature() *crypto.Signature { return tx.data.Signature }
 
 func (tx *Tx) SetSignature(sig crypto.Signature) {
-	tx.sanityChecked = false
+	tx.setSanityChecked(false)
 	tx.data.Signature = &sig
 }
 
 func (tx *Tx) SetPublicKey(pub crypto.PublicKey) {
-	tx.sanityChecked = false
+	tx.setSanityChecked(false)
 	tx.data.PublicKey = &pub
 }
 
 func (tx *Tx) SanityCheck() error {
-	if tx.sanityChecked {
+	if tx.isSanityChecked() {
 		return nil
 	}
+	if err := tx.basicCheck(); err != nil {
+		return err
+	}
+	if !tx.IsMintbaseTx() {
+		if !tx.PublicKey().Verify(tx.SignBytes(), *tx.Signature()) {
+			return errors.Errorf(errors.ErrInvalidTx, "invalid signature")
+		}
+	}
+
+	tx.setSanityChecked(true)
+
+	return nil
+}
+
+// basicCheck checks the transaction, except verifying the signature.
+func (tx *Tx) basicCheck() error {
 	if tx.Version() != 1 {
 		return errors.Errorf(errors.ErrInvalidTx, "invalid version")
 	}
@@ -76,11 +94,21 @@ func (tx *Tx) SanityCheck() error {
 		return err
 	}
 
-	tx.sanityChecked = true
-
 	return nil
 }
 
+func (tx *Tx) isSanityChecked() bool {
+	return atomic.LoadInt32(&tx.sanityChecked) == 1
+}
+
+func (tx *Tx) setSanityChecked(checked bool) {
+	if checked {
+		atomic.StoreInt32(&tx.sanityChecked, 1)
+	} else {
+		atomic.StoreInt32(&tx.sanityChecked, 0)
+	}
+}
+
 func (tx *Tx) checkFee() error {
 	if tx.IsMintbaseTx() || tx.IsSortitionTx() {
 		if tx.Fee() != 0 {
@@ -119,10 +147,6 @@ func (tx *Tx) checkSignature() error {
 		if !tx.Payload().Signer().Verify(*tx.PublicKey()) {
 			return errors.Errorf(errors.ErrInvalidTx, "invalid public key")
 		}
-		bs := tx.SignBytes()
-		if !tx.PublicKey().Verify(bs, *tx.Signature()) {
-			return errors.Errorf(errors.ErrInvalidTx, "invalid signature")
-		}
 	}
 	return nil
 }
@@ -0,0 +1,58 @@
+package util
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock provides the current time and the timers.
+// The system clock is used by default. Simulations replace it by a virtual clock.
+type Clock interface {
+	Now() time.Time
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+type Timer interface {
+	Stop() bool
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
+
+var (
+	clockLock sync.RWMutex
+	clock     Clock = systemClock{}
+)
+
+// SetClock replaces the clock and returns the previous one.
+// A nil clock restores the system clock.
+func SetClock(c Clock) Clock {
+	clockLock.Lock()
+	defer clockLock.Unlock()
+
+	if c == nil {
+		c = systemClock{}
+	}
+	prev := clock
+	clock = c
+	return prev
+}
+
+func currentClock() Clock {
+	clockLock.RLock()
+	defer clockLock.RUnlock()
+
+	return clock
+}
+
+// AfterFunc waits for the duration to elapse and then calls f.
+func AfterFunc(d time.Duration, f func()) Timer {
+	return currentClock().AfterFunc(d, f)
+}
@@ -13,7 +13,7 @@ func Now() time.Time {
 // RoundNow returns the result of rounding sec to the current time in UTC.
 // The rounding behavior is rounding down.
 func RoundNow(sec int) time.Time {
-	return roundDownTime(time.Now(), sec)
+	return roundDownTime(currentClock().Now(), sec)
 }
 
 func roundDownTime(t time.Time, sec int) time.Time {
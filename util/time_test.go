@@ -51,3 +51,30 @@ func TestRoundingTime(t *testing.T) {
 	// fmt.Println(c4)
 	// fmt.Println(c5)
 }
+
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time { return c.now }
+func (c *testClock) AfterFunc(d time.Duration, f func()) Timer {
+	c.now = c.now.Add(d)
+	f()
+	return nil
+}
+
+func TestSetClock(t *testing.T) {
+	t1, _ := time.Parse(time.RFC3339Nano, "2006-01-02T15:04:11.111111111Z")
+	c := &testClock{now: t1}
+	prev := SetClock(c)
+	defer SetClock(prev)
+
+	assert.Equal(t, Now(), t1)
+	called := false
+	AfterFunc(time.Minute, func() { called = true })
+	assert.True(t, called)
+	assert.Equal(t, Now(), t1.Add(time.Minute))
+
+	SetClock(nil)
+	assert.NotEqual(t, Now(), t1.Add(time.Minute))
+}
@@ -72,6 +72,11 @@ func SetFlag(flags, mask int) int {
 	return flags
 }
 
+func UnsetFlag(flags, mask int) int {
+	flags = flags & ^mask
+	return flags
+}
+
 func IsFlagSet(flags, mask int) bool {
 	return flags&mask == mask
 }
@@ -27,6 +27,9 @@ func TestSetFlags(t *testing.T) {
 	assert.Equal(t, flags, 0xa)
 	assert.True(t, IsFlagSet(flags, 0x2))
 	assert.False(t, IsFlagSet(flags, 0x4))
+	flags = UnsetFlag(flags, 0x2)
+	assert.Equal(t, flags, 0x8)
+	assert.False(t, IsFlagSet(flags, 0x2))
 }
 
 func TestRandomPeerID(t *testing.T) {
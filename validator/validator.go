@@ -87,6 +87,14 @@ func (val *Validator) Decode(bs []byte) error {
 	return cbor.Unmarshal(bs, &val.data)
 }
 
+func (val *Validator) MarshalCBOR() ([]byte, error) {
+	return cbor.Marshal(val.data)
+}
+
+func (val *Validator) UnmarshalCBOR(bs []byte) error {
+	return cbor.Unmarshal(bs, &val.data)
+}
+
 func (val Validator) MarshalJSON() ([]byte, error) {
 	return json.Marshal(val.data)
 }
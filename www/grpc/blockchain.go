@@ -46,3 +46,32 @@ func (zs *zarbServer) GetNetworkInfo(ctx context.Context, request *zarb.NetworkI
 		Peers:  rps,
 	}, nil
 }
+
+func (zs *zarbServer) GetSyncInfo(ctx context.Context, request *zarb.SyncInfoRequest) (*zarb.SyncInfoResponse, error) {
+	p := zs.sync.DownloadProgress()
+
+	return &zarb.SyncInfoResponse{
+		Downloading:     p.Downloading,
+		Height:          int64(p.Height),
+		TargetHeight:    int64(p.TargetHeight),
+		BlocksPerSecond: p.BlocksPerSecond,
+		EtaSeconds:      int64(p.ETA.Seconds()),
+	}, nil
+}
+
+func (zs *zarbServer) GetAddressBook(ctx context.Context, request *zarb.AddressBookRequest) (*zarb.AddressBookResponse, error) {
+	entries := zs.sync.AddressBook()
+	peers := make([]*zarb.KnownPeer, 0, len(entries))
+	for _, e := range entries {
+		peers = append(peers, &zarb.KnownPeer{
+			PeerId:    e.PeerID.String(),
+			Addresses: e.Addresses,
+			LastSeen:  e.LastSeen.Unix(),
+			Score:     int32(e.Score),
+		})
+	}
+
+	return &zarb.AddressBookResponse{
+		Peers: peers,
+	}, nil
+}
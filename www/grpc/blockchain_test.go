@@ -2,9 +2,13 @@ package grpc
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/zarbchain/zarb-go/block"
+	"github.com/zarbchain/zarb-go/network"
+	"github.com/zarbchain/zarb-go/sync/scheduler"
+	"github.com/zarbchain/zarb-go/util"
 	zarb "github.com/zarbchain/zarb-go/www/grpc/proto"
 )
 
@@ -80,3 +84,56 @@ func TestGetBlockchainInfo(t *testing.T) {
 
 	assert.Nil(t, err, "Error closing connection")
 }
+
+func TestGetSyncInfo(t *testing.T) {
+	conn, client := callServer(t)
+
+	t.Run("Should return not downloading", func(t *testing.T) {
+		res, err := client.GetSyncInfo(tCtx, &zarb.SyncInfoRequest{})
+		assert.NoError(t, err)
+		assert.False(t, res.Downloading)
+	})
+
+	tMockSync.Progress = scheduler.Progress{
+		Downloading:     true,
+		Height:          100,
+		TargetHeight:    1100,
+		BlocksPerSecond: 10,
+		ETA:             100 * time.Second,
+	}
+
+	t.Run("Should return the download progress", func(t *testing.T) {
+		res, err := client.GetSyncInfo(tCtx, &zarb.SyncInfoRequest{})
+		assert.NoError(t, err)
+		assert.True(t, res.Downloading)
+		assert.Equal(t, res.Height, int64(100))
+		assert.Equal(t, res.TargetHeight, int64(1100))
+		assert.Equal(t, res.BlocksPerSecond, float64(10))
+		assert.Equal(t, res.EtaSeconds, int64(100))
+	})
+
+	assert.Nil(t, conn.Close(), "Error closing connection")
+}
+
+func TestGetAddressBook(t *testing.T) {
+	conn, client := callServer(t)
+
+	pid := util.RandomPeerID()
+	lastSeen := util.Now()
+	tMockSync.Addresses = []network.AddressInfo{{
+		PeerID:    pid,
+		Addresses: []string{"/ip4/1.2.3.4/tcp/21777"},
+		LastSeen:  lastSeen,
+		Score:     3,
+	}}
+
+	res, err := client.GetAddressBook(tCtx, &zarb.AddressBookRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, len(res.Peers), 1)
+	assert.Equal(t, res.Peers[0].PeerId, pid.String())
+	assert.Equal(t, res.Peers[0].Addresses, []string{"/ip4/1.2.3.4/tcp/21777"})
+	assert.Equal(t, res.Peers[0].LastSeen, lastSeen.Unix())
+	assert.Equal(t, res.Peers[0].Score, int32(3))
+
+	assert.Nil(t, conn.Close(), "Error closing connection")
+}
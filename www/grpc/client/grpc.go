@@ -63,6 +63,15 @@ func SendTx(rpcEndpoint string, payload []byte) (string, error) {
 	return res.Id, nil
 }
 
+func GetConsensusInfo(rpcEndpoint string) (*zarb.ConsensusInfoResponse, error) {
+	client, err := GetRPCClient(rpcEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.GetConsensusInfo(context.Background(), &zarb.ConsensusInfoRequest{})
+}
+
 func GetRPCClient(rpcEndpoint string) (zarb.ZarbClient, error) {
 	if zclient != nil {
 		return zclient, nil
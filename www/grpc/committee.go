@@ -0,0 +1,94 @@
+package grpc
+
+import (
+	"context"
+
+	zarb "github.com/zarbchain/zarb-go/www/grpc/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	maxScheduledRounds       = 100
+	maxCommitteeChangesRange = 1000
+)
+
+func (zs *zarbServer) GetCommittee(ctx context.Context, request *zarb.CommitteeRequest) (*zarb.CommitteeResponse, error) {
+	vals := zs.state.CommitteeValidators()
+	res := &zarb.CommitteeResponse{
+		Height:     int64(zs.state.LastBlockHeight() + 1),
+		TotalStake: zs.state.CommitteeStake(),
+		Proposer:   zs.state.Proposer(0).Address().String(),
+		Validators: make([]*zarb.Validator, 0, len(vals)),
+	}
+	for _, v := range vals {
+		res.TotalPower += v.Power()
+		res.Validators = append(res.Validators, validatorToProto(v))
+	}
+	return res, nil
+}
+
+func (zs *zarbServer) GetProposerSchedule(ctx context.Context, request *zarb.ProposerScheduleRequest) (*zarb.ProposerScheduleResponse, error) {
+	rounds := int(request.Rounds)
+	if rounds == 0 {
+		rounds = len(zs.state.CommitteeValidators())
+	}
+	if rounds < 0 || rounds > maxScheduledRounds {
+		return nil, status.Errorf(codes.InvalidArgument, "rounds should be between 1 and %v", maxScheduledRounds)
+	}
+
+	res := &zarb.ProposerScheduleResponse{
+		Height:    int64(zs.state.LastBlockHeight() + 1),
+		Proposers: make([]*zarb.ScheduledProposer, 0, rounds),
+	}
+	for r := 0; r < rounds; r++ {
+		p := zs.state.Proposer(r)
+		res.Proposers = append(res.Proposers, &zarb.ScheduledProposer{
+			Round:   int32(r),
+			Address: p.Address().String(),
+			Number:  int32(p.Number()),
+		})
+	}
+	return res, nil
+}
+
+func (zs *zarbServer) GetCommitteeChanges(ctx context.Context, request *zarb.CommitteeChangesRequest) (*zarb.CommitteeChangesResponse, error) {
+	from := int(request.FromHeight)
+	to := int(request.ToHeight)
+	if to == 0 {
+		to = zs.state.LastBlockHeight()
+	}
+	if from < 1 || to < from {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid height range: %v-%v", from, to)
+	}
+	if to-from >= maxCommitteeChangesRange {
+		return nil, status.Errorf(codes.InvalidArgument, "height range is more than %v", maxCommitteeChangesRange)
+	}
+
+	res := &zarb.CommitteeChangesResponse{
+		Changes: make([]*zarb.CommitteeChange, 0),
+	}
+	for h := from; h <= to; h++ {
+		c := zs.state.CommitteeChange(h)
+		if c == nil {
+			continue
+		}
+		change := &zarb.CommitteeChange{
+			Height: int64(h),
+			Joined: make([]string, 0, len(c.Joined)),
+			Left:   make([]string, 0, len(c.Left)),
+		}
+		for _, num := range c.Joined {
+			if val := zs.state.ValidatorByNumber(num); val != nil {
+				change.Joined = append(change.Joined, val.Address().String())
+			}
+		}
+		for _, num := range c.Left {
+			if val := zs.state.ValidatorByNumber(num); val != nil {
+				change.Left = append(change.Left, val.Address().String())
+			}
+		}
+		res.Changes = append(res.Changes, change)
+	}
+	return res, nil
+}
@@ -0,0 +1,102 @@
+package grpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zarbchain/zarb-go/store"
+	"github.com/zarbchain/zarb-go/validator"
+	zarb "github.com/zarbchain/zarb-go/www/grpc/proto"
+)
+
+func TestGetCommittee(t *testing.T) {
+	conn, client := callServer(t)
+	defer conn.Close()
+
+	res, err := client.GetCommittee(tCtx, &zarb.CommitteeRequest{})
+	require.NoError(t, err)
+
+	vals := tMockState.CommitteeValidators()
+	assert.Equal(t, res.Height, int64(tMockState.LastBlockHeight()+1))
+	assert.Equal(t, res.TotalStake, tMockState.CommitteeStake())
+	assert.Equal(t, res.Proposer, tMockState.Proposer(0).Address().String())
+	require.Len(t, res.Validators, len(vals))
+	totalPower := int64(0)
+	for i, v := range vals {
+		totalPower += v.Power()
+		assert.Equal(t, res.Validators[i].Address, v.Address().String())
+		assert.Equal(t, res.Validators[i].Stake, v.Stake())
+		assert.Equal(t, res.Validators[i].LastJoinedHeight, int32(v.LastJoinedHeight()))
+	}
+	assert.Equal(t, res.TotalPower, totalPower)
+}
+
+func TestGetProposerSchedule(t *testing.T) {
+	conn, client := callServer(t)
+	defer conn.Close()
+
+	t.Run("Invalid rounds", func(t *testing.T) {
+		_, err := client.GetProposerSchedule(tCtx, &zarb.ProposerScheduleRequest{Rounds: -1})
+		assert.Error(t, err)
+		_, err = client.GetProposerSchedule(tCtx, &zarb.ProposerScheduleRequest{Rounds: maxScheduledRounds + 1})
+		assert.Error(t, err)
+	})
+
+	t.Run("Default rounds is the committee size", func(t *testing.T) {
+		res, err := client.GetProposerSchedule(tCtx, &zarb.ProposerScheduleRequest{})
+		require.NoError(t, err)
+		assert.Len(t, res.Proposers, len(tMockState.CommitteeValidators()))
+	})
+
+	t.Run("Proposers rotate", func(t *testing.T) {
+		res, err := client.GetProposerSchedule(tCtx, &zarb.ProposerScheduleRequest{Rounds: 6})
+		require.NoError(t, err)
+		require.Len(t, res.Proposers, 6)
+		for r, p := range res.Proposers {
+			assert.Equal(t, p.Round, int32(r))
+			assert.Equal(t, p.Address, tMockState.Proposer(r).Address().String())
+			assert.Equal(t, p.Number, int32(tMockState.Proposer(r).Number()))
+		}
+		assert.Equal(t, res.Proposers[0].Address, res.Proposers[4].Address)
+	})
+}
+
+func TestGetCommitteeChanges(t *testing.T) {
+	conn, client := callServer(t)
+	defer conn.Close()
+
+	val1, _ := validator.GenerateTestValidator(100)
+	val2, _ := validator.GenerateTestValidator(101)
+	tMockState.Store.UpdateValidator(val1)
+	tMockState.Store.UpdateValidator(val2)
+	tMockState.Store.SaveCommitteeChange(5, &store.CommitteeChange{Joined: []int{100}})
+	tMockState.Store.SaveCommitteeChange(8, &store.CommitteeChange{Joined: []int{101}, Left: []int{100}})
+
+	t.Run("Invalid range", func(t *testing.T) {
+		_, err := client.GetCommitteeChanges(tCtx, &zarb.CommitteeChangesRequest{FromHeight: 0, ToHeight: 10})
+		assert.Error(t, err)
+		_, err = client.GetCommitteeChanges(tCtx, &zarb.CommitteeChangesRequest{FromHeight: 10, ToHeight: 9})
+		assert.Error(t, err)
+		_, err = client.GetCommitteeChanges(tCtx, &zarb.CommitteeChangesRequest{FromHeight: 1, ToHeight: maxCommitteeChangesRange + 1})
+		assert.Error(t, err)
+	})
+
+	t.Run("List changes", func(t *testing.T) {
+		res, err := client.GetCommitteeChanges(tCtx, &zarb.CommitteeChangesRequest{FromHeight: 1, ToHeight: 10})
+		require.NoError(t, err)
+		require.Len(t, res.Changes, 2)
+		assert.Equal(t, res.Changes[0].Height, int64(5))
+		assert.Equal(t, res.Changes[0].Joined, []string{val1.Address().String()})
+		assert.Empty(t, res.Changes[0].Left)
+		assert.Equal(t, res.Changes[1].Height, int64(8))
+		assert.Equal(t, res.Changes[1].Joined, []string{val2.Address().String()})
+		assert.Equal(t, res.Changes[1].Left, []string{val1.Address().String()})
+	})
+
+	t.Run("No change", func(t *testing.T) {
+		res, err := client.GetCommitteeChanges(tCtx, &zarb.CommitteeChangesRequest{FromHeight: 6, ToHeight: 7})
+		require.NoError(t, err)
+		assert.Empty(t, res.Changes)
+	})
+}
@@ -0,0 +1,95 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/zarbchain/zarb-go/consensus/event"
+	"github.com/zarbchain/zarb-go/crypto"
+	zarb "github.com/zarbchain/zarb-go/www/grpc/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// eventBufferSize is the number of the events that are kept for a slow client before dropping them
+const eventBufferSize = 1024
+
+func (zs *zarbServer) GetConsensusEvents(request *zarb.ConsensusEventsRequest, stream zarb.Zarb_GetConsensusEventsServer) error {
+	sub := zs.consensus.SubscribeEvents(eventBufferSize)
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+
+		case e, ok := <-sub.Events():
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(consensusEventToProto(e)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func consensusEventToProto(e event.Event) *zarb.ConsensusEvent {
+	pe := &zarb.ConsensusEvent{
+		Type:             zarb.ConsensusEventType(e.Type),
+		Height:           int64(e.Height),
+		Round:            int32(e.Round),
+		Time:             timestamppb.New(e.Time),
+		HeightDurationMs: e.HeightDuration.Milliseconds(),
+		RoundDurationMs:  e.RoundDuration.Milliseconds(),
+	}
+	if !e.BlockHash.IsUndef() {
+		pe.BlockHash = e.BlockHash.String()
+	}
+	if e.VoteType.IsValid() {
+		pe.VoteType = e.VoteType.String()
+	}
+	if !e.Address.EqualsTo(crypto.Address{}) {
+		pe.Address = e.Address.String()
+	}
+	return pe
+}
+
+func (zs *zarbServer) GetConsensusInfo(ctx context.Context, request *zarb.ConsensusInfoRequest) (*zarb.ConsensusInfoResponse, error) {
+	info := zs.consensus.Info()
+
+	res := &zarb.ConsensusInfoResponse{
+		Height: int64(info.Height),
+		Round:  int32(info.Round),
+		Step:   info.Step,
+		Rounds: make([]*zarb.RoundVotes, 0, len(info.Rounds)),
+	}
+	if !info.Proposer.EqualsTo(crypto.Address{}) {
+		res.Proposer = info.Proposer.String()
+	}
+	if !info.ProposalHash.IsUndef() {
+		res.ProposalHash = info.ProposalHash.String()
+	}
+	for _, ri := range info.Rounds {
+		rv := &zarb.RoundVotes{
+			Round:   int32(ri.Round),
+			Tallies: make([]*zarb.VoteTally, 0, len(ri.Tallies)),
+		}
+		for _, t := range ri.Tallies {
+			tally := &zarb.VoteTally{
+				Type:        t.Type.String(),
+				TotalPower:  t.TotalPower,
+				VotedPower:  t.VotedPower,
+				BlockPowers: make(map[string]int64),
+				Missing:     make([]string, 0, len(t.Missing)),
+			}
+			for h, p := range t.BlockPowers {
+				tally.BlockPowers[h.String()] = p
+			}
+			for _, addr := range t.Missing {
+				tally.Missing = append(tally.Missing, addr.String())
+			}
+			rv.Tallies = append(rv.Tallies, tally)
+		}
+		res.Rounds = append(res.Rounds, rv)
+	}
+	return res, nil
+}
@@ -0,0 +1,111 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zarbchain/zarb-go/consensus/event"
+	"github.com/zarbchain/zarb-go/consensus/proposal"
+	"github.com/zarbchain/zarb-go/consensus/vote"
+	"github.com/zarbchain/zarb-go/crypto"
+	"github.com/zarbchain/zarb-go/util"
+	zarb "github.com/zarbchain/zarb-go/www/grpc/proto"
+)
+
+func TestGetConsensusEvents(t *testing.T) {
+	conn, client := callServer(t)
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(tCtx)
+	stream, err := client.GetConsensusEvents(ctx, &zarb.ConsensusEventsRequest{})
+	require.NoError(t, err)
+
+	// Waiting for the server to subscribe
+	for i := 0; i < 20 && tMockConsensus.EventBus.NumSubscribers() == 0; i++ {
+		time.Sleep(50 * time.Millisecond)
+	}
+	require.Equal(t, tMockConsensus.EventBus.NumSubscribers(), 1)
+
+	addr, _, _ := crypto.GenerateTestKeyPair()
+	hash := crypto.GenerateTestHash()
+	tMockConsensus.EventBus.Publish(event.Event{
+		Type:           event.TypeNewHeight,
+		Height:         12,
+		Time:           util.Now(),
+		HeightDuration: 0,
+	})
+	tMockConsensus.EventBus.Publish(event.Event{
+		Type:           event.TypeVoteAdded,
+		Height:         12,
+		Round:          1,
+		Time:           util.Now(),
+		HeightDuration: 3 * time.Second,
+		RoundDuration:  time.Second,
+		BlockHash:      hash,
+		VoteType:       vote.VoteTypePrepare,
+		Address:        addr,
+	})
+
+	e, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, e.Type, zarb.ConsensusEventType_CONSENSUS_EVENT_NEW_HEIGHT)
+	assert.Equal(t, e.Height, int64(12))
+	assert.Empty(t, e.BlockHash)
+	assert.Empty(t, e.VoteType)
+	assert.Empty(t, e.Address)
+
+	e, err = stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, e.Type, zarb.ConsensusEventType_CONSENSUS_EVENT_VOTE_ADDED)
+	assert.Equal(t, e.Round, int32(1))
+	assert.Equal(t, e.HeightDurationMs, int64(3000))
+	assert.Equal(t, e.RoundDurationMs, int64(1000))
+	assert.Equal(t, e.BlockHash, hash.String())
+	assert.Equal(t, e.VoteType, vote.VoteTypePrepare.String())
+	assert.Equal(t, e.Address, addr.String())
+
+	// The subscription is closed after the client cancels the stream
+	cancel()
+	for i := 0; i < 20 && tMockConsensus.EventBus.NumSubscribers() != 0; i++ {
+		time.Sleep(50 * time.Millisecond)
+	}
+	assert.Zero(t, tMockConsensus.EventBus.NumSubscribers())
+}
+
+func TestGetConsensusInfo(t *testing.T) {
+	conn, client := callServer(t)
+	defer conn.Close()
+
+	p, _ := proposal.GenerateTestProposal(tMockState.LastBlockHeight()+1, 0)
+	v := vote.NewVote(vote.VoteTypePrepare, tMockState.LastBlockHeight()+1, 0, p.Block().Hash(), tSigners[0].Address())
+	tSigners[0].SignMsg(v)
+	tMockConsensus.SetProposal(p)
+	tMockConsensus.AddVote(v)
+	defer func() {
+		tMockConsensus.Proposal = nil
+		tMockConsensus.Votes = nil
+	}()
+
+	res, err := client.GetConsensusInfo(tCtx, &zarb.ConsensusInfoRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, res.Height, int64(tMockState.LastBlockHeight()+1))
+	assert.Equal(t, res.Round, int32(0))
+	assert.Equal(t, res.Proposer, tMockState.Proposer(0).Address().String())
+	assert.Equal(t, res.ProposalHash, p.Block().Hash().String())
+	require.Len(t, res.Rounds, 1)
+	require.Len(t, res.Rounds[0].Tallies, 3)
+
+	prepare := res.Rounds[0].Tallies[0]
+	assert.Equal(t, prepare.Type, vote.VoteTypePrepare.String())
+	assert.Equal(t, prepare.BlockPowers[p.Block().Hash().String()], prepare.VotedPower)
+	assert.Len(t, prepare.Missing, len(tSigners)-1)
+	assert.NotContains(t, prepare.Missing, tSigners[0].Address().String())
+
+	precommit := res.Rounds[0].Tallies[1]
+	assert.Zero(t, precommit.VotedPower)
+	assert.Empty(t, precommit.BlockPowers)
+	assert.Len(t, precommit.Missing, len(tSigners))
+}
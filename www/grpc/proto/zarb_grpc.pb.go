@@ -27,7 +27,14 @@ type ZarbClient interface {
 	GetValidatorByNumber(ctx context.Context, in *ValidatorByNumberRequest, opts ...grpc.CallOption) (*ValidatorResponse, error)
 	GetBlockchainInfo(ctx context.Context, in *BlockchainInfoRequest, opts ...grpc.CallOption) (*BlockchainInfoResponse, error)
 	GetNetworkInfo(ctx context.Context, in *NetworkInfoRequest, opts ...grpc.CallOption) (*NetworkInfoResponse, error)
+	GetAddressBook(ctx context.Context, in *AddressBookRequest, opts ...grpc.CallOption) (*AddressBookResponse, error)
+	GetSyncInfo(ctx context.Context, in *SyncInfoRequest, opts ...grpc.CallOption) (*SyncInfoResponse, error)
 	SendRawTransaction(ctx context.Context, in *SendRawTransactionRequest, opts ...grpc.CallOption) (*SendRawTransactionResponse, error)
+	GetConsensusEvents(ctx context.Context, in *ConsensusEventsRequest, opts ...grpc.CallOption) (Zarb_GetConsensusEventsClient, error)
+	GetConsensusInfo(ctx context.Context, in *ConsensusInfoRequest, opts ...grpc.CallOption) (*ConsensusInfoResponse, error)
+	GetCommittee(ctx context.Context, in *CommitteeRequest, opts ...grpc.CallOption) (*CommitteeResponse, error)
+	GetProposerSchedule(ctx context.Context, in *ProposerScheduleRequest, opts ...grpc.CallOption) (*ProposerScheduleResponse, error)
+	GetCommitteeChanges(ctx context.Context, in *CommitteeChangesRequest, opts ...grpc.CallOption) (*CommitteeChangesResponse, error)
 }
 
 type zarbClient struct {
@@ -119,6 +126,24 @@ func (c *zarbClient) GetNetworkInfo(ctx context.Context, in *NetworkInfoRequest,
 	return out, nil
 }
 
+func (c *zarbClient) GetAddressBook(ctx context.Context, in *AddressBookRequest, opts ...grpc.CallOption) (*AddressBookResponse, error) {
+	out := new(AddressBookResponse)
+	err := c.cc.Invoke(ctx, "/zarb.Zarb/GetAddressBook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *zarbClient) GetSyncInfo(ctx context.Context, in *SyncInfoRequest, opts ...grpc.CallOption) (*SyncInfoResponse, error) {
+	out := new(SyncInfoResponse)
+	err := c.cc.Invoke(ctx, "/zarb.Zarb/GetSyncInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *zarbClient) SendRawTransaction(ctx context.Context, in *SendRawTransactionRequest, opts ...grpc.CallOption) (*SendRawTransactionResponse, error) {
 	out := new(SendRawTransactionResponse)
 	err := c.cc.Invoke(ctx, "/zarb.Zarb/SendRawTransaction", in, out, opts...)
@@ -128,6 +153,74 @@ func (c *zarbClient) SendRawTransaction(ctx context.Context, in *SendRawTransact
 	return out, nil
 }
 
+func (c *zarbClient) GetConsensusEvents(ctx context.Context, in *ConsensusEventsRequest, opts ...grpc.CallOption) (Zarb_GetConsensusEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Zarb_ServiceDesc.Streams[0], "/zarb.Zarb/GetConsensusEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &zarbGetConsensusEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Zarb_GetConsensusEventsClient interface {
+	Recv() (*ConsensusEvent, error)
+	grpc.ClientStream
+}
+
+type zarbGetConsensusEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *zarbGetConsensusEventsClient) Recv() (*ConsensusEvent, error) {
+	m := new(ConsensusEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *zarbClient) GetConsensusInfo(ctx context.Context, in *ConsensusInfoRequest, opts ...grpc.CallOption) (*ConsensusInfoResponse, error) {
+	out := new(ConsensusInfoResponse)
+	err := c.cc.Invoke(ctx, "/zarb.Zarb/GetConsensusInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *zarbClient) GetCommittee(ctx context.Context, in *CommitteeRequest, opts ...grpc.CallOption) (*CommitteeResponse, error) {
+	out := new(CommitteeResponse)
+	err := c.cc.Invoke(ctx, "/zarb.Zarb/GetCommittee", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *zarbClient) GetProposerSchedule(ctx context.Context, in *ProposerScheduleRequest, opts ...grpc.CallOption) (*ProposerScheduleResponse, error) {
+	out := new(ProposerScheduleResponse)
+	err := c.cc.Invoke(ctx, "/zarb.Zarb/GetProposerSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *zarbClient) GetCommitteeChanges(ctx context.Context, in *CommitteeChangesRequest, opts ...grpc.CallOption) (*CommitteeChangesResponse, error) {
+	out := new(CommitteeChangesResponse)
+	err := c.cc.Invoke(ctx, "/zarb.Zarb/GetCommitteeChanges", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ZarbServer is the server API for Zarb service.
 // All implementations should embed UnimplementedZarbServer
 // for forward compatibility
@@ -141,7 +234,14 @@ type ZarbServer interface {
 	GetValidatorByNumber(context.Context, *ValidatorByNumberRequest) (*ValidatorResponse, error)
 	GetBlockchainInfo(context.Context, *BlockchainInfoRequest) (*BlockchainInfoResponse, error)
 	GetNetworkInfo(context.Context, *NetworkInfoRequest) (*NetworkInfoResponse, error)
+	GetAddressBook(context.Context, *AddressBookRequest) (*AddressBookResponse, error)
+	GetSyncInfo(context.Context, *SyncInfoRequest) (*SyncInfoResponse, error)
 	SendRawTransaction(context.Context, *SendRawTransactionRequest) (*SendRawTransactionResponse, error)
+	GetConsensusEvents(*ConsensusEventsRequest, Zarb_GetConsensusEventsServer) error
+	GetConsensusInfo(context.Context, *ConsensusInfoRequest) (*ConsensusInfoResponse, error)
+	GetCommittee(context.Context, *CommitteeRequest) (*CommitteeResponse, error)
+	GetProposerSchedule(context.Context, *ProposerScheduleRequest) (*ProposerScheduleResponse, error)
+	GetCommitteeChanges(context.Context, *CommitteeChangesRequest) (*CommitteeChangesResponse, error)
 }
 
 // UnimplementedZarbServer should be embedded to have forward compatible implementations.
@@ -175,9 +275,30 @@ func (UnimplementedZarbServer) GetBlockchainInfo(context.Context, *BlockchainInf
 func (UnimplementedZarbServer) GetNetworkInfo(context.Context, *NetworkInfoRequest) (*NetworkInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetNetworkInfo not implemented")
 }
+func (UnimplementedZarbServer) GetAddressBook(context.Context, *AddressBookRequest) (*AddressBookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAddressBook not implemented")
+}
+func (UnimplementedZarbServer) GetSyncInfo(context.Context, *SyncInfoRequest) (*SyncInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSyncInfo not implemented")
+}
 func (UnimplementedZarbServer) SendRawTransaction(context.Context, *SendRawTransactionRequest) (*SendRawTransactionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SendRawTransaction not implemented")
 }
+func (UnimplementedZarbServer) GetConsensusEvents(*ConsensusEventsRequest, Zarb_GetConsensusEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetConsensusEvents not implemented")
+}
+func (UnimplementedZarbServer) GetConsensusInfo(context.Context, *ConsensusInfoRequest) (*ConsensusInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConsensusInfo not implemented")
+}
+func (UnimplementedZarbServer) GetCommittee(context.Context, *CommitteeRequest) (*CommitteeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCommittee not implemented")
+}
+func (UnimplementedZarbServer) GetProposerSchedule(context.Context, *ProposerScheduleRequest) (*ProposerScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProposerSchedule not implemented")
+}
+func (UnimplementedZarbServer) GetCommitteeChanges(context.Context, *CommitteeChangesRequest) (*CommitteeChangesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCommitteeChanges not implemented")
+}
 
 // UnsafeZarbServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to ZarbServer will
@@ -352,6 +473,42 @@ func _Zarb_GetNetworkInfo_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Zarb_GetAddressBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddressBookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ZarbServer).GetAddressBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/zarb.Zarb/GetAddressBook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ZarbServer).GetAddressBook(ctx, req.(*AddressBookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Zarb_GetSyncInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ZarbServer).GetSyncInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/zarb.Zarb/GetSyncInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ZarbServer).GetSyncInfo(ctx, req.(*SyncInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Zarb_SendRawTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SendRawTransactionRequest)
 	if err := dec(in); err != nil {
@@ -370,6 +527,99 @@ func _Zarb_SendRawTransaction_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Zarb_GetConsensusEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ConsensusEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ZarbServer).GetConsensusEvents(m, &zarbGetConsensusEventsServer{stream})
+}
+
+type Zarb_GetConsensusEventsServer interface {
+	Send(*ConsensusEvent) error
+	grpc.ServerStream
+}
+
+type zarbGetConsensusEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *zarbGetConsensusEventsServer) Send(m *ConsensusEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Zarb_GetConsensusInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConsensusInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ZarbServer).GetConsensusInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/zarb.Zarb/GetConsensusInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ZarbServer).GetConsensusInfo(ctx, req.(*ConsensusInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Zarb_GetCommittee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommitteeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ZarbServer).GetCommittee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/zarb.Zarb/GetCommittee",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ZarbServer).GetCommittee(ctx, req.(*CommitteeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Zarb_GetProposerSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProposerScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ZarbServer).GetProposerSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/zarb.Zarb/GetProposerSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ZarbServer).GetProposerSchedule(ctx, req.(*ProposerScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Zarb_GetCommitteeChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommitteeChangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ZarbServer).GetCommitteeChanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/zarb.Zarb/GetCommitteeChanges",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ZarbServer).GetCommitteeChanges(ctx, req.(*CommitteeChangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Zarb_ServiceDesc is the grpc.ServiceDesc for Zarb service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -413,11 +663,41 @@ var Zarb_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetNetworkInfo",
 			Handler:    _Zarb_GetNetworkInfo_Handler,
 		},
+		{
+			MethodName: "GetAddressBook",
+			Handler:    _Zarb_GetAddressBook_Handler,
+		},
+		{
+			MethodName: "GetSyncInfo",
+			Handler:    _Zarb_GetSyncInfo_Handler,
+		},
 		{
 			MethodName: "SendRawTransaction",
 			Handler:    _Zarb_SendRawTransaction_Handler,
 		},
+		{
+			MethodName: "GetConsensusInfo",
+			Handler:    _Zarb_GetConsensusInfo_Handler,
+		},
+		{
+			MethodName: "GetCommittee",
+			Handler:    _Zarb_GetCommittee_Handler,
+		},
+		{
+			MethodName: "GetProposerSchedule",
+			Handler:    _Zarb_GetProposerSchedule_Handler,
+		},
+		{
+			MethodName: "GetCommitteeChanges",
+			Handler:    _Zarb_GetCommitteeChanges_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetConsensusEvents",
+			Handler:       _Zarb_GetConsensusEvents_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "zarb.proto",
 }
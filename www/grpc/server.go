@@ -4,6 +4,7 @@ import (
 	"context"
 	"net"
 
+	"github.com/zarbchain/zarb-go/consensus"
 	"github.com/zarbchain/zarb-go/logger"
 	"github.com/zarbchain/zarb-go/state"
 	"github.com/zarbchain/zarb-go/sync"
@@ -14,29 +15,32 @@ import (
 type zarbServer struct {
 	zarb.UnimplementedZarbServer
 
-	state  state.Facade
-	sync   sync.Synchronizer
-	logger *logger.Logger
+	state     state.Facade
+	sync      sync.Synchronizer
+	consensus consensus.Reader
+	logger    *logger.Logger
 }
 
 type Server struct {
-	ctx      context.Context
-	config   *Config
-	listener net.Listener
-	grpc     *grpc.Server
-	state    state.Facade
-	sync     sync.Synchronizer
-	logger   *logger.Logger
+	ctx       context.Context
+	config    *Config
+	listener  net.Listener
+	grpc      *grpc.Server
+	state     state.Facade
+	sync      sync.Synchronizer
+	consensus consensus.Reader
+	logger    *logger.Logger
 }
 
-func NewServer(conf *Config, state state.Facade, sync sync.Synchronizer) (*Server, error) {
+func NewServer(conf *Config, state state.Facade, sync sync.Synchronizer, consensus consensus.Reader) (*Server, error) {
 
 	return &Server{
-		ctx:    context.Background(),
-		config: conf,
-		state:  state,
-		sync:   sync,
-		logger: logger.NewLogger("_grpc", nil),
+		ctx:       context.Background(),
+		config:    conf,
+		state:     state,
+		sync:      sync,
+		consensus: consensus,
+		logger:    logger.NewLogger("_grpc", nil),
 	}, nil
 }
 
@@ -47,9 +51,10 @@ func (s *Server) StartServer() error {
 
 	grpc := grpc.NewServer()
 	server := &zarbServer{
-		state:  s.state,
-		sync:   s.sync,
-		logger: s.logger,
+		state:     s.state,
+		sync:      s.sync,
+		consensus: s.consensus,
+		logger:    s.logger,
 	}
 	zarb.RegisterZarbServer(grpc, server)
 
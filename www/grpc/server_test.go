@@ -7,6 +7,8 @@ import (
 	"testing"
 
 	"github.com/zarbchain/zarb-go/committee"
+	"github.com/zarbchain/zarb-go/consensus"
+	"github.com/zarbchain/zarb-go/crypto"
 	"github.com/zarbchain/zarb-go/logger"
 	"github.com/zarbchain/zarb-go/state"
 	"github.com/zarbchain/zarb-go/sync"
@@ -17,6 +19,8 @@ import (
 
 var tMockState *state.MockState
 var tMockSync *sync.MockSync
+var tMockConsensus *consensus.MockConsensus
+var tSigners []crypto.Signer
 var tListener *bufconn.Listener
 var tCtx context.Context
 
@@ -25,17 +29,20 @@ func init() {
 
 	const bufSize = 1024 * 1024
 
-	committee, _ := committee.GenerateTestCommittee()
+	committee, signers := committee.GenerateTestCommittee()
+	tSigners = signers
 	tListener = bufconn.Listen(bufSize)
 	tMockState = state.MockingState(committee)
 	tMockSync = sync.MockingSync()
+	tMockConsensus = consensus.MockingConsensus(tMockState)
 	tCtx = context.Background()
 
 	s := grpc.NewServer()
 	server := &zarbServer{
-		state:  tMockState,
-		sync:   tMockSync,
-		logger: logger.NewLogger("_grpc", nil),
+		state:     tMockState,
+		sync:      tMockSync,
+		consensus: tMockConsensus,
+		logger:    logger.NewLogger("_grpc", nil),
 	}
 	zarb.RegisterZarbServer(s, server)
 	go func() {